@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/fake_orders"
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/logger"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"course-go-avito-SitnikovArtem06/pkg/kafka"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+)
+
+const TimeOut = 5
+
+const (
+	SinkNone  = "none"
+	SinkFile  = "file"
+	SinkKafka = "kafka"
+)
+
+type options struct {
+	httpAddr string
+	grpcAddr string
+	scenario string
+	sink     string
+	sinkFile string
+}
+
+func newSink(opts options) (fake_orders.Sink, error) {
+	switch opts.sink {
+	case SinkNone:
+		return nil, nil
+	case SinkFile:
+		return fake_orders.NewFileSink(opts.sinkFile)
+	case SinkKafka:
+		kcfg, saramaCfg, err := kafka.InitKafka()
+		if err != nil {
+			return nil, err
+		}
+		return fake_orders.NewKafkaSink(kcfg.Brokers, kcfg.Topic, saramaCfg)
+	default:
+		return nil, fmt.Errorf("unknown --sink %q, want %s, %s or %s", opts.sink, SinkNone, SinkFile, SinkKafka)
+	}
+}
+
+func run(ctx context.Context, opts options, loger logger.Logger) error {
+	store := order.NewMemoryGateway()
+
+	sc := &fake_orders.Scenario{}
+	if opts.scenario != "" {
+		var err error
+		if sc, err = fake_orders.LoadScenario(opts.scenario); err != nil {
+			return err
+		}
+	}
+	fake_orders.Seed(store, sc, time.Now().UTC())
+
+	sink, err := newSink(opts)
+	if err != nil {
+		return err
+	}
+	if sink != nil {
+		defer sink.Close()
+	}
+
+	lis, err := net.Listen("tcp", opts.grpcAddr)
+	if err != nil {
+		return fmt.Errorf("grpc listen: %w", err)
+	}
+
+	grpcSrv := grpc.NewServer()
+	pb.RegisterOrdersServiceServer(grpcSrv, fake_orders.NewOrdersServer(store))
+
+	httpSrv := &http.Server{
+		Addr:    opts.httpAddr,
+		Handler: fake_orders.Routes(store),
+	}
+
+	errCh := make(chan error, 3)
+
+	go func() {
+		loger.Log(fmt.Sprintf("fake-orders gRPC on %s", opts.grpcAddr))
+		if err := grpcSrv.Serve(lis); err != nil {
+			errCh <- fmt.Errorf("grpc: %w", err)
+		}
+	}()
+
+	go func() {
+		loger.Log(fmt.Sprintf("fake-orders HTTP on %s", opts.httpAddr))
+		if err := httpSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("http: %w", err)
+		}
+	}()
+
+	go func() {
+		if err := fake_orders.Play(ctx, store, sc, sink); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				errCh <- err
+			}
+			return
+		}
+		if len(sc.Steps) > 0 {
+			loger.Log("fake-orders scenario finished")
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		err = nil
+	case err = <-errCh:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), TimeOut*time.Second)
+	defer cancel()
+
+	_ = httpSrv.Shutdown(shutdownCtx)
+	grpcSrv.GracefulStop()
+
+	return err
+}
+
+func main() {
+	_ = godotenv.Load()
+
+	var opts options
+	pflag.StringVar(&opts.httpAddr, "http-addr", ":8090", "Address for the public order HTTP API")
+	pflag.StringVar(&opts.grpcAddr, "grpc-addr", ":9095", "Address for the OrdersService gRPC API")
+	pflag.StringVar(&opts.scenario, "scenario", "", "JSON scenario with seed orders and status steps")
+	pflag.StringVar(&opts.sink, "sink", SinkFile, "Where status events go: none, file or kafka")
+	pflag.StringVar(&opts.sinkFile, "sink-file", "order-events.jsonl", "JSONL file for --sink=file")
+	pflag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	loger := logger.NewLogger()
+
+	if err := run(ctx, opts, loger); err != nil {
+		loger.Log(fmt.Sprintf("fatal: %v", err))
+		os.Exit(1)
+	}
+}
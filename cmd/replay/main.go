@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/logger"
+	"course-go-avito-SitnikovArtem06/internal/replay"
+	"course-go-avito-SitnikovArtem06/internal/repository/courier_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/delivery_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/event_repository"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory"
+	"course-go-avito-SitnikovArtem06/internal/service/transport_factory"
+	"course-go-avito-SitnikovArtem06/internal/tx"
+	"course-go-avito-SitnikovArtem06/pkg/database"
+	"course-go-avito-SitnikovArtem06/pkg/kafka"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	file       string
+	partition  int32
+	fromOffset int64
+	toOffset   int64
+	fromTime   string
+	toTime     string
+	dryRun     bool
+}
+
+func (o options) rng() (replay.Range, error) {
+	rng := replay.Range{
+		Partition:  o.partition,
+		FromOffset: o.fromOffset,
+		ToOffset:   o.toOffset,
+	}
+
+	var err error
+	if o.fromTime != "" {
+		if rng.FromTime, err = time.Parse(time.RFC3339, o.fromTime); err != nil {
+			return rng, fmt.Errorf("invalid --from-time: %w", err)
+		}
+	}
+	if o.toTime != "" {
+		if rng.ToTime, err = time.Parse(time.RFC3339, o.toTime); err != nil {
+			return rng, fmt.Errorf("invalid --to-time: %w", err)
+		}
+	}
+
+	bounded := o.file != "" || rng.ToOffset >= 0 || !rng.ToTime.IsZero()
+	if !bounded {
+		return rng, fmt.Errorf("kafka replay needs --to-offset or --to-time")
+	}
+
+	return rng, nil
+}
+
+func run(ctx context.Context, opts options) error {
+	rng, err := opts.rng()
+	if err != nil {
+		return err
+	}
+
+	var src replay.Source
+	if opts.file != "" {
+		src = replay.NewFileSource(opts.file, rng)
+	} else {
+		kcfg, saramaCfg, err := kafka.InitKafka()
+		if err != nil {
+			return err
+		}
+		src = replay.NewKafkaSource(kcfg.Brokers, kcfg.Topic, saramaCfg, rng)
+	}
+
+	// Replay reads orders the way the worker does.
+	gatewayTransport := os.Getenv("WORKER_ORDER_GATEWAY_TRANSPORT")
+	if gatewayTransport == "" {
+		gatewayTransport = order.TransportHTTP
+	}
+
+	orderGateway, closeGateway, err := order.NewGatewayFromEnv(gatewayTransport)
+	if err != nil {
+		return fmt.Errorf("order gateway: %w", err)
+	}
+	defer closeGateway()
+
+	var replayer *replay.Replayer
+	if opts.dryRun {
+		// Do is never called in dry-run mode, so no database is needed.
+		replayer = replay.NewReplayer(order_status_factory.NewOrderStatusFactory(nil), orderGateway, nil, true)
+	} else {
+		dbpool, err := database.InitDb(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to connect to database: %w", err)
+		}
+		defer dbpool.Close()
+
+		txManager := tx.NewPgxTxManager(dbpool)
+		courierRepo := courier_repository.NewCourierRepo(txManager)
+		deliveryRepo := delivery_repository.NewDeliveryRepository(txManager)
+		assignService := assign_service.NewAssignService(txManager, deliveryRepo, courierRepo, transport_factory.NewTransportFactory())
+		assignNotifier := events.NewAssignNotifier(assignService, txManager, event_repository.NewEventRepository(txManager))
+
+		replayer = replay.NewReplayer(order_status_factory.NewOrderStatusFactory(assignNotifier), orderGateway, assignService, false)
+	}
+
+	summary, err := replayer.Run(ctx, src)
+	if summary != nil {
+		summary.Write(os.Stdout)
+	}
+	if err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+
+	return nil
+}
+
+func main() {
+	_ = godotenv.Load()
+
+	var opts options
+	pflag.StringVar(&opts.file, "file", "", "JSONL dump with one order event per line (reads Kafka when empty)")
+	pflag.Int32Var(&opts.partition, "partition", replay.AllPartitions, "Kafka partition to replay, -1 for all")
+	pflag.Int64Var(&opts.fromOffset, "from-offset", -1, "First offset to replay (line number for --file)")
+	pflag.Int64Var(&opts.toOffset, "to-offset", -1, "Last offset to replay, inclusive")
+	pflag.StringVar(&opts.fromTime, "from-time", "", "Replay events at or after this RFC3339 time")
+	pflag.StringVar(&opts.toTime, "to-time", "", "Replay events at or before this RFC3339 time")
+	pflag.BoolVar(&opts.dryRun, "dry-run", false, "Check events against the order service without changing deliveries")
+	pflag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	loger := logger.NewLogger()
+
+	if err := run(ctx, opts); err != nil {
+		loger.Log(fmt.Sprintf("fatal: %v", err))
+		os.Exit(1)
+	}
+}
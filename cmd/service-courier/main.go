@@ -2,20 +2,36 @@ package main
 
 import (
 	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
 	"course-go-avito-SitnikovArtem06/internal/handlers"
 	"course-go-avito-SitnikovArtem06/internal/handlers/assign_handler"
+	"course-go-avito-SitnikovArtem06/internal/handlers/courier_grpc"
 	"course-go-avito-SitnikovArtem06/internal/handlers/courier_handler"
+	"course-go-avito-SitnikovArtem06/internal/handlers/delivery_handler"
+	"course-go-avito-SitnikovArtem06/internal/handlers/event_handler"
+	"course-go-avito-SitnikovArtem06/internal/handlers/openapi"
+	"course-go-avito-SitnikovArtem06/internal/health"
 	logger "course-go-avito-SitnikovArtem06/internal/logger"
 	"course-go-avito-SitnikovArtem06/internal/middleware"
+	"course-go-avito-SitnikovArtem06/internal/middleware/idempotency"
 	"course-go-avito-SitnikovArtem06/internal/middleware/ratelimiter"
 	"course-go-avito-SitnikovArtem06/internal/observability"
+	"course-go-avito-SitnikovArtem06/internal/pb"
 	"course-go-avito-SitnikovArtem06/internal/repository/courier_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/cursor_repository"
 	"course-go-avito-SitnikovArtem06/internal/repository/delivery_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/event_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/idempotency_repository"
 	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"course-go-avito-SitnikovArtem06/internal/service/courier_service"
 	"course-go-avito-SitnikovArtem06/internal/service/delivery_monitor_service"
+	"course-go-avito-SitnikovArtem06/internal/service/delivery_service"
+	"course-go-avito-SitnikovArtem06/internal/service/order_monitor_service"
 	"course-go-avito-SitnikovArtem06/internal/service/transport_factory"
 	"course-go-avito-SitnikovArtem06/internal/tx"
+	"course-go-avito-SitnikovArtem06/migrations"
 	"course-go-avito-SitnikovArtem06/pkg/database"
 	"errors"
 	"fmt"
@@ -27,17 +43,21 @@ import (
 	"github.com/joho/godotenv"
 
 	"log"
+	"net"
 	"net/http"
 	"os"
 
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
 )
 
 const TimeOut = 5
 const Capacity = 5.0
 const Refill = 5.0
+const DefaultPollInterval = 5 * time.Second
+const DefaultGrpcPort = "50052"
 
-func run(ctx context.Context, port string, timesec int, loger logger.Logger) error {
+func run(ctx context.Context, port string, grpcPort string, timesec int, polling bool, pollTransport string, pollInterval time.Duration, pollPageSize int, loger logger.Logger) error {
 
 	dbpool, err := database.InitDb(ctx)
 
@@ -57,20 +77,26 @@ func run(ctx context.Context, port string, timesec int, loger logger.Logger) err
 	deliveryRepo := delivery_repository.NewDeliveryRepository(txManager)
 	assignService := assign_service.NewAssignService(txManager, deliveryRepo, repo, transportFactory)
 
-	assignHandler := assign_handler.NewAssignHandler(assignService)
+	// Every write appends to the event log, whichever process makes it; the
+	// listener brings the log into this instance's hub.
+	eventRepo := event_repository.NewEventRepository(txManager)
+	courierNotifier := events.NewCourierNotifier(courierService, txManager, eventRepo)
+	assignNotifier := events.NewAssignNotifier(assignService, txManager, eventRepo)
 
-	handler := courier_handler.NewHandler(courierService)
+	hub := events.NewHub(events.DefaultHistorySize)
+	listen := func(ctx context.Context, onNotify func(ctx context.Context) error) error {
+		return database.Listen(ctx, dbpool, event_repository.Channel, onNotify)
+	}
+	go events.NewListener(eventRepo, hub, listen, events.DefaultListenRetry, loger).Run(ctx)
+	go events.Purge(ctx, eventRepo, events.DefaultPurgeInterval, events.DefaultRetention, loger)
 
-	interval := time.Duration(timesec) * time.Second
+	assignHandler := assign_handler.NewAssignHandler(assignNotifier, assign_handler.V1)
 
-	monitorService := delivery_monitor_service.NewDeliveryMonitorService(deliveryRepo, repo, interval)
+	handler := courier_handler.NewHandler(courierNotifier, courier_handler.V1)
 
-	// gateway, err := order.NewGrpcGateway()
-	//if err != nil {
-	//return fmt.Errorf("fail with grpc %w", err)
-	//}
+	interval := time.Duration(timesec) * time.Second
 
-	// monitorOrder := order_monitor_service.NewOrderMonitorService(gateway, assignService, 5*time.Second)
+	monitorService := delivery_monitor_service.NewDeliveryMonitorService(txManager, deliveryRepo, repo, eventRepo, interval)
 
 	errMonitorCh := make(chan error, 1)
 
@@ -80,23 +106,93 @@ func run(ctx context.Context, port string, timesec int, loger logger.Logger) err
 		}
 	}()
 
+	schemaVersion, err := migrations.Latest()
+	if err != nil {
+		return err
+	}
+
+	checks := []health.Check{
+		health.Ping("postgres", dbpool),
+		health.Migrations(func(ctx context.Context) (int64, error) { return database.MigrationVersion(ctx, dbpool) }, schemaVersion),
+		health.Heartbeat("delivery_monitor", monitorService.LastTick, interval),
+	}
+
 	errMonitorOrderCh := make(chan error, 1)
 
-	// go func() {
-	//if err := monitorOrder.Monitor(ctx); err != nil {
-	//errMonitorOrderCh <- err
-	//}
-	//}()
+	if polling {
+		if pollTransport == order.TransportHTTP {
+			return fmt.Errorf("order polling: %w over %s, set POLL_ORDER_GATEWAY_TRANSPORT=%s", order.ErrListUnsupported, order.TransportHTTP, order.TransportGRPC)
+		}
+
+		gateway, closeGateway, err := order.NewGatewayFromEnv(pollTransport)
+		if err != nil {
+			return fmt.Errorf("order gateway: %w", err)
+		}
+		defer closeGateway()
+
+		cursorRepo := cursor_repository.NewCursorRepository(txManager)
+
+		monitorOrder := order_monitor_service.NewOrderMonitorService(gateway, assignNotifier, cursorRepo, pollInterval, pollPageSize, loger)
+		checks = append(checks, health.Heartbeat("order_monitor", monitorOrder.LastTick, pollInterval))
+
+		go func() {
+			if err := monitorOrder.Monitor(ctx); err != nil {
+				errMonitorOrderCh <- err
+			}
+		}()
+	}
 
 	observability.Register()
 
-	r := handlers.Routes(handler, assignHandler)
+	spec, err := openapi.Load()
+	if err != nil {
+		return err
+	}
+
+	authn, err := auth.FromEnv()
+	if err != nil {
+		return err
+	}
+
+	idempotencyTTL, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
+	if err != nil || idempotencyTTL <= 0 {
+		idempotencyTTL = idempotency.DefaultTTL
+	}
+
+	idempotencyLease, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_LEASE"))
+	if err != nil || idempotencyLease <= 0 {
+		idempotencyLease = idempotency.DefaultLease
+	}
+
+	idempotencyRepo := idempotency_repository.NewIdempotencyRepository(txManager)
+	go idempotency.Purge(ctx, idempotencyRepo, idempotency.DefaultPurgeInterval, loger)
+
+	deliveryHandler := delivery_handler.NewHandler(delivery_service.NewDeliveryService(deliveryRepo, repo), delivery_handler.V1)
+
+	eventHandler := event_handler.NewHandler(hub, event_handler.V1)
+
+	v1 := handlers.V1(handler, assignHandler, deliveryHandler, eventHandler, idempotency.Middleware(idempotencyRepo, idempotencyTTL, idempotencyLease, loger))
+
+	ready := health.NewReadiness(health.DefaultTimeout, checks...)
+
+	r := handlers.Routes(authn, openapi.NewValidator(spec).Middleware, ready, v1)
 
 	tokenBucket := ratelimiter.NewTokenBucket(Capacity, Refill)
 
 	rLimiter := ratelimiter.RateLimiterMiddleware(tokenBucket, loger)
 
-	rMiddleware := middleware.ObservabilityMiddleware(rLimiter(r), loger)
+	// Probes skip the rate limit, or a busy instance would be taken out of
+	// rotation for being busy.
+	limited := rLimiter(r)
+	probed := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == health.LivePath || req.URL.Path == health.ReadyPath {
+			r.ServeHTTP(w, req)
+			return
+		}
+		limited.ServeHTTP(w, req)
+	})
+
+	rMiddleware := middleware.RequestId(middleware.ObservabilityMiddleware(probed, loger))
 
 	pprofSrv := observability.StartPprof("0.0.0.0:6060", loger)
 	defer observability.StopServer(pprofSrv)
@@ -106,6 +202,18 @@ func run(ctx context.Context, port string, timesec int, loger logger.Logger) err
 		Handler: rMiddleware,
 	}
 
+	grpcLis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		return fmt.Errorf("grpc listen: %w", err)
+	}
+
+	grpcSrv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(courier_grpc.UnaryAuth(authn)),
+		grpc.ChainStreamInterceptor(courier_grpc.StreamAuth(authn)),
+	)
+	pb.RegisterCouriersServiceServer(grpcSrv, courier_grpc.NewServer(courierNotifier, assignNotifier, hub))
+	defer grpcSrv.Stop()
+
 	errCh := make(chan error, 1)
 
 	go func() {
@@ -115,6 +223,15 @@ func run(ctx context.Context, port string, timesec int, loger logger.Logger) err
 		}
 	}()
 
+	errGrpcCh := make(chan error, 1)
+
+	go func() {
+		log.Printf("gRPC server start on : %s", grpcPort)
+		if err := grpcSrv.Serve(grpcLis); err != nil {
+			errGrpcCh <- err
+		}
+	}()
+
 	select {
 	case <-ctx.Done():
 
@@ -127,6 +244,18 @@ func run(ctx context.Context, port string, timesec int, loger logger.Logger) err
 		if err := srv.Shutdown(shutdownCtx); err != nil {
 			return fmt.Errorf("shutdown error: %w", err)
 		}
+
+		// Watch streams only end with the client, so graceful stop is
+		// bounded by the same timeout.
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+		}
 		return nil
 
 	case err = <-errCh:
@@ -134,6 +263,8 @@ func run(ctx context.Context, port string, timesec int, loger logger.Logger) err
 			return nil
 		}
 		return fmt.Errorf("listen: %w", err)
+	case err = <-errGrpcCh:
+		return fmt.Errorf("grpc serve: %w", err)
 	case err = <-errMonitorCh:
 		if errors.Is(err, context.Canceled) {
 			return nil
@@ -157,8 +288,30 @@ func main() {
 	port := os.Getenv("PORT")
 	monitorTime := os.Getenv("MONITOR_TIME")
 
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = DefaultGrpcPort
+	}
+
 	timesec, _ := strconv.Atoi(monitorTime)
 
+	polling, _ := strconv.ParseBool(os.Getenv("ORDER_POLLING_ENABLED"))
+
+	pollTransport := os.Getenv("POLL_ORDER_GATEWAY_TRANSPORT")
+	if pollTransport == "" {
+		pollTransport = order.TransportGRPC
+	}
+
+	pollInterval, err := time.ParseDuration(os.Getenv("ORDER_POLL_INTERVAL"))
+	if err != nil || pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	pollPageSize, err := strconv.Atoi(os.Getenv("ORDER_POLL_PAGE_SIZE"))
+	if err != nil || pollPageSize <= 0 {
+		pollPageSize = order_monitor_service.DefaultPageSize
+	}
+
 	var portFlag = pflag.String("port", port, "Server port")
 
 	pflag.Parse()
@@ -171,7 +324,7 @@ func main() {
 
 	loger := logger.NewLogger()
 
-	if err := run(ctx, port, timesec, loger); err != nil {
+	if err := run(ctx, port, grpcPort, timesec, polling, pollTransport, pollInterval, pollPageSize, loger); err != nil {
 		loger.Log(fmt.Sprintf("fatal: %v", err))
 		os.Exit(1)
 	}
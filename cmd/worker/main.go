@@ -2,22 +2,27 @@
 
 import (
 	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
 	"course-go-avito-SitnikovArtem06/internal/gateway/order"
 	"course-go-avito-SitnikovArtem06/internal/handlers/queues/order/changed"
+	"course-go-avito-SitnikovArtem06/internal/health"
 	"course-go-avito-SitnikovArtem06/internal/logger"
+	"course-go-avito-SitnikovArtem06/internal/observability"
 	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory"
 	"course-go-avito-SitnikovArtem06/internal/transport"
+	"course-go-avito-SitnikovArtem06/migrations"
 	"course-go-avito-SitnikovArtem06/pkg/kafka"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"course-go-avito-SitnikovArtem06/internal/repository/courier_repository"
 	"course-go-avito-SitnikovArtem06/internal/repository/delivery_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/event_repository"
 	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"course-go-avito-SitnikovArtem06/internal/service/order_changed_service"
 	"course-go-avito-SitnikovArtem06/internal/service/transport_factory"
@@ -27,15 +32,31 @@ import (
 	"github.com/joho/godotenv"
 )
 
-const TimeOut = 5 * time.Second
+const DefaultWorkers = 8
+const DefaultMetricsAddr = "0.0.0.0:9091"
+const DefaultProbeAddr = "0.0.0.0:8086"
+const (
+	DefaultCacheSize        = 10000
+	DefaultCacheTTL         = 30 * time.Second
+	DefaultCacheNegativeTTL = 5 * time.Second
+)
 
-func run(ctx context.Context, loger logger.Logger) error {
+func run(ctx context.Context, opts transport.ConsumerOptions, loger logger.Logger) error {
 	dbpool, err := database.InitDb(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to connect to database: %w", err)
 	}
 	defer dbpool.Close()
 
+	observability.Register()
+
+	metricsAddr := os.Getenv("WORKER_METRICS_ADDR")
+	if metricsAddr == "" {
+		metricsAddr = DefaultMetricsAddr
+	}
+	metricsSrv := observability.StartMetrics(metricsAddr, loger)
+	defer observability.StopServer(metricsSrv)
+
 	txManager := tx.NewPgxTxManager(dbpool)
 
 	courierRepo := courier_repository.NewCourierRepo(txManager)
@@ -43,14 +64,37 @@ func run(ctx context.Context, loger logger.Logger) error {
 	transportFactory := transport_factory.NewTransportFactory()
 
 	assignService := assign_service.NewAssignService(txManager, deliveryRepo, courierRepo, transportFactory)
+	assignNotifier := events.NewAssignNotifier(assignService, txManager, event_repository.NewEventRepository(txManager))
 
-	statusFactory := order_status_factory.NewOrderStatusFactory(assignService)
+	statusFactory := order_status_factory.NewOrderStatusFactory(assignNotifier)
+
+	gatewayTransport := os.Getenv("WORKER_ORDER_GATEWAY_TRANSPORT")
+	if gatewayTransport == "" {
+		gatewayTransport = order.TransportHTTP
+	}
+
+	orderGateway, closeGateway, err := order.NewGatewayFromEnv(gatewayTransport)
+	if err != nil {
+		return fmt.Errorf("order gateway: %w", err)
+	}
+	defer closeGateway()
 
-	baseUrl := os.Getenv("ORDER_HTTP_BASEURL")
+	cacheSize, err := strconv.Atoi(os.Getenv("ORDER_CACHE_SIZE"))
+	if err != nil || cacheSize < 0 {
+		cacheSize = DefaultCacheSize
+	}
+	cacheTTL, err := time.ParseDuration(os.Getenv("ORDER_CACHE_TTL"))
+	if err != nil || cacheTTL <= 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+	cacheNegativeTTL, err := time.ParseDuration(os.Getenv("ORDER_CACHE_NEGATIVE_TTL"))
+	if err != nil || cacheNegativeTTL < 0 {
+		cacheNegativeTTL = DefaultCacheNegativeTTL
+	}
 
-	httpGateway := order.NewHttpGateway(baseUrl, &http.Client{Timeout: TimeOut})
+	cachedGateway := order.NewCachedGateway(orderGateway, cacheSize, cacheTTL, cacheNegativeTTL)
 
-	orderChanged := order_changed_service.NewOrderChangedService(statusFactory, httpGateway)
+	orderChanged := order_changed_service.NewOrderChangedService(statusFactory, cachedGateway, assignService)
 
 	orderChangedHandelr := changed.NewChangedHandler(orderChanged)
 
@@ -59,28 +103,50 @@ func run(ctx context.Context, loger logger.Logger) error {
 		return err
 	}
 
-	kafkaConsumer := transport.NewKafkaConsumer(kcfg.Brokers, kcfg.Topic, orderChangedHandelr, saramaCfg)
+	schemaVersion, err := migrations.Latest()
+	if err != nil {
+		return err
+	}
+
+	kafkaProbe := kafka.NewProbe(kcfg.Brokers, kcfg.Topic, saramaCfg)
+	defer kafkaProbe.Close()
+
+	kafkaConsumer := transport.NewKafkaConsumer(kcfg.Brokers, kcfg.Topic, kcfg.GroupID, orderChangedHandelr, saramaCfg, opts)
+
+	ready := health.NewReadiness(health.DefaultTimeout,
+		health.Ping("postgres", dbpool),
+		health.Migrations(func(ctx context.Context) (int64, error) { return database.MigrationVersion(ctx, dbpool) }, schemaVersion),
+		health.Check{Name: "kafka", Run: kafkaProbe.Check},
+		health.Heartbeat("consumer", kafkaConsumer.LastBeat, transport.HeartbeatInterval),
+	)
+
+	probeAddr := os.Getenv("WORKER_PROBE_ADDR")
+	if probeAddr == "" {
+		probeAddr = DefaultProbeAddr
+	}
+	probeSrv, err := health.StartServer(probeAddr, ready, loger)
+	if err != nil {
+		return err
+	}
+	defer observability.StopServer(probeSrv)
 
 	errCh := make(chan error, 1)
 
 	go func() {
-		if err := kafkaConsumer.Run(ctx); err != nil {
-			errCh <- err
-		}
+		errCh <- kafkaConsumer.Run(ctx)
 	}()
 
 	select {
 	case <-ctx.Done():
-
 		loger.Log("Shutting down worker")
+		err = <-errCh
+	case err = <-errCh:
+	}
 
+	if err == nil || errors.Is(err, context.Canceled) {
 		return nil
-	case err := <-errCh:
-		if errors.Is(err, context.Canceled) {
-			return nil
-		}
-		return fmt.Errorf("worker: %w", err)
 	}
+	return fmt.Errorf("worker: %w", err)
 }
 
 func main() {
@@ -89,9 +155,24 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	workers, err := strconv.Atoi(os.Getenv("WORKER_POOL_SIZE"))
+	if err != nil || workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	drainTimeout, err := time.ParseDuration(os.Getenv("WORKER_DRAIN_TIMEOUT"))
+	if err != nil || drainTimeout <= 0 {
+		drainTimeout = transport.DefaultDrainTimeout
+	}
+
+	opts := transport.ConsumerOptions{
+		Workers:      workers,
+		DrainTimeout: drainTimeout,
+	}
+
 	loger := logger.NewLogger()
 
-	if err := run(ctx, loger); err != nil {
+	if err := run(ctx, opts, loger); err != nil {
 		loger.Log(fmt.Sprintf("fatal: %v", err))
 		os.Exit(1)
 	}
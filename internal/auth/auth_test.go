@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("test-secret")
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims Claims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func claims(role Role, courierId int64, exp time.Duration) Claims {
+	return Claims{
+		Role:      role,
+		CourierId: courierId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    "auth",
+			Audience:  jwt.ClaimStrings{"service-courier"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(exp)),
+		},
+	}
+}
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+
+	a := NewAuthenticator(nil, func() []byte { return testSecret }, "auth", "service-courier")
+
+	p, err := a.Token(sign(t, jwt.SigningMethodHS256, testSecret, claims(RoleCourier, 7, time.Hour)))
+	require.NoError(t, err)
+	require.Equal(t, &Principal{Subject: "user-1", Role: RoleCourier, CourierId: 7}, p)
+
+	noExp := claims(RoleAdmin, 0, time.Hour)
+	noExp.ExpiresAt = nil
+	wrongIssuer := claims(RoleAdmin, 0, time.Hour)
+	wrongIssuer.Issuer = "someone-else"
+
+	rejected := map[string]string{
+		"expired":         sign(t, jwt.SigningMethodHS256, testSecret, claims(RoleAdmin, 0, -time.Hour)),
+		"no exp":          sign(t, jwt.SigningMethodHS256, testSecret, noExp),
+		"wrong secret":    sign(t, jwt.SigningMethodHS256, []byte("other"), claims(RoleAdmin, 0, time.Hour)),
+		"wrong algorithm": sign(t, jwt.SigningMethodHS512, testSecret, claims(RoleAdmin, 0, time.Hour)),
+		"alg none":        sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(RoleAdmin, 0, time.Hour)),
+		"wrong issuer":    sign(t, jwt.SigningMethodHS256, testSecret, wrongIssuer),
+		"unknown role":    sign(t, jwt.SigningMethodHS256, testSecret, claims("root", 0, time.Hour)),
+		"courier no id":   sign(t, jwt.SigningMethodHS256, testSecret, claims(RoleCourier, 0, time.Hour)),
+		"garbage":         "not.a.token",
+	}
+	for name, token := range rejected {
+		_, err := a.Token(token)
+		require.True(t, errors.Is(err, ErrInvalidToken), "%s: %v", name, err)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	t.Parallel()
+
+	keys, err := ParseAPIKeys([]byte(`[
+		{"key":"k-admin","subject":"ops","role":"admin"},
+		{"key":"k-courier","subject":"courier-3","role":"courier","courier_id":3}
+	]`))
+	require.NoError(t, err)
+
+	a := NewAuthenticator(func() map[[sha256.Size]byte]*Principal { return keys }, nil, "", "")
+
+	p, err := a.APIKey("k-courier")
+	require.NoError(t, err)
+	require.Equal(t, &Principal{Subject: "courier-3", Role: RoleCourier, CourierId: 3}, p)
+	require.True(t, p.CanAccessCourier(3))
+	require.False(t, p.CanAccessCourier(4))
+
+	_, err = a.APIKey("k-unknown")
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, err = a.Token("anything")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	keys, err := ParseAPIKeys([]byte(`[{"key":"k-admin","subject":"ops","role":"admin"}]`))
+	require.NoError(t, err)
+
+	a := NewAuthenticator(func() map[[sha256.Size]byte]*Principal { return keys }, func() []byte { return testSecret }, "", "")
+
+	p, err := a.Resolve("k-admin", "Bearer ignored")
+	require.NoError(t, err)
+	require.Equal(t, "ops", p.Subject)
+
+	p, err = a.Resolve("", "bearer "+sign(t, jwt.SigningMethodHS256, testSecret, claims(RoleCourier, 3, time.Hour)))
+	require.NoError(t, err)
+	require.Equal(t, RoleCourier, p.Role)
+
+	_, err = a.Resolve("", "Basic b3BzOnB3")
+	require.ErrorIs(t, err, ErrMissingCredentials)
+}
+
+func TestCanAccessCourier_FailsClosed(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, CanAccessCourier(context.Background(), 3))
+
+	ctx := WithPrincipal(context.Background(), &Principal{Subject: "courier-3", Role: RoleCourier, CourierId: 3})
+	require.True(t, CanAccessCourier(ctx, 3))
+	require.False(t, CanAccessCourier(ctx, 4))
+}
+
+func TestParseAPIKeys_Rejects(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{
+		`{}`,
+		`[{"key":"","subject":"s","role":"admin"}]`,
+		`[{"key":"k","subject":"s","role":"root"}]`,
+		`[{"key":"k","subject":"","role":"admin"}]`,
+		`[{"key":"k","subject":"s","role":"courier"}]`,
+		`[{"key":"k","subject":"a","role":"admin"},{"key":"k","subject":"b","role":"admin"}]`,
+	} {
+		_, err := ParseAPIKeys([]byte(data))
+		require.Error(t, err, data)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("AUTH_ENABLED", "")
+	t.Setenv("AUTH_API_KEYS_FILE", "")
+	t.Setenv("AUTH_JWT_SECRET_FILE", "")
+	t.Setenv("AUTH_JWT_SECRET", "")
+
+	_, err := FromEnv()
+	require.ErrorIs(t, err, ErrNoCredentials)
+
+	t.Setenv("AUTH_ENABLED", "false")
+	a, err := FromEnv()
+	require.NoError(t, err)
+	p, ok := a.Anonymous()
+	require.True(t, ok)
+	require.Equal(t, RoleAdmin, p.Role)
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"key":"k1","subject":"svc","role":"service"}]`), 0o600))
+
+	t.Setenv("AUTH_ENABLED", "true")
+	t.Setenv("AUTH_API_KEYS_FILE", path)
+	a, err = FromEnv()
+	require.NoError(t, err)
+	_, ok = a.Anonymous()
+	require.False(t, ok)
+
+	p, err = a.APIKey("k1")
+	require.NoError(t, err)
+	require.Equal(t, RoleService, p.Role)
+}
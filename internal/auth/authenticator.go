@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// APIKey is one entry of the API keys file.
+type APIKey struct {
+	Key       string `json:"key"`
+	Subject   string `json:"subject"`
+	Role      Role   `json:"role"`
+	CourierId int64  `json:"courier_id,omitempty"`
+}
+
+// Claims are the JWT claims the service understands on top of the
+// registered ones; sub names the principal.
+type Claims struct {
+	Role      Role  `json:"role"`
+	CourierId int64 `json:"courier_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+const jwtLeeway = 30 * time.Second
+
+// Authenticator resolves API keys and HS256 JWTs to principals. Keys and the
+// secret are read through functions so rotated files are picked up.
+type Authenticator struct {
+	apiKeys   func() map[[sha256.Size]byte]*Principal
+	jwtSecret func() []byte
+	issuer    string
+	audience  string
+
+	anonymous *Principal
+}
+
+func NewAuthenticator(apiKeys func() map[[sha256.Size]byte]*Principal, jwtSecret func() []byte, issuer, audience string) *Authenticator {
+	return &Authenticator{
+		apiKeys:   apiKeys,
+		jwtSecret: jwtSecret,
+		issuer:    issuer,
+		audience:  audience,
+	}
+}
+
+// Disabled lets every request act as an anonymous admin. Local runs only.
+func Disabled() *Authenticator {
+	return &Authenticator{anonymous: &Principal{Subject: "anonymous", Role: RoleAdmin}}
+}
+
+// Anonymous returns the principal used when authentication is disabled.
+func (a *Authenticator) Anonymous() (*Principal, bool) {
+	return a.anonymous, a.anonymous != nil
+}
+
+// Resolve returns the principal for a call that carried apiKey and the
+// Authorization value authorization. An API key wins over a bearer token.
+func (a *Authenticator) Resolve(apiKey, authorization string) (*Principal, error) {
+	if p, ok := a.Anonymous(); ok {
+		return p, nil
+	}
+
+	if apiKey != "" {
+		return a.APIKey(apiKey)
+	}
+
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return a.Token(strings.TrimSpace(token))
+	}
+
+	return nil, ErrMissingCredentials
+}
+
+func (a *Authenticator) APIKey(key string) (*Principal, error) {
+	if a.apiKeys == nil || key == "" {
+		return nil, ErrInvalidAPIKey
+	}
+	// Keys are looked up by hash, so the lookup time does not depend on how
+	// much of a guessed key matches.
+	p, ok := a.apiKeys()[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+	return p, nil
+}
+
+func (a *Authenticator) Token(token string) (*Principal, error) {
+	if a.jwtSecret == nil {
+		return nil, ErrInvalidToken
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if a.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.issuer))
+	}
+	if a.audience != "" {
+		opts = append(opts, jwt.WithAudience(a.audience))
+	}
+
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return a.jwtSecret(), nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	p := &Principal{Subject: claims.Subject, Role: claims.Role, CourierId: claims.CourierId}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return p, nil
+}
+
+func (p *Principal) validate() error {
+	switch {
+	case p.Subject == "":
+		return fmt.Errorf("subject is empty")
+	case !p.Role.IsValid():
+		return fmt.Errorf("unknown role %q", p.Role)
+	case p.Role == RoleCourier && p.CourierId <= 0:
+		return fmt.Errorf("courier %q has no courier_id", p.Subject)
+	}
+	return nil
+}
+
+// ParseAPIKeys parses a JSON array of APIKey.
+func ParseAPIKeys(data ...[]byte) (map[[sha256.Size]byte]*Principal, error) {
+	var keys []APIKey
+	if err := json.Unmarshal(data[0], &keys); err != nil {
+		return nil, fmt.Errorf("auth: api keys: %w", err)
+	}
+
+	out := make(map[[sha256.Size]byte]*Principal, len(keys))
+	for i, k := range keys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("auth: api key %d: key is empty", i)
+		}
+		p := &Principal{Subject: k.Subject, Role: k.Role, CourierId: k.CourierId}
+		if err := p.validate(); err != nil {
+			return nil, fmt.Errorf("auth: api key %d: %w", i, err)
+		}
+		sum := sha256.Sum256([]byte(k.Key))
+		if _, dup := out[sum]; dup {
+			return nil, fmt.Errorf("auth: api key %d: duplicate key", i)
+		}
+		out[sum] = p
+	}
+	return out, nil
+}
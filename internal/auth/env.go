@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"bytes"
+	"course-go-avito-SitnikovArtem06/pkg/reload"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// FromEnv builds the authenticator from AUTH_* variables. Authentication is
+// on unless AUTH_ENABLED is explicitly false; when it is on, at least one of
+// AUTH_API_KEYS_FILE and AUTH_JWT_SECRET(_FILE) must be set. Both files are
+// re-read when they change.
+func FromEnv() (*Authenticator, error) {
+	if enabled, err := strconv.ParseBool(os.Getenv("AUTH_ENABLED")); err == nil && !enabled {
+		return Disabled(), nil
+	}
+
+	var apiKeys func() map[[sha256.Size]byte]*Principal
+	if path := os.Getenv("AUTH_API_KEYS_FILE"); path != "" {
+		keys, err := reload.NewValue(ParseAPIKeys, path)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = keys.Get
+	}
+
+	var jwtSecret func() []byte
+	if path := os.Getenv("AUTH_JWT_SECRET_FILE"); path != "" {
+		secret, err := reload.NewValue(parseSecret, path)
+		if err != nil {
+			return nil, err
+		}
+		jwtSecret = secret.Get
+	} else if value := os.Getenv("AUTH_JWT_SECRET"); value != "" {
+		jwtSecret = func() []byte { return []byte(value) }
+	}
+
+	if apiKeys == nil && jwtSecret == nil {
+		return nil, fmt.Errorf("auth: %w", ErrNoCredentials)
+	}
+
+	return NewAuthenticator(apiKeys, jwtSecret, os.Getenv("AUTH_JWT_ISSUER"), os.Getenv("AUTH_JWT_AUDIENCE")), nil
+}
+
+func parseSecret(data ...[]byte) ([]byte, error) {
+	secret := bytes.TrimSpace(data[0])
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("auth: jwt secret file is empty")
+	}
+	return secret, nil
+}
@@ -0,0 +1,13 @@
+package auth
+
+import "errors"
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid API key")
+
+	ErrInvalidToken = errors.New("invalid token")
+
+	ErrNoCredentials = errors.New("no API keys or JWT secret configured")
+
+	ErrMissingCredentials = errors.New("no API key or bearer token")
+)
@@ -0,0 +1,63 @@
+package auth
+
+import "context"
+
+type Role string
+
+const (
+	RoleAdmin      Role = "admin"
+	RoleDispatcher Role = "dispatcher"
+	RoleCourier    Role = "courier"
+	RoleService    Role = "service"
+)
+
+// Role sets for the per-operation permission rules, shared by the HTTP routes
+// and the gRPC methods. They are mirrored by x-roles in the OpenAPI spec.
+var (
+	Staff        = []Role{RoleAdmin, RoleDispatcher}
+	StaffService = []Role{RoleAdmin, RoleDispatcher, RoleService}
+	AnyRole      = []Role{RoleAdmin, RoleDispatcher, RoleService, RoleCourier}
+	StaffCourier = []Role{RoleAdmin, RoleDispatcher, RoleCourier}
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleAdmin || r == RoleDispatcher || r == RoleCourier || r == RoleService
+}
+
+func (r Role) String() string {
+	return string(r)
+}
+
+// Principal is whoever a request acts on behalf of.
+type Principal struct {
+	Subject string
+	Role    Role
+	// CourierId binds a courier principal to its own courier record.
+	CourierId int64
+}
+
+// CanAccessCourier reports whether p may read or change the courier id:
+// couriers only see themselves, every other role sees everyone.
+func (p *Principal) CanAccessCourier(id int64) bool {
+	return p.Role != RoleCourier || p.CourierId == id
+}
+
+// CanAccessCourier applies the courier self-access rule to the principal of
+// ctx. A context without a principal is never allowed.
+func CanAccessCourier(ctx context.Context, id int64) bool {
+	p, ok := FromContext(ctx)
+	return ok && p.CanAccessCourier(id)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of the request ctx belongs to, so that
+// services can record who made a change.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,156 @@
+package events
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	TypeCourierCreated     = "courier.created"
+	TypeCourierUpdated     = "courier.updated"
+	TypeDeliveryAssigned   = "delivery.assigned"
+	TypeDeliveryUnassigned = "delivery.unassigned"
+	TypeDeliveryPickedUp   = "delivery.picked_up"
+	TypeDeliveryDelivering = "delivery.delivering"
+	TypeDeliveryCompleted  = "delivery.completed"
+	TypeDeliveryFailed     = "delivery.failed_delivery"
+	TypeDeliveryReturned   = "delivery.returned"
+)
+
+const (
+	DefaultHistorySize = 1024
+	DefaultBufferSize  = 64
+)
+
+// Event is one change of a courier or a delivery. Ids come from the event
+// log and only grow, across restarts and replicas, so a subscriber can
+// resume after the last id it saw on any instance.
+type Event struct {
+	Id            int64
+	Type          string
+	CourierId     int64
+	CourierStatus string
+	Transport     string
+	OrderId       string
+	Deadline      time.Time
+	Actor         string
+	ActorRole     string
+	At            time.Time
+}
+
+// Filter selects events for a subscriber. Empty fields match everything.
+type Filter struct {
+	CourierId int64
+	Types     []string
+	Statuses  []string
+}
+
+func (f Filter) Match(e Event) bool {
+	if f.CourierId != 0 && f.CourierId != e.CourierId {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, e.CourierStatus) {
+		return false
+	}
+	return true
+}
+
+type subscriber struct {
+	filter Filter
+	ch     chan Event
+}
+
+// Hub fans events out to subscribers and keeps the last historySize events
+// for subscribers that resume.
+type Hub struct {
+	mu          sync.Mutex
+	lastId      int64
+	history     []Event
+	historySize int
+	subs        map[*subscriber]struct{}
+}
+
+func NewHub(historySize int) *Hub {
+	return &Hub{
+		historySize: historySize,
+		subs:        make(map[*subscriber]struct{}),
+	}
+}
+
+// Publish delivers e. An event read from the log keeps its id and one that
+// was already published is ignored; an event without an id gets the next
+// one. A subscriber that does not keep up is dropped instead of blocking the
+// publisher.
+func (h *Hub) Publish(e Event) Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if e.Id == 0 {
+		e.Id = h.lastId + 1
+	} else if e.Id <= h.lastId {
+		return e
+	}
+	h.lastId = e.Id
+	if e.At.IsZero() {
+		e.At = time.Now().UTC()
+	}
+
+	if h.historySize > 0 {
+		if len(h.history) == h.historySize {
+			h.history = h.history[1:]
+		}
+		h.history = append(h.history, e)
+	}
+
+	for sub := range h.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			delete(h.subs, sub)
+			close(sub.ch)
+		}
+	}
+
+	return e
+}
+
+// Subscribe returns matching events published after afterId, starting with
+// the ones still in history. The channel is closed when the subscriber is
+// cancelled or falls behind by more than buffer events.
+func (h *Hub) Subscribe(filter Filter, afterId int64, buffer int) (<-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var backlog []Event
+	if afterId > 0 {
+		for _, e := range h.history {
+			if e.Id > afterId && filter.Match(e) {
+				backlog = append(backlog, e)
+			}
+		}
+	}
+
+	sub := &subscriber{filter: filter, ch: make(chan Event, len(backlog)+max(buffer, 1))}
+	for _, e := range backlog {
+		sub.ch <- e
+	}
+	h.subs[sub] = struct{}{}
+
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[sub]; ok {
+			delete(h.subs, sub)
+			close(sub.ch)
+		}
+	}
+
+	return sub.ch, cancel
+}
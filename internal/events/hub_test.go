@@ -0,0 +1,104 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_PublishToMatchingSubscribers(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(DefaultHistorySize)
+
+	all, cancelAll := hub.Subscribe(Filter{}, 0, 4)
+	defer cancelAll()
+	courier2, cancel2 := hub.Subscribe(Filter{CourierId: 2}, 0, 4)
+	defer cancel2()
+
+	hub.Publish(Event{Type: TypeDeliveryAssigned, CourierId: 1})
+	hub.Publish(Event{Type: TypeDeliveryAssigned, CourierId: 2})
+
+	require.Equal(t, int64(1), (<-all).Id)
+	require.Equal(t, int64(2), (<-all).Id)
+
+	e := <-courier2
+	require.Equal(t, int64(2), e.Id)
+	require.False(t, e.At.IsZero())
+	require.Empty(t, courier2)
+}
+
+func TestHub_ResumeAfterId(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(2)
+
+	hub.Publish(Event{Type: TypeCourierCreated})
+	hub.Publish(Event{Type: TypeCourierUpdated, CourierStatus: "busy"})
+	hub.Publish(Event{Type: TypeCourierUpdated, CourierStatus: "paused"})
+
+	ch, cancel := hub.Subscribe(Filter{Types: []string{TypeCourierUpdated}}, 1, 1)
+	defer cancel()
+
+	require.Equal(t, int64(2), (<-ch).Id)
+	require.Equal(t, int64(3), (<-ch).Id)
+
+	hub.Publish(Event{Type: TypeCourierUpdated})
+	require.Equal(t, int64(4), (<-ch).Id)
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(0)
+
+	ch, cancel := hub.Subscribe(Filter{}, 0, 1)
+	defer cancel()
+
+	hub.Publish(Event{})
+	hub.Publish(Event{})
+
+	<-ch
+	_, ok := <-ch
+	require.False(t, ok)
+}
+
+func TestHub_CancelClosesChannel(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(0)
+
+	ch, cancel := hub.Subscribe(Filter{}, 0, 1)
+	cancel()
+	cancel()
+
+	_, ok := <-ch
+	require.False(t, ok)
+
+	hub.Publish(Event{})
+}
+
+func TestFilter_Statuses(t *testing.T) {
+	t.Parallel()
+
+	f := Filter{Statuses: []string{"busy"}}
+	require.True(t, f.Match(Event{CourierStatus: "busy"}))
+	require.False(t, f.Match(Event{CourierStatus: "available"}))
+}
+
+func TestHub_KeepsLogIds(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(DefaultHistorySize)
+
+	ch, cancel := hub.Subscribe(Filter{}, 0, 4)
+	defer cancel()
+
+	hub.Publish(Event{Id: 41, Type: TypeCourierCreated})
+	hub.Publish(Event{Id: 41, Type: TypeCourierCreated})
+	hub.Publish(Event{Id: 43, Type: TypeCourierUpdated})
+
+	require.Equal(t, int64(41), (<-ch).Id)
+	require.Equal(t, int64(43), (<-ch).Id)
+	require.Empty(t, ch, "an id is published once")
+}
@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/logger"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultListenRetry   = time.Second
+	DefaultRetention     = 24 * time.Hour
+	DefaultPurgeInterval = time.Hour
+
+	listPageSize = 256
+)
+
+// ListenFunc subscribes to the notifications of the event log and calls
+// onNotify once subscribed and again after every notification. It blocks
+// until ctx is done or the subscription fails.
+type ListenFunc func(ctx context.Context, onNotify func(ctx context.Context) error) error
+
+// Listener feeds a Hub with the events every process appends to the event
+// log, so subscribers of any instance see the writes of all of them.
+type Listener struct {
+	log    eventLog
+	hub    *Hub
+	listen ListenFunc
+	retry  time.Duration
+	loger  logger.Logger
+
+	started bool
+	lastId  int64
+}
+
+func NewListener(log eventLog, hub *Hub, listen ListenFunc, retry time.Duration, loger logger.Logger) *Listener {
+	return &Listener{log: log, hub: hub, listen: listen, retry: retry, loger: loger}
+}
+
+// Run listens until ctx is done and subscribes again after retry whenever
+// the subscription fails. Nothing is lost in between: every subscription
+// starts by reading what the log gained meanwhile.
+func (l *Listener) Run(ctx context.Context) {
+	for {
+		err := l.listen(ctx, l.catchUp)
+		if ctx.Err() != nil {
+			return
+		}
+		l.loger.Log(fmt.Sprintf("events: listen: %v", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.retry):
+		}
+	}
+}
+
+// catchUp publishes every event after the last one it has seen. The first
+// call starts from the newest events that fit into the hub history, so a
+// restarted instance still lets subscribers resume.
+func (l *Listener) catchUp(ctx context.Context) error {
+	if !l.started {
+		last, err := l.log.LastId(ctx)
+		if err != nil {
+			return err
+		}
+		l.lastId = max(last-int64(l.hub.historySize), 0)
+		l.started = true
+	}
+
+	for {
+		list, err := l.log.ListAfter(ctx, l.lastId, listPageSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range list {
+			l.hub.Publish(e)
+			l.lastId = e.Id
+		}
+		if len(list) < listPageSize {
+			return nil
+		}
+	}
+}
+
+// Purge deletes events older than retention every interval until ctx is
+// done.
+func Purge(ctx context.Context, p purger, interval, retention time.Duration, loger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.DeleteOlderThan(ctx, retention); err != nil && ctx.Err() == nil {
+				loger.Log(fmt.Sprintf("events: purge: %v", err))
+			}
+		}
+	}
+}
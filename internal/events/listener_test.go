@@ -0,0 +1,94 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Log(string) {}
+
+// notifier is a ListenFunc driven by the test: every value sent to it is one
+// notification, and a closed channel breaks the subscription.
+type notifier struct {
+	ch      chan struct{}
+	listens atomic.Int32
+}
+
+func (n *notifier) listen(ctx context.Context, onNotify func(ctx context.Context) error) error {
+	n.listens.Add(1)
+	for {
+		if err := onNotify(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-n.ch:
+			if !ok {
+				return errors.New("connection lost")
+			}
+		}
+	}
+}
+
+func TestListener_PublishesLogEvents(t *testing.T) {
+	t.Parallel()
+
+	store := &memStore{}
+	for range 3 {
+		require.NoError(t, store.Append(context.Background(), Event{Type: TypeCourierCreated}))
+	}
+
+	hub := NewHub(2)
+	n := &notifier{ch: make(chan struct{})}
+	l := NewListener(store, hub, n.listen, time.Millisecond, nopLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go l.Run(ctx)
+
+	ch, unsubscribe := hub.Subscribe(Filter{}, 1, 4)
+	defer unsubscribe()
+
+	// A new instance starts from the newest events that fit into history.
+	require.Equal(t, int64(2), (<-ch).Id)
+	require.Equal(t, int64(3), (<-ch).Id)
+
+	require.NoError(t, store.Append(context.Background(), Event{Type: TypeDeliveryAssigned, CourierId: 5}))
+	n.ch <- struct{}{}
+
+	e := <-ch
+	require.Equal(t, int64(4), e.Id)
+	require.Equal(t, int64(5), e.CourierId)
+}
+
+func TestListener_CatchesUpAfterReconnect(t *testing.T) {
+	t.Parallel()
+
+	store := &memStore{}
+	hub := NewHub(DefaultHistorySize)
+	n := &notifier{ch: make(chan struct{})}
+	l := NewListener(store, hub, n.listen, time.Millisecond, nopLogger{})
+
+	ch, unsubscribe := hub.Subscribe(Filter{}, 0, 4)
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go l.Run(ctx)
+
+	require.Eventually(t, func() bool { return n.listens.Load() == 1 }, time.Second, time.Millisecond)
+
+	// The event is written while the connection is down and nobody notifies.
+	require.NoError(t, store.Append(context.Background(), Event{Type: TypeCourierCreated}))
+	close(n.ch)
+
+	require.Equal(t, int64(1), (<-ch).Id)
+}
@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/events/service_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/events/service_contract.go -destination internal/events/mocks/mock_service.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "course-go-avito-SitnikovArtem06/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcourierService is a mock of courierService interface.
+type MockcourierService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcourierServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockcourierServiceMockRecorder is the mock recorder for MockcourierService.
+type MockcourierServiceMockRecorder struct {
+	mock *MockcourierService
+}
+
+// NewMockcourierService creates a new mock instance.
+func NewMockcourierService(ctrl *gomock.Controller) *MockcourierService {
+	mock := &MockcourierService{ctrl: ctrl}
+	mock.recorder = &MockcourierServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcourierService) EXPECT() *MockcourierServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCourier mocks base method.
+func (m *MockcourierService) CreateCourier(ctx context.Context, c *model.CreateCourierRequest) (*model.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCourier", ctx, c)
+	ret0, _ := ret[0].(*model.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCourier indicates an expected call of CreateCourier.
+func (mr *MockcourierServiceMockRecorder) CreateCourier(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourier", reflect.TypeOf((*MockcourierService)(nil).CreateCourier), ctx, c)
+}
+
+// GetAllCouriers mocks base method.
+func (m *MockcourierService) GetAllCouriers(ctx context.Context) ([]model.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCouriers", ctx)
+	ret0, _ := ret[0].([]model.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCouriers indicates an expected call of GetAllCouriers.
+func (mr *MockcourierServiceMockRecorder) GetAllCouriers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCouriers", reflect.TypeOf((*MockcourierService)(nil).GetAllCouriers), ctx)
+}
+
+// GetCourierById mocks base method.
+func (m *MockcourierService) GetCourierById(ctx context.Context, id int64) (*model.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierById", ctx, id)
+	ret0, _ := ret[0].(*model.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierById indicates an expected call of GetCourierById.
+func (mr *MockcourierServiceMockRecorder) GetCourierById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierById", reflect.TypeOf((*MockcourierService)(nil).GetCourierById), ctx, id)
+}
+
+// UpdateCourier mocks base method.
+func (m *MockcourierService) UpdateCourier(ctx context.Context, req *model.UpdateCourierRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCourier", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCourier indicates an expected call of UpdateCourier.
+func (mr *MockcourierServiceMockRecorder) UpdateCourier(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourier", reflect.TypeOf((*MockcourierService)(nil).UpdateCourier), ctx, req)
+}
+
+// MockassignService is a mock of assignService interface.
+type MockassignService struct {
+	ctrl     *gomock.Controller
+	recorder *MockassignServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockassignServiceMockRecorder is the mock recorder for MockassignService.
+type MockassignServiceMockRecorder struct {
+	mock *MockassignService
+}
+
+// NewMockassignService creates a new mock instance.
+func NewMockassignService(ctrl *gomock.Controller) *MockassignService {
+	mock := &MockassignService{ctrl: ctrl}
+	mock.recorder = &MockassignServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockassignService) EXPECT() *MockassignServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignCourier mocks base method.
+func (m *MockassignService) AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCourier", ctx, orderId)
+	ret0, _ := ret[0].(*model.AssignCourier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignCourier indicates an expected call of AssignCourier.
+func (mr *MockassignServiceMockRecorder) AssignCourier(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourier", reflect.TypeOf((*MockassignService)(nil).AssignCourier), ctx, orderId)
+}
+
+// AssignCouriers mocks base method.
+func (m *MockassignService) AssignCouriers(ctx context.Context, orderIds []string, policy model.BatchPolicy) ([]model.AssignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCouriers", ctx, orderIds, policy)
+	ret0, _ := ret[0].([]model.AssignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignCouriers indicates an expected call of AssignCouriers.
+func (mr *MockassignServiceMockRecorder) AssignCouriers(ctx, orderIds, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCouriers", reflect.TypeOf((*MockassignService)(nil).AssignCouriers), ctx, orderIds, policy)
+}
+
+// AssignOrder mocks base method.
+func (m *MockassignService) AssignOrder(ctx context.Context, order *model.Order) (*model.AssignCourier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignOrder", ctx, order)
+	ret0, _ := ret[0].(*model.AssignCourier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignOrder indicates an expected call of AssignOrder.
+func (mr *MockassignServiceMockRecorder) AssignOrder(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrder", reflect.TypeOf((*MockassignService)(nil).AssignOrder), ctx, order)
+}
+
+// ChangeDeliveryStatus mocks base method.
+func (m *MockassignService) ChangeDeliveryStatus(ctx context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeDeliveryStatus", ctx, orderId, status)
+	ret0, _ := ret[0].(*model.DeliveryStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeDeliveryStatus indicates an expected call of ChangeDeliveryStatus.
+func (mr *MockassignServiceMockRecorder) ChangeDeliveryStatus(ctx, orderId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeDeliveryStatus", reflect.TypeOf((*MockassignService)(nil).ChangeDeliveryStatus), ctx, orderId, status)
+}
+
+// CompleteCourier mocks base method.
+func (m *MockassignService) CompleteCourier(ctx context.Context, orderId string) (*model.CompleteCourier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteCourier", ctx, orderId)
+	ret0, _ := ret[0].(*model.CompleteCourier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteCourier indicates an expected call of CompleteCourier.
+func (mr *MockassignServiceMockRecorder) CompleteCourier(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteCourier", reflect.TypeOf((*MockassignService)(nil).CompleteCourier), ctx, orderId)
+}
+
+// UnassignCourier mocks base method.
+func (m *MockassignService) UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignCourier", ctx, orderId)
+	ret0, _ := ret[0].(*model.UnassignCourier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnassignCourier indicates an expected call of UnassignCourier.
+func (mr *MockassignServiceMockRecorder) UnassignCourier(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignCourier", reflect.TypeOf((*MockassignService)(nil).UnassignCourier), ctx, orderId)
+}
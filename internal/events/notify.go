@@ -0,0 +1,244 @@
+package events
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/tx"
+)
+
+// CourierNotifier appends an event for every successful courier write, in
+// the same transaction as the write, and passes reads through.
+type CourierNotifier struct {
+	courierService
+	tm    tx.TransactionManager
+	store store
+}
+
+func NewCourierNotifier(s courierService, tm tx.TransactionManager, store store) *CourierNotifier {
+	return &CourierNotifier{courierService: s, tm: tm, store: store}
+}
+
+func (n *CourierNotifier) CreateCourier(ctx context.Context, c *model.CreateCourierRequest) (*model.Courier, error) {
+	var courier *model.Courier
+
+	err := n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		if courier, err = n.courierService.CreateCourier(ctx, c); err != nil {
+			return err
+		}
+		return record(ctx, n.store, courierEvent(TypeCourierCreated, courier))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return courier, nil
+}
+
+func (n *CourierNotifier) UpdateCourier(ctx context.Context, req *model.UpdateCourierRequest) error {
+	return n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		if err := n.courierService.UpdateCourier(ctx, req); err != nil {
+			return err
+		}
+
+		courier, err := n.courierService.GetCourierById(ctx, *req.Id)
+		if err != nil {
+			courier = &model.Courier{Id: *req.Id}
+		}
+
+		return record(ctx, n.store, courierEvent(TypeCourierUpdated, courier))
+	})
+}
+
+// AssignNotifier appends an event for every delivery a courier takes, moves
+// along or is released from, in the same transaction as the change.
+type AssignNotifier struct {
+	assignService
+	tm    tx.TransactionManager
+	store store
+}
+
+func NewAssignNotifier(s assignService, tm tx.TransactionManager, store store) *AssignNotifier {
+	return &AssignNotifier{assignService: s, tm: tm, store: store}
+}
+
+func (n *AssignNotifier) AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error) {
+	var assign *model.AssignCourier
+
+	err := n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		if assign, err = n.assignService.AssignCourier(ctx, orderId); err != nil {
+			return err
+		}
+		return record(ctx, n.store, assignedEvent(assign))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return assign, nil
+}
+
+func (n *AssignNotifier) AssignOrder(ctx context.Context, order *model.Order) (*model.AssignCourier, error) {
+	var assign *model.AssignCourier
+
+	err := n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		if assign, err = n.assignService.AssignOrder(ctx, order); err != nil {
+			return err
+		}
+		return record(ctx, n.store, assignedEvent(assign))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return assign, nil
+}
+
+func (n *AssignNotifier) AssignCouriers(ctx context.Context, orderIds []string, policy model.BatchPolicy) ([]model.AssignResult, error) {
+	var results []model.AssignResult
+	var batchErr error
+
+	err := n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		results, batchErr = n.assignService.AssignCouriers(ctx, orderIds, policy)
+		if batchErr != nil {
+			return batchErr
+		}
+
+		for _, r := range results {
+			if r.Assign == nil {
+				continue
+			}
+			if err := record(ctx, n.store, assignedEvent(r.Assign)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// An aborted batch still reports why each order failed.
+	if batchErr != nil {
+		return results, batchErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (n *AssignNotifier) UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error) {
+	var unassign *model.UnassignCourier
+
+	err := n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		if unassign, err = n.assignService.UnassignCourier(ctx, orderId); err != nil {
+			return err
+		}
+		return record(ctx, n.store, Event{
+			Type:          TypeDeliveryUnassigned,
+			CourierId:     unassign.CourierId,
+			CourierStatus: model.CourierStatusAvailable.String(),
+			OrderId:       unassign.OrderId,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return unassign, nil
+}
+
+func (n *AssignNotifier) CompleteCourier(ctx context.Context, orderId string) (*model.CompleteCourier, error) {
+	var complete *model.CompleteCourier
+
+	err := n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		if complete, err = n.assignService.CompleteCourier(ctx, orderId); err != nil {
+			return err
+		}
+		return record(ctx, n.store, Event{
+			Type:          TypeDeliveryCompleted,
+			CourierId:     complete.CourierId,
+			CourierStatus: model.CourierStatusAvailable.String(),
+			OrderId:       complete.OrderId,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return complete, nil
+}
+
+// ChangeDeliveryStatus records the delivery's new status, and the courier as
+// available when the change frees them. A delivery that already had the
+// status records nothing.
+func (n *AssignNotifier) ChangeDeliveryStatus(ctx context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryStatusChange, error) {
+	var change *model.DeliveryStatusChange
+
+	err := n.tm.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		if change, err = n.assignService.ChangeDeliveryStatus(ctx, orderId, status); err != nil {
+			return err
+		}
+		if !change.Changed() {
+			return nil
+		}
+
+		e := Event{
+			Type:      deliveryEventTypes[change.To],
+			CourierId: change.CourierId,
+			OrderId:   change.OrderId,
+		}
+		if change.ReleasesCourier() {
+			e.CourierStatus = model.CourierStatusAvailable.String()
+		}
+		return record(ctx, n.store, e)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+var deliveryEventTypes = map[model.DeliveryStatus]string{
+	model.DeliveryAssigned:   TypeDeliveryAssigned,
+	model.DeliveryPickedUp:   TypeDeliveryPickedUp,
+	model.DeliveryDelivering: TypeDeliveryDelivering,
+	model.DeliveryCompleted:  TypeDeliveryCompleted,
+	model.DeliveryFailed:     TypeDeliveryFailed,
+	model.DeliveryReturned:   TypeDeliveryReturned,
+}
+
+func assignedEvent(a *model.AssignCourier) Event {
+	return Event{
+		Type:          TypeDeliveryAssigned,
+		CourierId:     a.CourierId,
+		CourierStatus: model.CourierStatusBusy.String(),
+		Transport:     a.Transport.String(),
+		OrderId:       a.OrderId,
+		Deadline:      a.Deadline,
+	}
+}
+
+func courierEvent(typ string, c *model.Courier) Event {
+	return Event{
+		Type:          typ,
+		CourierId:     c.Id,
+		CourierStatus: c.Status.String(),
+		Transport:     c.Transport.String(),
+	}
+}
+
+// record records who made the change, so the event stream doubles as an
+// audit trail. Background work carries no principal and leaves Actor empty.
+func record(ctx context.Context, store store, e Event) error {
+	if p, ok := auth.FromContext(ctx); ok {
+		e.Actor = p.Subject
+		e.ActorRole = p.Role.String()
+	}
+	return store.Append(ctx, e)
+}
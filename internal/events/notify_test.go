@@ -0,0 +1,250 @@
+package events
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/events/mocks"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	txmocks "course-go-avito-SitnikovArtem06/internal/service/mocks"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTxManager runs every transaction inline.
+func newTxManager(ctrl *gomock.Controller) *txmocks.MockTransactionManager {
+	tm := txmocks.NewMockTransactionManager(ctrl)
+	tm.EXPECT().Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			return fn(parent)
+		}).
+		AnyTimes()
+	return tm
+}
+
+// memStore is an event log kept in memory. Append fails with err when it
+// is set.
+type memStore struct {
+	mu     sync.Mutex
+	events []Event
+	err    error
+}
+
+func (s *memStore) Append(_ context.Context, e Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	e.Id = int64(len(s.events) + 1)
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *memStore) ListAfter(_ context.Context, afterId int64, limit int) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	var list []Event
+	for _, e := range s.events {
+		if e.Id > afterId && len(list) < limit {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+func (s *memStore) LastId(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.events)), s.err
+}
+
+func TestCourierNotifier_UpdateRecordsCurrentState(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockcourierService(ctrl)
+	store := &memStore{}
+	n := NewCourierNotifier(svc, newTxManager(ctrl), store)
+
+	id := int64(7)
+	status := model.CourierStatusPaused
+	req := &model.UpdateCourierRequest{Id: &id, Status: &status}
+
+	svc.EXPECT().UpdateCourier(gomock.Any(), req).Return(nil)
+	svc.EXPECT().GetCourierById(gomock.Any(), id).Return(&model.Courier{Id: id, Status: status, Transport: model.Car}, nil)
+
+	require.NoError(t, n.UpdateCourier(context.Background(), req))
+
+	require.Len(t, store.events, 1)
+	e := store.events[0]
+	require.Equal(t, TypeCourierUpdated, e.Type)
+	require.Equal(t, id, e.CourierId)
+	require.Equal(t, "paused", e.CourierStatus)
+	require.Equal(t, "car", e.Transport)
+	require.Empty(t, e.Actor)
+}
+
+func TestAssignNotifier_AssignRecords(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockassignService(ctrl)
+	store := &memStore{}
+	n := NewAssignNotifier(svc, newTxManager(ctrl), store)
+
+	deadline := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	svc.EXPECT().AssignCourier(gomock.Any(), "o1").
+		Return(&model.AssignCourier{CourierId: 3, OrderId: "o1", Transport: model.Scooter, Deadline: deadline}, nil)
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "dispatch-1", Role: auth.RoleDispatcher})
+	_, err := n.AssignCourier(ctx, "o1")
+	require.NoError(t, err)
+
+	require.Len(t, store.events, 1)
+	e := store.events[0]
+	require.Equal(t, TypeDeliveryAssigned, e.Type)
+	require.Equal(t, int64(3), e.CourierId)
+	require.Equal(t, "busy", e.CourierStatus)
+	require.Equal(t, deadline, e.Deadline)
+	require.Equal(t, "dispatch-1", e.Actor)
+	require.Equal(t, "dispatcher", e.ActorRole)
+}
+
+func TestAssignNotifier_CompleteRecordsCourier(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockassignService(ctrl)
+	store := &memStore{}
+	n := NewAssignNotifier(svc, newTxManager(ctrl), store)
+
+	svc.EXPECT().CompleteCourier(gomock.Any(), "o1").
+		Return(&model.CompleteCourier{CourierId: 3, OrderId: "o1"}, nil)
+
+	_, err := n.CompleteCourier(context.Background(), "o1")
+	require.NoError(t, err)
+
+	require.Len(t, store.events, 1)
+	e := store.events[0]
+	require.Equal(t, TypeDeliveryCompleted, e.Type)
+	require.Equal(t, int64(3), e.CourierId)
+	require.Equal(t, "available", e.CourierStatus)
+	require.Equal(t, "o1", e.OrderId)
+}
+
+func TestAssignNotifier_ChangeDeliveryStatusRecordsStatusAndReleasedCourier(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockassignService(ctrl)
+	store := &memStore{}
+	n := NewAssignNotifier(svc, newTxManager(ctrl), store)
+
+	for _, change := range []model.DeliveryStatusChange{
+		{CourierId: 3, OrderId: "o1", From: model.DeliveryAssigned, To: model.DeliveryPickedUp},
+		{CourierId: 3, OrderId: "o1", From: model.DeliveryPickedUp, To: model.DeliveryPickedUp},
+		{CourierId: 3, OrderId: "o1", From: model.DeliveryPickedUp, To: model.DeliveryFailed},
+		{CourierId: 3, OrderId: "o1", From: model.DeliveryFailed, To: model.DeliveryReturned},
+	} {
+		svc.EXPECT().ChangeDeliveryStatus(gomock.Any(), "o1", change.To).Return(&change, nil)
+
+		got, err := n.ChangeDeliveryStatus(context.Background(), "o1", change.To)
+		require.NoError(t, err)
+		require.Equal(t, &change, got)
+	}
+
+	require.Len(t, store.events, 3, "a repeated status records nothing")
+
+	require.Equal(t, TypeDeliveryPickedUp, store.events[0].Type)
+	require.Equal(t, int64(3), store.events[0].CourierId)
+	require.Empty(t, store.events[0].CourierStatus)
+
+	require.Equal(t, TypeDeliveryFailed, store.events[1].Type)
+	require.Equal(t, "available", store.events[1].CourierStatus)
+
+	require.Equal(t, TypeDeliveryReturned, store.events[2].Type)
+	require.Empty(t, store.events[2].CourierStatus, "the courier was freed by the failure already")
+}
+
+func TestAssignNotifier_ErrorRecordsNothing(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockassignService(ctrl)
+	store := &memStore{}
+	n := NewAssignNotifier(svc, newTxManager(ctrl), store)
+
+	svcErr := errors.New("boom")
+	svc.EXPECT().UnassignCourier(gomock.Any(), "o1").Return(nil, svcErr)
+	svc.EXPECT().ChangeDeliveryStatus(gomock.Any(), "o1", model.DeliveryPickedUp).Return(nil, svcErr)
+
+	_, err := n.UnassignCourier(context.Background(), "o1")
+	require.ErrorIs(t, err, svcErr)
+	_, err = n.ChangeDeliveryStatus(context.Background(), "o1", model.DeliveryPickedUp)
+	require.ErrorIs(t, err, svcErr)
+	require.Empty(t, store.events)
+}
+
+func TestAssignNotifier_AppendErrorFailsTheWrite(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockassignService(ctrl)
+	store := &memStore{}
+	n := NewAssignNotifier(svc, newTxManager(ctrl), store)
+
+	appendErr := errors.New("append failed")
+	store.err = appendErr
+	svc.EXPECT().UnassignCourier(gomock.Any(), "o1").Return(&model.UnassignCourier{CourierId: 3, OrderId: "o1"}, nil)
+
+	unassign, err := n.UnassignCourier(context.Background(), "o1")
+	require.ErrorIs(t, err, appendErr)
+	require.Nil(t, unassign)
+}
+
+func TestAssignNotifier_BatchRecordsAssignedOrders(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockassignService(ctrl)
+	store := &memStore{}
+	n := NewAssignNotifier(svc, newTxManager(ctrl), store)
+
+	svc.EXPECT().AssignCouriers(gomock.Any(), []string{"o1", "o2"}, model.BatchPartial).Return([]model.AssignResult{
+		{OrderId: "o1", Assign: &model.AssignCourier{CourierId: 3, OrderId: "o1", Transport: model.Car}},
+		{OrderId: "o2", Err: errors.New("no available couriers now")},
+	}, nil)
+
+	results, err := n.AssignCouriers(context.Background(), []string{"o1", "o2"}, model.BatchPartial)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Len(t, store.events, 1)
+	require.Equal(t, TypeDeliveryAssigned, store.events[0].Type)
+	require.Equal(t, "o1", store.events[0].OrderId)
+}
@@ -0,0 +1,22 @@
+package events
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+)
+
+type courierService interface {
+	CreateCourier(ctx context.Context, c *model.CreateCourierRequest) (*model.Courier, error)
+	GetCourierById(ctx context.Context, id int64) (*model.Courier, error)
+	GetAllCouriers(ctx context.Context) ([]model.Courier, error)
+	UpdateCourier(ctx context.Context, req *model.UpdateCourierRequest) error
+}
+
+type assignService interface {
+	AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error)
+	AssignOrder(ctx context.Context, order *model.Order) (*model.AssignCourier, error)
+	AssignCouriers(ctx context.Context, orderIds []string, policy model.BatchPolicy) ([]model.AssignResult, error)
+	UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error)
+	CompleteCourier(ctx context.Context, orderId string) (*model.CompleteCourier, error)
+	ChangeDeliveryStatus(ctx context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryStatusChange, error)
+}
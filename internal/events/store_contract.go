@@ -0,0 +1,19 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+type store interface {
+	Append(ctx context.Context, e Event) error
+}
+
+type eventLog interface {
+	ListAfter(ctx context.Context, afterId int64, limit int) ([]Event, error)
+	LastId(ctx context.Context) (int64, error)
+}
+
+type purger interface {
+	DeleteOlderThan(ctx context.Context, age time.Duration) (int64, error)
+}
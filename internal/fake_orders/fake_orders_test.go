@@ -0,0 +1,157 @@
+package fake_orders
+
+import (
+	"bufio"
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/handlers/queues/order/changed"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newStore() *order.MemoryGateway {
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return order.NewMemoryGateway(
+		model.Order{Id: "o1", RestaurantId: "r1", Status: "created", CreatedAt: t0,
+			Items: []model.OrderItem{{Name: "pizza", Price: 100, Quantity: 2}}},
+		model.Order{Id: "o2", Status: "created", CreatedAt: t0.Add(time.Minute)},
+		model.Order{Id: "o3", Status: "created", CreatedAt: t0.Add(2 * time.Minute)},
+	)
+}
+
+func TestHTTP_ServesHttpGateway(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(Routes(newStore()))
+	defer srv.Close()
+
+	gw := order.NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	o, err := gw.GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, "r1", o.RestaurantId)
+	require.Equal(t, int64(2), o.Snapshot().ItemsCount)
+
+	_, err = gw.GetOrder(context.Background(), "missing")
+	require.ErrorIs(t, err, order.ErrOrderNotFound)
+
+	_, err = gw.ListSince(context.Background(), time.Time{}, 2, "")
+	require.ErrorIs(t, err, order.ErrListUnsupported)
+}
+
+func TestGRPC_ServesGrpcGateway(t *testing.T) {
+	t.Parallel()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterOrdersServiceServer(s, NewOrdersServer(newStore()))
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	gw := order.NewGrpcGateway(conn, time.Second, 1)
+
+	o, err := gw.GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, "r1", o.RestaurantId)
+
+	_, err = gw.GetOrder(context.Background(), "missing")
+	require.ErrorIs(t, err, order.ErrOrderNotFound)
+
+	page, err := gw.ListSince(context.Background(), time.Date(2026, 3, 1, 10, 1, 0, 0, time.UTC), 0, "")
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 2)
+	require.Equal(t, "o2", page.Orders[0].Id)
+}
+
+func TestPlay_UpdatesStoreAndWritesEvents(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"orders": [{"order_id": "o1", "restaurant_id": "r1", "status": "created"}],
+		"steps": [
+			{"order_id": "o1", "status": "cancelled"},
+			{"after": "10ms", "order_id": "o2", "status": "created"}
+		]
+	}`), 0o644))
+
+	sc, err := LoadScenario(path)
+	require.NoError(t, err)
+
+	store := order.NewMemoryGateway()
+	Seed(store, sc, time.Now().UTC())
+
+	sinkPath := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := NewFileSink(sinkPath)
+	require.NoError(t, err)
+
+	require.NoError(t, Play(context.Background(), store, sc, sink))
+	require.NoError(t, sink.Close())
+
+	o1, err := store.GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, "cancelled", o1.Status)
+	require.Equal(t, "r1", o1.RestaurantId)
+
+	o2, err := store.GetOrder(context.Background(), "o2")
+	require.NoError(t, err)
+	require.Equal(t, "created", o2.Status)
+	require.False(t, o2.CreatedAt.IsZero())
+
+	f, err := os.Open(sinkPath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var events []changed.OrderStatusChanged
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev changed.OrderStatusChanged
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &ev))
+		events = append(events, ev)
+	}
+	require.Len(t, events, 2)
+	require.Equal(t, "o1", events[0].OrderID)
+	require.Equal(t, "cancelled", events[0].Status)
+	require.Equal(t, "o2", events[1].OrderID)
+}
+
+func TestLoadScenario_RejectsIncompleteStep(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"steps": [{"after": "1s", "order_id": "o1"}]}`), 0o644))
+
+	_, err := LoadScenario(path)
+	require.Error(t, err)
+}
+
+func TestPlay_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	sc := &Scenario{Steps: []Step{{After: Duration(time.Hour), OrderId: "o1", Status: "created"}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.ErrorIs(t, Play(ctx, order.NewMemoryGateway(), sc, nil), context.Canceled)
+}
@@ -0,0 +1,55 @@
+package fake_orders
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OrdersServer serves the OrdersService gRPC API from the in-memory store.
+type OrdersServer struct {
+	pb.UnimplementedOrdersServiceServer
+	store *order.MemoryGateway
+}
+
+func NewOrdersServer(store *order.MemoryGateway) *OrdersServer {
+	return &OrdersServer{store: store}
+}
+
+func (s *OrdersServer) GetOrderById(ctx context.Context, req *pb.GetOrderByIdRequest) (*pb.GetOrderByIdResponse, error) {
+	o, err := s.store.GetOrder(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &pb.GetOrderByIdResponse{Order: order.OrderToProto(o)}, nil
+}
+
+func (s *OrdersServer) GetOrders(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "negative limit")
+	}
+
+	var from time.Time
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+
+	page, err := s.store.ListSince(ctx, from, int(req.GetLimit()), req.GetPageToken())
+	if err != nil {
+		if errors.Is(err, order.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.GetOrdersResponse{Orders: make([]*pb.Order, 0, len(page.Orders)), NextPageToken: page.NextPageToken}
+	for i := range page.Orders {
+		resp.Orders = append(resp.Orders, order.OrderToProto(&page.Orders[i]))
+	}
+	return resp, nil
+}
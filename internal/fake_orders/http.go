@@ -0,0 +1,38 @@
+package fake_orders
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Routes serves the public order API the HTTP gateway talks to.
+func Routes(store *order.MemoryGateway) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/public/api/v1/order/{id}", func(w http.ResponseWriter, r *http.Request) {
+		o, err := store.GetOrder(r.Context(), chi.URLParam(r, "id"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, "order not found")
+			return
+		}
+		writeJSON(w, order.NewOrderDto(o))
+	})
+
+	return r
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": msg,
+	})
+}
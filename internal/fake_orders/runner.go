@@ -0,0 +1,76 @@
+package fake_orders
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/handlers/queues/order/changed"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Seed puts the scenario orders into the store. Orders without created_at
+// get the current time so incremental polling picks them up.
+func Seed(store *order.MemoryGateway, sc *Scenario, now time.Time) {
+	for i := range sc.Orders {
+		o := sc.Orders[i].ToModel()
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = now
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = o.CreatedAt
+		}
+		store.Put(*o)
+	}
+}
+
+// Play runs the scenario steps in order until they end or ctx is done.
+func Play(ctx context.Context, store *order.MemoryGateway, sc *Scenario, sink Sink) error {
+	for i, step := range sc.Steps {
+		if step.After > 0 {
+			timer := time.NewTimer(time.Duration(step.After))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if err := apply(ctx, store, step, sink, time.Now().UTC()); err != nil {
+			return fmt.Errorf("scenario step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func apply(ctx context.Context, store *order.MemoryGateway, step Step, sink Sink, now time.Time) error {
+	o, err := store.GetOrder(ctx, step.OrderId)
+	if err != nil {
+		if !errors.Is(err, order.ErrOrderNotFound) {
+			return err
+		}
+		o = &model.Order{Id: step.OrderId, CreatedAt: now}
+	}
+
+	o.Status = step.Status
+	o.UpdatedAt = now
+	store.Put(*o)
+
+	if sink == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(changed.OrderStatusChanged{
+		OrderID:   o.Id,
+		Status:    o.Status,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	return sink.Publish(ctx, o.Id, value)
+}
@@ -0,0 +1,67 @@
+package fake_orders
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Scenario seeds the store with Orders and then plays Steps one after another.
+type Scenario struct {
+	Orders []order.OrderDto `json:"orders"`
+	Steps  []Step           `json:"steps"`
+}
+
+// Step changes the status of an order After the previous step and publishes
+// the matching status event. An unknown order is created on the fly.
+type Step struct {
+	After   Duration `json:"after"`
+	OrderId string   `json:"order_id"`
+	Status  string   `json:"status"`
+}
+
+// Duration reads durations such as "1500ms" from JSON.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("duration must be a string like \"2s\": %w", err)
+	}
+	if raw == "" {
+		*d = 0
+		return nil
+	}
+
+	v, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scenario: %w", err)
+	}
+
+	var sc Scenario
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, fmt.Errorf("parse scenario: %w", err)
+	}
+
+	for i, step := range sc.Steps {
+		if step.OrderId == "" || step.Status == "" {
+			return nil, fmt.Errorf("scenario step %d: order_id and status are required", i+1)
+		}
+		if step.After < 0 {
+			return nil, fmt.Errorf("scenario step %d: negative delay", i+1)
+		}
+	}
+
+	return &sc, nil
+}
@@ -0,0 +1,78 @@
+package fake_orders
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// Sink receives the status events produced by a scenario.
+type Sink interface {
+	Publish(ctx context.Context, key string, value []byte) error
+	Close() error
+}
+
+// FileSink appends one event per line, in the format cmd/replay --file reads.
+type FileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open sink file: %w", err)
+	}
+	return &FileSink{f: f}, nil
+}
+
+func (s *FileSink) Publish(ctx context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.f.Write(append(value, '\n')); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
+
+// KafkaSink publishes events keyed by order id, so a local broker feeds the
+// worker exactly like the real order service would.
+type KafkaSink struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewKafkaSink(brokers []string, topic string, cfg *sarama.Config) (*KafkaSink, error) {
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+
+	producer, err := sarama.NewSyncProducer(brokers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("kafka producer: %w", err)
+	}
+	return &KafkaSink{producer: producer, topic: topic}, nil
+}
+
+func (s *KafkaSink) Publish(ctx context.Context, key string, value []byte) error {
+	_, _, err := s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: s.topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(value),
+	})
+	if err != nil {
+		return fmt.Errorf("publish event: %w", err)
+	}
+	return nil
+}
+
+func (s *KafkaSink) Close() error {
+	return s.producer.Close()
+}
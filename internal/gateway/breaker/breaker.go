@@ -0,0 +1,160 @@
+package breaker
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/observability"
+	"sync"
+	"time"
+)
+
+// ErrOpen is transient: the same call may succeed once the breaker lets
+// calls through again.
+var ErrOpen error = openError{}
+
+type openError struct{}
+
+func (openError) Error() string { return "circuit breaker is open" }
+
+func (openError) Transient() bool { return true }
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker opens after threshold consecutive failures and rejects calls for
+// cooldown. After that it lets up to probes calls through in half-open state:
+// a success closes it again, a failure reopens it. Every allowed call must end
+// in Success, Failure or Cancel, or its probe slot is never given back.
+type Breaker struct {
+	mu        sync.Mutex
+	name      string
+	threshold int
+	cooldown  time.Duration
+	probes    int
+
+	state    State
+	failures int
+	openedAt time.Time
+	inFlight int
+
+	now func() time.Time
+}
+
+func New(name string, threshold int, cooldown time.Duration, probes int) *Breaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+	if probes <= 0 {
+		probes = 1
+	}
+
+	b := &Breaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		probes:    probes,
+		now:       time.Now,
+	}
+	observability.GatewayCircuitState.WithLabelValues(name).Set(float64(StateClosed))
+
+	return b
+}
+
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrOpen
+		}
+		b.setState(StateHalfOpen)
+		b.inFlight = 1
+		return nil
+	case StateHalfOpen:
+		if b.inFlight >= b.probes {
+			return ErrOpen
+		}
+		b.inFlight++
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	if b.state == StateHalfOpen {
+		b.inFlight = 0
+		b.setState(StateClosed)
+	}
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateHalfOpen:
+		b.inFlight = 0
+		b.open()
+	case StateClosed:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.open()
+		}
+	}
+}
+
+// Cancel ends an allowed call that says nothing about the service's health,
+// such as one whose context was cancelled. In half-open state it frees the
+// probe slot for the next call.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.inFlight > 0 {
+		b.inFlight--
+	}
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) open() {
+	b.failures = 0
+	b.openedAt = b.now()
+	b.setState(StateOpen)
+}
+
+func (b *Breaker) setState(s State) {
+	if b.state == s {
+		return
+	}
+	b.state = s
+	observability.GatewayCircuitState.WithLabelValues(b.name).Set(float64(s))
+	observability.GatewayCircuitTransitionsTotal.WithLabelValues(b.name, s.String()).Inc()
+}
@@ -0,0 +1,112 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*Breaker, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
+	b := New("test", threshold, cooldown, 1)
+	b.now = clock.now
+	return b, clock
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	t.Parallel()
+
+	b, _ := newTestBreaker(3, time.Second)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, b.Allow())
+		b.Failure()
+	}
+	require.Equal(t, StateClosed, b.State())
+
+	require.NoError(t, b.Allow())
+	b.Failure()
+	require.Equal(t, StateOpen, b.State())
+	require.ErrorIs(t, b.Allow(), ErrOpen)
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	t.Parallel()
+
+	b, _ := newTestBreaker(2, time.Second)
+
+	b.Failure()
+	b.Success()
+	b.Failure()
+	require.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenProbe_Success(t *testing.T) {
+	t.Parallel()
+
+	b, clock := newTestBreaker(1, time.Second)
+
+	b.Failure()
+	require.ErrorIs(t, b.Allow(), ErrOpen)
+
+	clock.t = clock.t.Add(time.Second)
+
+	require.NoError(t, b.Allow())
+	require.Equal(t, StateHalfOpen, b.State())
+	require.ErrorIs(t, b.Allow(), ErrOpen)
+
+	b.Success()
+	require.Equal(t, StateClosed, b.State())
+	require.NoError(t, b.Allow())
+}
+
+func TestBreaker_HalfOpenProbe_Failure(t *testing.T) {
+	t.Parallel()
+
+	b, clock := newTestBreaker(1, time.Second)
+
+	b.Failure()
+	clock.t = clock.t.Add(time.Second)
+
+	require.NoError(t, b.Allow())
+	b.Failure()
+
+	require.Equal(t, StateOpen, b.State())
+	require.ErrorIs(t, b.Allow(), ErrOpen)
+}
+
+func TestBreaker_HalfOpenProbe_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	b, clock := newTestBreaker(1, time.Second)
+
+	b.Failure()
+	clock.t = clock.t.Add(time.Second)
+
+	require.NoError(t, b.Allow())
+	b.Cancel()
+
+	require.Equal(t, StateHalfOpen, b.State())
+	require.NoError(t, b.Allow())
+	b.Success()
+	require.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_CancelWhenClosed(t *testing.T) {
+	t.Parallel()
+
+	b, _ := newTestBreaker(1, time.Second)
+
+	require.NoError(t, b.Allow())
+	b.Cancel()
+
+	require.Equal(t, StateClosed, b.State())
+	require.NoError(t, b.Allow())
+}
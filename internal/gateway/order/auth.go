@@ -0,0 +1,82 @@
+package order
+
+import (
+	"course-go-avito-SitnikovArtem06/pkg/reload"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	AuthNone   = "none"
+	AuthBearer = "bearer"
+	AuthAPIKey = "api_key"
+
+	DefaultAPIKeyHeader = "X-Api-Key"
+)
+
+// authTransport adds the order service credentials to every request.
+type authTransport struct {
+	next   http.RoundTripper
+	header string
+	prefix string
+	secret func() string
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, t.prefix+t.secret())
+	return t.next.RoundTrip(req)
+}
+
+// NewAuthTransport wraps next so that requests carry a bearer token or an
+// API key. secret is asked on every request, so it may rotate.
+func NewAuthTransport(next http.RoundTripper, scheme, header string, secret func() string) (http.RoundTripper, error) {
+	switch scheme {
+	case AuthBearer:
+		return &authTransport{next: next, header: "Authorization", prefix: "Bearer ", secret: secret}, nil
+	case AuthAPIKey:
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+		return &authTransport{next: next, header: header, secret: secret}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth scheme %q, want %s, %s or %s", scheme, AuthNone, AuthBearer, AuthAPIKey)
+	}
+}
+
+// httpAuthFromEnv wraps next according to ORDER_HTTP_AUTH. The secret comes
+// from ORDER_HTTP_TOKEN_FILE, re-read when the file changes, or from
+// ORDER_HTTP_TOKEN.
+func httpAuthFromEnv(next http.RoundTripper) (http.RoundTripper, error) {
+	scheme := os.Getenv("ORDER_HTTP_AUTH")
+	if scheme == "" || scheme == AuthNone {
+		return next, nil
+	}
+
+	var secret func() string
+
+	if path := os.Getenv("ORDER_HTTP_TOKEN_FILE"); path != "" {
+		token, err := reload.NewValue(parseToken, path)
+		if err != nil {
+			return nil, fmt.Errorf("ORDER_HTTP_TOKEN_FILE: %w", err)
+		}
+		secret = token.Get
+	} else if value := os.Getenv("ORDER_HTTP_TOKEN"); value != "" {
+		secret = func() string { return value }
+	} else {
+		return nil, fmt.Errorf("ORDER_HTTP_AUTH=%s needs ORDER_HTTP_TOKEN_FILE or ORDER_HTTP_TOKEN", scheme)
+	}
+
+	return NewAuthTransport(next, scheme, os.Getenv("ORDER_HTTP_API_KEY_HEADER"), secret)
+}
+
+func parseToken(data ...[]byte) (string, error) {
+	token := strings.TrimSpace(string(data[0]))
+	if token == "" {
+		return "", errors.New("token file is empty")
+	}
+	return token, nil
+}
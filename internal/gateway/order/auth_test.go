@@ -0,0 +1,78 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/pkg/reload"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpAuth_BearerTokenFileRotates(t *testing.T) {
+	var got []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"order_id":"o1"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "token")
+	t0 := time.Now().Add(-time.Hour)
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, t0, t0))
+
+	t.Setenv("ORDER_HTTP_AUTH", AuthBearer)
+	t.Setenv("ORDER_HTTP_TOKEN_FILE", path)
+
+	client, err := httpClientFromEnv()
+	require.NoError(t, err)
+	gw := NewHttpGateway(srv.URL, client)
+
+	_, err = gw.GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	require.NoError(t, os.Chtimes(path, t0.Add(time.Minute), t0.Add(time.Minute)))
+
+	// The new token is picked up within reload.CheckInterval.
+	require.Eventually(t, func() bool {
+		_, err := gw.GetOrder(context.Background(), "o1")
+		return err == nil && got[len(got)-1] == "Bearer second"
+	}, 3*reload.CheckInterval, 50*time.Millisecond)
+
+	require.Equal(t, "Bearer first", got[0])
+}
+
+func TestHttpAuth_APIKeyHeader(t *testing.T) {
+	var got string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Order-Key")
+		_, _ = w.Write([]byte(`{"order_id":"o1"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("ORDER_HTTP_AUTH", AuthAPIKey)
+	t.Setenv("ORDER_HTTP_TOKEN", "secret")
+	t.Setenv("ORDER_HTTP_API_KEY_HEADER", "X-Order-Key")
+
+	client, err := httpClientFromEnv()
+	require.NoError(t, err)
+
+	_, err = NewHttpGateway(srv.URL, client).GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, "secret", got)
+}
+
+func TestHttpAuth_MissingSecret(t *testing.T) {
+	t.Setenv("ORDER_HTTP_AUTH", AuthBearer)
+
+	_, err := httpClientFromEnv()
+	require.Error(t, err)
+}
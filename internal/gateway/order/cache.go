@@ -0,0 +1,151 @@
+package order
+
+import (
+	"container/list"
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/observability"
+	"errors"
+	"sync"
+	"time"
+)
+
+type getter interface {
+	GetOrder(ctx context.Context, orderId string) (*model.Order, error)
+}
+
+type cacheEntry struct {
+	orderID   string
+	order     *model.Order
+	err       error
+	expiresAt time.Time
+}
+
+// CachedGateway keeps recent GetOrder results in a bounded LRU. Not found
+// answers are cached for a shorter negativeTTL, other errors are not cached.
+type CachedGateway struct {
+	next        getter
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	// invalidations counts Invalidate calls, so that a fetch that raced
+	// one is not cached.
+	invalidations uint64
+
+	now func() time.Time
+}
+
+func NewCachedGateway(next getter, size int, ttl, negativeTTL time.Duration) *CachedGateway {
+	return &CachedGateway{
+		next:        next,
+		size:        size,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		lru:         list.New(),
+		entries:     make(map[string]*list.Element),
+		now:         time.Now,
+	}
+}
+
+func (c *CachedGateway) GetOrder(ctx context.Context, orderId string) (*model.Order, error) {
+
+	if e, ok := c.lookup(orderId); ok {
+		if e.err != nil {
+			observability.OrderCacheRequestsTotal.WithLabelValues("negative_hit").Inc()
+			return nil, e.err
+		}
+		observability.OrderCacheRequestsTotal.WithLabelValues("hit").Inc()
+		copied := *e.order
+		return &copied, nil
+	}
+
+	observability.OrderCacheRequestsTotal.WithLabelValues("miss").Inc()
+
+	fetchedAt := c.now()
+	generation := c.generation()
+
+	order, err := c.next.GetOrder(ctx, orderId)
+	switch {
+	case err == nil:
+		copied := *order
+		c.store(generation, &cacheEntry{orderID: orderId, order: &copied, expiresAt: fetchedAt.Add(c.ttl)})
+	case errors.Is(err, ErrOrderNotFound) && c.negativeTTL > 0:
+		c.store(generation, &cacheEntry{orderID: orderId, err: err, expiresAt: fetchedAt.Add(c.negativeTTL)})
+	}
+
+	return order, err
+}
+
+// Invalidate drops the cached order, since it may have changed. A fetch
+// that was in flight meanwhile is returned but not cached.
+func (c *CachedGateway) Invalidate(orderId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidations++
+
+	if el, ok := c.entries[orderId]; ok {
+		c.remove(el)
+		observability.OrderCacheInvalidationsTotal.Inc()
+	}
+}
+
+func (c *CachedGateway) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.invalidations
+}
+
+func (c *CachedGateway) lookup(orderId string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[orderId]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*cacheEntry)
+	if !c.now().Before(e.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(el)
+	return e, true
+}
+
+func (c *CachedGateway) store(generation uint64, e *cacheEntry) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.invalidations {
+		return
+	}
+
+	if el, ok := c.entries[e.orderID]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[e.orderID] = c.lru.PushFront(e)
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *CachedGateway) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).orderID)
+}
@@ -0,0 +1,151 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingGetter struct {
+	calls  map[string]int
+	status map[string]string
+	err    error
+	// onGet runs after the answer is read, as if it were still on the wire.
+	onGet func()
+}
+
+func (g *countingGetter) GetOrder(ctx context.Context, orderId string) (*model.Order, error) {
+	g.calls[orderId]++
+	if g.err != nil {
+		return nil, g.err
+	}
+	status, ok := g.status[orderId]
+	if g.onGet != nil {
+		g.onGet()
+	}
+	if !ok {
+		return nil, fmt.Errorf("order gateway: %w", ErrOrderNotFound)
+	}
+	return &model.Order{Id: orderId, Status: status}, nil
+}
+
+func newTestCache(next getter, size int) (*CachedGateway, *time.Time) {
+	now := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	c := NewCachedGateway(next, size, time.Minute, 10*time.Second)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCachedGateway_HitWithinTTL(t *testing.T) {
+	t.Parallel()
+
+	next := &countingGetter{calls: map[string]int{}, status: map[string]string{"o1": "created"}}
+	c, now := newTestCache(next, 10)
+
+	for i := 0; i < 3; i++ {
+		o, err := c.GetOrder(context.Background(), "o1")
+		require.NoError(t, err)
+		require.Equal(t, "created", o.Status)
+	}
+	require.Equal(t, 1, next.calls["o1"])
+
+	*now = now.Add(time.Minute)
+
+	_, err := c.GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, 2, next.calls["o1"])
+}
+
+func TestCachedGateway_NegativeCaching(t *testing.T) {
+	t.Parallel()
+
+	next := &countingGetter{calls: map[string]int{}, status: map[string]string{}}
+	c, now := newTestCache(next, 10)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetOrder(context.Background(), "missing")
+		require.ErrorIs(t, err, ErrOrderNotFound)
+	}
+	require.Equal(t, 1, next.calls["missing"])
+
+	*now = now.Add(10 * time.Second)
+
+	_, err := c.GetOrder(context.Background(), "missing")
+	require.ErrorIs(t, err, ErrOrderNotFound)
+	require.Equal(t, 2, next.calls["missing"])
+}
+
+func TestCachedGateway_ErrorsAreNotCached(t *testing.T) {
+	t.Parallel()
+
+	next := &countingGetter{calls: map[string]int{}, err: errors.New("status=503")}
+	c, _ := newTestCache(next, 10)
+
+	_, _ = c.GetOrder(context.Background(), "o1")
+	_, _ = c.GetOrder(context.Background(), "o1")
+	require.Equal(t, 2, next.calls["o1"])
+}
+
+func TestCachedGateway_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	next := &countingGetter{calls: map[string]int{}, status: map[string]string{"o1": "created", "o2": "created", "o3": "created"}}
+	c, _ := newTestCache(next, 2)
+
+	ctx := context.Background()
+	_, _ = c.GetOrder(ctx, "o1")
+	_, _ = c.GetOrder(ctx, "o2")
+	_, _ = c.GetOrder(ctx, "o1")
+	_, _ = c.GetOrder(ctx, "o3")
+
+	_, _ = c.GetOrder(ctx, "o1")
+	_, _ = c.GetOrder(ctx, "o2")
+
+	require.Equal(t, 1, next.calls["o1"])
+	require.Equal(t, 2, next.calls["o2"])
+}
+
+func TestCachedGateway_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	next := &countingGetter{calls: map[string]int{}, status: map[string]string{"o1": "created", "o2": "created"}}
+	c, _ := newTestCache(next, 10)
+	ctx := context.Background()
+
+	_, _ = c.GetOrder(ctx, "o1")
+	_, _ = c.GetOrder(ctx, "o2")
+
+	c.Invalidate("o1")
+	_, _ = c.GetOrder(ctx, "o1")
+	_, _ = c.GetOrder(ctx, "o2")
+	require.Equal(t, 2, next.calls["o1"])
+	require.Equal(t, 1, next.calls["o2"])
+}
+
+func TestCachedGateway_DoesNotCacheFetchRacingInvalidate(t *testing.T) {
+	t.Parallel()
+
+	next := &countingGetter{calls: map[string]int{}, status: map[string]string{"o1": "created"}}
+	c, _ := newTestCache(next, 10)
+	ctx := context.Background()
+
+	// The status changes while the first fetch is on the wire.
+	next.onGet = func() {
+		next.status["o1"] = "picked_up"
+		c.Invalidate("o1")
+	}
+	o, err := c.GetOrder(ctx, "o1")
+	require.NoError(t, err)
+	require.Equal(t, "created", o.Status)
+
+	next.onGet = nil
+	o, err = c.GetOrder(ctx, "o1")
+	require.NoError(t, err)
+	require.Equal(t, "picked_up", o.Status)
+	require.Equal(t, 2, next.calls["o1"])
+}
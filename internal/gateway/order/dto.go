@@ -1,9 +1,172 @@
 ﻿package order
 
-import "time"
+import (
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
 
 type OrderDto struct {
-	OrderID   string    `json:"order_id"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	OrderID           string          `json:"order_id"`
+	UserID            string          `json:"user_id"`
+	OrderNumber       string          `json:"order_number"`
+	Fio               string          `json:"fio"`
+	RestaurantID      string          `json:"restaurant_id"`
+	Items             []OrderItemDto  `json:"items"`
+	TotalPrice        int64           `json:"total_price"`
+	Address           OrderAddressDto `json:"address"`
+	Status            string          `json:"status"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	EstimatedDelivery time.Time       `json:"estimated_delivery"`
+}
+
+type OrderItemDto struct {
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Quantity int64  `json:"quantity"`
+}
+
+type OrderAddressDto struct {
+	Street    string `json:"street"`
+	House     string `json:"house"`
+	Apartment string `json:"apartment"`
+	Floor     string `json:"floor"`
+	Comment   string `json:"comment"`
+}
+
+func (d *OrderDto) ToModel() *model.Order {
+	items := make([]model.OrderItem, 0, len(d.Items))
+	for _, item := range d.Items {
+		items = append(items, model.OrderItem{Name: item.Name, Price: item.Price, Quantity: item.Quantity})
+	}
+
+	return &model.Order{
+		Id:           d.OrderID,
+		UserId:       d.UserID,
+		Number:       d.OrderNumber,
+		CustomerName: d.Fio,
+		RestaurantId: d.RestaurantID,
+		Items:        items,
+		TotalPrice:   d.TotalPrice,
+		Address: model.OrderAddress{
+			Street:    d.Address.Street,
+			House:     d.Address.House,
+			Apartment: d.Address.Apartment,
+			Floor:     d.Address.Floor,
+			Comment:   d.Address.Comment,
+		},
+		Status:            d.Status,
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
+		EstimatedDelivery: d.EstimatedDelivery,
+	}
+}
+
+// NewOrderDto is the reverse of ToModel, for serving orders over HTTP.
+func NewOrderDto(o *model.Order) OrderDto {
+	items := make([]OrderItemDto, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, OrderItemDto{Name: item.Name, Price: item.Price, Quantity: item.Quantity})
+	}
+
+	return OrderDto{
+		OrderID:      o.Id,
+		UserID:       o.UserId,
+		OrderNumber:  o.Number,
+		Fio:          o.CustomerName,
+		RestaurantID: o.RestaurantId,
+		Items:        items,
+		TotalPrice:   o.TotalPrice,
+		Address: OrderAddressDto{
+			Street:    o.Address.Street,
+			House:     o.Address.House,
+			Apartment: o.Address.Apartment,
+			Floor:     o.Address.Floor,
+			Comment:   o.Address.Comment,
+		},
+		Status:            o.Status,
+		CreatedAt:         o.CreatedAt,
+		UpdatedAt:         o.UpdatedAt,
+		EstimatedDelivery: o.EstimatedDelivery,
+	}
+}
+
+// OrderToProto is the reverse of orderFromProto, for serving orders over gRPC.
+func OrderToProto(o *model.Order) *pb.Order {
+	items := make([]*pb.Item, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, &pb.Item{Name: item.Name, Price: item.Price, Quantity: item.Quantity})
+	}
+
+	order := &pb.Order{
+		Id:           o.Id,
+		UserId:       o.UserId,
+		OrderNumber:  o.Number,
+		Fio:          o.CustomerName,
+		RestaurantId: o.RestaurantId,
+		Items:        items,
+		TotalPrice:   o.TotalPrice,
+		Address: &pb.DeliveryAddress{
+			Street:    o.Address.Street,
+			House:     o.Address.House,
+			Apartment: o.Address.Apartment,
+			Floor:     o.Address.Floor,
+			Comment:   o.Address.Comment,
+		},
+		Status: o.Status,
+	}
+
+	if !o.CreatedAt.IsZero() {
+		order.CreatedAt = timestamppb.New(o.CreatedAt)
+	}
+	if !o.UpdatedAt.IsZero() {
+		order.UpdatedAt = timestamppb.New(o.UpdatedAt)
+	}
+	if !o.EstimatedDelivery.IsZero() {
+		order.EstimatedDelivery = timestamppb.New(o.EstimatedDelivery)
+	}
+
+	return order
+}
+
+func orderFromProto(o *pb.Order) model.Order {
+	items := make([]model.OrderItem, 0, len(o.GetItems()))
+	for _, item := range o.GetItems() {
+		items = append(items, model.OrderItem{Name: item.GetName(), Price: item.GetPrice(), Quantity: item.GetQuantity()})
+	}
+
+	addr := o.GetAddress()
+
+	order := model.Order{
+		Id:           o.GetId(),
+		UserId:       o.GetUserId(),
+		Number:       o.GetOrderNumber(),
+		CustomerName: o.GetFio(),
+		RestaurantId: o.GetRestaurantId(),
+		Items:        items,
+		TotalPrice:   o.GetTotalPrice(),
+		Address: model.OrderAddress{
+			Street:    addr.GetStreet(),
+			House:     addr.GetHouse(),
+			Apartment: addr.GetApartment(),
+			Floor:     addr.GetFloor(),
+			Comment:   addr.GetComment(),
+		},
+		Status: o.GetStatus(),
+	}
+
+	if o.GetCreatedAt() != nil {
+		order.CreatedAt = o.GetCreatedAt().AsTime()
+	}
+	if o.GetUpdatedAt() != nil {
+		order.UpdatedAt = o.GetUpdatedAt().AsTime()
+	}
+	if o.GetEstimatedDelivery() != nil {
+		order.EstimatedDelivery = o.GetEstimatedDelivery().AsTime()
+	}
+
+	return order
 }
@@ -0,0 +1,104 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetOrder_MapsFullOrder(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"order_id": "o1",
+			"user_id": "u1",
+			"order_number": "N-1",
+			"fio": "Ivan Ivanov",
+			"restaurant_id": "r1",
+			"items": [{"name": "pizza", "price": 500, "quantity": 2}],
+			"total_price": 1000,
+			"address": {"street": "Lenina", "house": "1", "apartment": "12", "floor": "3", "comment": "code 12"},
+			"status": "created",
+			"created_at": "2026-03-01T10:00:00Z",
+			"updated_at": "2026-03-01T10:05:00Z",
+			"estimated_delivery": "2026-03-01T11:00:00Z"
+		}`))
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	got, err := gw.GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+
+	require.Equal(t, &model.Order{
+		Id:                "o1",
+		UserId:            "u1",
+		Number:            "N-1",
+		CustomerName:      "Ivan Ivanov",
+		RestaurantId:      "r1",
+		Items:             []model.OrderItem{{Name: "pizza", Price: 500, Quantity: 2}},
+		TotalPrice:        1000,
+		Address:           model.OrderAddress{Street: "Lenina", House: "1", Apartment: "12", Floor: "3", Comment: "code 12"},
+		Status:            "created",
+		CreatedAt:         time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2026, 3, 1, 10, 5, 0, 0, time.UTC),
+		EstimatedDelivery: time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC),
+	}, got)
+}
+
+func TestOrderFromProto(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	eta := createdAt.Add(time.Hour)
+
+	got := orderFromProto(&pb.Order{
+		Id:                "o1",
+		RestaurantId:      "r1",
+		Items:             []*pb.Item{{Name: "pizza", Price: 500, Quantity: 2}, {Name: "cola", Price: 100, Quantity: 1}},
+		TotalPrice:        1100,
+		Address:           &pb.DeliveryAddress{Street: "Lenina", House: "1"},
+		Status:            "created",
+		CreatedAt:         timestamppb.New(createdAt),
+		EstimatedDelivery: timestamppb.New(eta),
+	})
+
+	require.Equal(t, "o1", got.Id)
+	require.Equal(t, "r1", got.RestaurantId)
+	require.Len(t, got.Items, 2)
+	require.Equal(t, model.OrderAddress{Street: "Lenina", House: "1"}, got.Address)
+	require.Equal(t, createdAt, got.CreatedAt)
+	require.True(t, got.UpdatedAt.IsZero())
+	require.Equal(t, eta, got.EstimatedDelivery)
+	require.Equal(t, model.OrderSnapshot{
+		RestaurantId: "r1",
+		Address:      model.OrderAddress{Street: "Lenina", House: "1"},
+		TotalPrice:   1100,
+		ItemsCount:   3,
+	}, got.Snapshot())
+}
+
+func TestListSince_UnsupportedOverHttp(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	page, err := gw.ListSince(context.Background(), time.Now(), 10, "")
+	require.ErrorIs(t, err, ErrListUnsupported)
+	require.Nil(t, page)
+}
@@ -0,0 +1,21 @@
+package order
+
+import "errors"
+
+var (
+	ErrOrderNotFound = errors.New("order not found")
+
+	ErrInvalidPageToken = errors.New("invalid page token")
+
+	ErrListUnsupported = errors.New("listing orders is not supported")
+
+	// ErrUnavailable is returned once every retry failed. It is transient:
+	// the order service may answer again later.
+	ErrUnavailable error = transientError("order service unavailable")
+)
+
+type transientError string
+
+func (e transientError) Error() string { return string(e) }
+
+func (transientError) Transient() bool { return true }
@@ -0,0 +1,55 @@
+package order
+
+import (
+	"course-go-avito-SitnikovArtem06/pkg/grpcclient"
+	"course-go-avito-SitnikovArtem06/pkg/tlsconfig"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// NewGatewayFromEnv builds the gateway for transport from ORDER_* variables.
+// The returned close func releases the underlying connection. There is no
+// memory transport: a MemoryGateway built here would hold no orders.
+func NewGatewayFromEnv(transport string) (Gateway, func() error, error) {
+	switch transport {
+	case TransportHTTP:
+		baseURL := os.Getenv("ORDER_HTTP_BASEURL")
+		if baseURL == "" {
+			return nil, nil, fmt.Errorf("ORDER_HTTP_BASEURL is required for %s transport", transport)
+		}
+		client, err := httpClientFromEnv()
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewHttpGateway(baseURL, client), func() error { return nil }, nil
+	case TransportGRPC:
+		conn, cfg, err := grpcclient.InitOrderClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewGrpcGateway(conn, cfg.CallTimeout, cfg.RetryAttempts), conn.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown transport %q, want %s or %s", transport, TransportHTTP, TransportGRPC)
+	}
+}
+
+func httpClientFromEnv() (*http.Client, error) {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsCfg, err := tlsconfig.Load("ORDER_HTTP")
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		base.TLSClientConfig = tlsCfg
+	}
+
+	transport, err := httpAuthFromEnv(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{Timeout: TimeOut * time.Second, Transport: transport}, nil
+}
@@ -0,0 +1,87 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"sync"
+	"time"
+)
+
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// BatchConcurrency bounds the lookups a single BatchGet runs at once.
+const BatchConcurrency = 8
+
+// BatchResult is the outcome of one id in a BatchGet: either Order or Err
+// is set.
+type BatchResult struct {
+	OrderId string
+	Order   *model.Order
+	Err     error
+}
+
+// Gateway is the order service port shared by every transport.
+type Gateway interface {
+	GetOrder(ctx context.Context, orderId string) (*model.Order, error)
+	// ListSince returns a page of orders created at or after from, ordered by
+	// (created_at, id). Pass the previous NextPageToken to get the next page.
+	ListSince(ctx context.Context, from time.Time, limit int, pageToken string) (*model.OrdersPage, error)
+	// BatchGet looks every id up and returns one result per id, in the order
+	// of orderIds. A failed lookup only fails its own result.
+	BatchGet(ctx context.Context, orderIds []string) []BatchResult
+}
+
+// batchGet runs get for every distinct id with at most concurrency calls in
+// flight. Repeated ids share the result of the first lookup.
+func batchGet(ctx context.Context, orderIds []string, concurrency int, get func(ctx context.Context, orderId string) (*model.Order, error)) []BatchResult {
+	results := make([]BatchResult, len(orderIds))
+	first := make(map[string]int, len(orderIds))
+
+	sem := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+
+	for i, id := range orderIds {
+		results[i].OrderId = id
+		if _, ok := first[id]; ok {
+			continue
+		}
+		first[id] = i
+
+		// Once ctx is done the ids that have not started yet fail with its
+		// error instead of waiting for a slot.
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(r *BatchResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r.Order, r.Err = get(ctx, r.OrderId)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	for i := range results {
+		if j := first[results[i].OrderId]; j != i {
+			results[i].Order, results[i].Err = results[j].Order, results[j].Err
+		}
+	}
+
+	return results
+}
+
+var (
+	_ Gateway = (*HttpGateway)(nil)
+	_ Gateway = (*GrpcGateway)(nil)
+	_ Gateway = (*MemoryGateway)(nil)
+)
@@ -0,0 +1,41 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchGet_StopsWaitingForSlotsWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int32
+	get := func(ctx context.Context, orderId string) (*model.Order, error) {
+		atomic.AddInt32(&calls, 1)
+		cancel()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	results := batchGet(ctx, []string{"o1", "o2", "o3", "o2"}, 1, get)
+
+	require.Len(t, results, 4)
+	for _, r := range results {
+		require.ErrorIs(t, r.Err, context.Canceled, r.OrderId)
+		require.Nil(t, r.Order)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestNewGatewayFromEnv_RejectsMemory(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := NewGatewayFromEnv("memory")
+	require.ErrorContains(t, err, `unknown transport "memory"`)
+}
@@ -3,53 +3,126 @@
 import (
 	"context"
 	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/observability"
 	"course-go-avito-SitnikovArtem06/internal/pb"
+	"fmt"
+	"time"
+
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"os"
-	"time"
 )
 
 type GrpcGateway struct {
-	client pb.OrdersServiceClient
+	client        pb.OrdersServiceClient
+	callTimeout   time.Duration
+	retryAttempts int
+}
+
+// NewGrpcGateway wraps conn. Every attempt gets its own callTimeout deadline
+// and transient failures are retried up to retryAttempts times in total.
+func NewGrpcGateway(conn grpc.ClientConnInterface, callTimeout time.Duration, retryAttempts int) *GrpcGateway {
+	return &GrpcGateway{
+		client:        pb.NewOrdersServiceClient(conn),
+		callTimeout:   callTimeout,
+		retryAttempts: max(retryAttempts, 1),
+	}
 }
 
-func (g *GrpcGateway) GetNewOrders(ctx context.Context, from time.Time) (*model.OrdersResponse, error) {
+func (g *GrpcGateway) GetOrder(ctx context.Context, orderId string) (*model.Order, error) {
+
+	req := pb.GetOrderByIdRequest{Id: orderId}
 
-	req := pb.GetOrdersRequest{From: timestamppb.New(from)}
+	var resp *pb.GetOrderByIdResponse
 
-	resp, err := g.client.GetOrders(ctx, &req)
+	err := g.call(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = g.client.GetOrderById(ctx, &req)
+		return err
+	})
 	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("order gateway: %w", ErrOrderNotFound)
+		}
 		return nil, err
 	}
+	if resp.GetOrder() == nil {
+		return nil, fmt.Errorf("order gateway: %w", ErrOrderNotFound)
+	}
 
-	ordersId := make([]string, 0, len(resp.Orders))
-	createdAt := make([]time.Time, 0, len(resp.Orders))
+	order := orderFromProto(resp.GetOrder())
+	return &order, nil
+}
 
-	for _, order := range resp.Orders {
-		ordersId = append(ordersId, order.Id)
-		createdAt = append(createdAt, order.CreatedAt.AsTime())
+func (g *GrpcGateway) BatchGet(ctx context.Context, orderIds []string) []BatchResult {
+	return batchGet(ctx, orderIds, BatchConcurrency, g.GetOrder)
+}
 
-	}
+func (g *GrpcGateway) ListSince(ctx context.Context, from time.Time, limit int, pageToken string) (*model.OrdersPage, error) {
 
-	return &model.OrdersResponse{OrdersId: ordersId, CreatedAt: createdAt}, nil
-}
+	req := pb.GetOrdersRequest{From: timestamppb.New(from), Limit: int32(limit), PageToken: pageToken}
 
-func NewGrpcGateway() (*GrpcGateway, error) {
-	addr := os.Getenv("ORDER_GRPC_HOST")
+	var resp *pb.GetOrdersResponse
 
-	conn, err := grpc.NewClient(
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	err := g.call(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = g.client.GetOrders(ctx, &req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	client := pb.NewOrdersServiceClient(conn)
+	orders := make([]model.Order, 0, len(resp.Orders))
 
-	return &GrpcGateway{
-		client: client,
-	}, nil
+	for _, order := range resp.Orders {
+		orders = append(orders, orderFromProto(order))
+	}
+
+	return &model.OrdersPage{Orders: orders, NextPageToken: resp.GetNextPageToken()}, nil
+}
+
+func (g *GrpcGateway) call(ctx context.Context, fn func(ctx context.Context) error) error {
+
+	var lastErr error
+
+	for i := 1; i <= g.retryAttempts; i++ {
+		if i != 1 {
+			observability.GatewayRetriesTotal.WithLabelValues().Inc()
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, g.callTimeout)
+		err := fn(callCtx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retryableCode(status.Code(err)) {
+			return fmt.Errorf("order gateway: %w", err)
+		}
+		lastErr = err
+
+		if i < g.retryAttempts {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(retryDelay(i, 0)):
+			}
+		}
+	}
+
+	return fmt.Errorf("order gateway: %w after %d attempts: %w", ErrUnavailable, g.retryAttempts, lastErr)
+}
+
+func retryableCode(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,143 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeOrdersServer struct {
+	pb.UnimplementedOrdersServiceServer
+	calls   atomic.Int32
+	handler func(ctx context.Context, call int32) (*pb.GetOrdersResponse, error)
+	orders  map[string]*pb.Order
+}
+
+func (s *fakeOrdersServer) GetOrderById(ctx context.Context, req *pb.GetOrderByIdRequest) (*pb.GetOrderByIdResponse, error) {
+	s.calls.Add(1)
+	order, ok := s.orders[req.GetId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "no order")
+	}
+	return &pb.GetOrderByIdResponse{Order: order}, nil
+}
+
+func (s *fakeOrdersServer) GetOrders(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersResponse, error) {
+	return s.handler(ctx, s.calls.Add(1))
+}
+
+func newBufGateway(t *testing.T, srv *fakeOrdersServer, callTimeout time.Duration, attempts int) *GrpcGateway {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterOrdersServiceServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewGrpcGateway(conn, callTimeout, attempts)
+}
+
+func TestListSince_RetryOnUnavailable_ThenSuccess(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	srv := &fakeOrdersServer{handler: func(ctx context.Context, call int32) (*pb.GetOrdersResponse, error) {
+		if call == 1 {
+			return nil, status.Error(codes.Unavailable, "down")
+		}
+		return &pb.GetOrdersResponse{Orders: []*pb.Order{{Id: "o1", CreatedAt: timestamppb.New(createdAt)}}, NextPageToken: "next"}, nil
+	}}
+
+	gw := newBufGateway(t, srv, time.Second, 3)
+
+	page, err := gw.ListSince(context.Background(), createdAt.Add(-time.Minute), 1, "")
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 1)
+	require.Equal(t, "o1", page.Orders[0].Id)
+	require.Equal(t, "next", page.NextPageToken)
+	require.Equal(t, int32(2), srv.calls.Load())
+}
+
+func TestListSince_DeadlinePerAttempt(t *testing.T) {
+	t.Parallel()
+
+	srv := &fakeOrdersServer{handler: func(ctx context.Context, call int32) (*pb.GetOrdersResponse, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+
+	gw := newBufGateway(t, srv, 50*time.Millisecond, 2)
+
+	_, err := gw.ListSince(context.Background(), time.Now(), 0, "")
+	require.Error(t, err)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	require.Equal(t, int32(2), srv.calls.Load())
+}
+
+func TestListSince_NoRetryOnInvalidArgument(t *testing.T) {
+	t.Parallel()
+
+	srv := &fakeOrdersServer{handler: func(ctx context.Context, call int32) (*pb.GetOrdersResponse, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad from")
+	}}
+
+	gw := newBufGateway(t, srv, time.Second, 3)
+
+	_, err := gw.ListSince(context.Background(), time.Now(), 0, "")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, int32(1), srv.calls.Load())
+}
+
+func TestGrpcGetOrder_UsesGetOrderById(t *testing.T) {
+	t.Parallel()
+
+	srv := &fakeOrdersServer{orders: map[string]*pb.Order{"o1": {Id: "o1", Status: "created", RestaurantId: "r1"}}}
+
+	gw := newBufGateway(t, srv, time.Second, 3)
+
+	order, err := gw.GetOrder(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, "r1", order.RestaurantId)
+
+	_, err = gw.GetOrder(context.Background(), "missing")
+	require.ErrorIs(t, err, ErrOrderNotFound)
+	require.Equal(t, int32(2), srv.calls.Load())
+}
+
+func TestGrpcBatchGet_ParallelGetOrderById(t *testing.T) {
+	t.Parallel()
+
+	srv := &fakeOrdersServer{orders: map[string]*pb.Order{
+		"o1": {Id: "o1"},
+		"o2": {Id: "o2"},
+	}}
+
+	gw := newBufGateway(t, srv, time.Second, 1)
+
+	results := gw.BatchGet(context.Background(), []string{"o1", "missing", "o2", "o1"})
+	require.Len(t, results, 4)
+	require.Equal(t, "o1", results[0].Order.Id)
+	require.ErrorIs(t, results[1].Err, ErrOrderNotFound)
+	require.Equal(t, "o2", results[2].Order.Id)
+	require.Equal(t, "o1", results[3].Order.Id)
+	require.Equal(t, int32(3), srv.calls.Load())
+}
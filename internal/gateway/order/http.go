@@ -2,10 +2,15 @@
 
 import (
 	"context"
+	"course-go-avito-SitnikovArtem06/internal/gateway/breaker"
+	"course-go-avito-SitnikovArtem06/internal/model"
 	"course-go-avito-SitnikovArtem06/internal/observability"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -13,23 +18,72 @@ const (
 	TimeOut       = 5
 	RetryAttempts = 5
 	RetryDelay    = 100 * time.Millisecond
+	MaxRetryDelay = 2 * time.Second
+	MaxRetryAfter = 30 * time.Second
+
+	BreakerThreshold = 5
+	BreakerCooldown  = 10 * time.Second
+	BreakerProbes    = 1
 )
 
 type HttpGateway struct {
 	baseURL string
 	client  *http.Client
+	breaker *breaker.Breaker
 }
 
 func NewHttpGateway(url string, client *http.Client) *HttpGateway {
 	return &HttpGateway{
 		baseURL: url,
 		client:  client,
+		breaker: breaker.New("order_http", BreakerThreshold, BreakerCooldown, BreakerProbes),
+	}
+}
+
+func (g *HttpGateway) GetOrder(ctx context.Context, orderId string) (*model.Order, error) {
+
+	var order OrderDto
+	if err := g.fetch(ctx, "/public/api/v1/order/"+url.PathEscape(orderId), &order); err != nil {
+		return nil, err
+	}
+
+	return order.ToModel(), nil
+}
+
+// ListSince is not available over HTTP: the order service's public HTTP API
+// only serves single orders, so polling has to go through the gRPC gateway.
+func (g *HttpGateway) ListSince(ctx context.Context, from time.Time, limit int, pageToken string) (*model.OrdersPage, error) {
+	return nil, fmt.Errorf("%s transport: %w", TransportHTTP, ErrListUnsupported)
+}
+
+func (g *HttpGateway) BatchGet(ctx context.Context, orderIds []string) []BatchResult {
+	return batchGet(ctx, orderIds, BatchConcurrency, g.GetOrder)
+}
+
+func (g *HttpGateway) fetch(ctx context.Context, path string, out any) error {
+
+	if err := g.breaker.Allow(); err != nil {
+		return fmt.Errorf("order gateway: %w", err)
 	}
+
+	degraded, err := g.get(ctx, path, out)
+	switch {
+	case degraded:
+		g.breaker.Failure()
+	case ctx.Err() == nil:
+		g.breaker.Success()
+	default:
+		g.breaker.Cancel()
+	}
+
+	return err
 }
 
-func (g *HttpGateway) GetOrder(ctx context.Context, orderId string) (*OrderDto, error) {
+// get reports degraded when the order service itself looks unhealthy,
+// as opposed to answering with a definitive client error.
+func (g *HttpGateway) get(ctx context.Context, path string, out any) (bool, error) {
 
-	url := g.baseURL + "/public/api/v1/order/" + orderId
+	endpoint := g.baseURL + path
 
 	var lastErr error
 
@@ -37,47 +91,92 @@ func (g *HttpGateway) GetOrder(ctx context.Context, orderId string) (*OrderDto,
 		if i != 1 {
 			observability.GatewayRetriesTotal.WithLabelValues().Inc()
 		}
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
 		req.Header.Set("Accept", "application/json")
 
+		var retryAfter time.Duration
+
 		resp, err := g.client.Do(req)
 		if err != nil {
-			return nil, err
-		}
-
-		switch resp.StatusCode {
-		case http.StatusOK:
-			var order OrderDto
-			err = json.NewDecoder(resp.Body).Decode(&order)
-			_ = resp.Body.Close()
-			if err != nil {
-				return nil, err
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			lastErr = fmt.Errorf("order gateway: %w", err)
+		} else {
+			switch resp.StatusCode {
+			case http.StatusOK:
+				err = json.NewDecoder(resp.Body).Decode(out)
+				_ = resp.Body.Close()
+				if err != nil {
+					return false, err
+				}
+				return false, nil
+			case http.StatusTooManyRequests,
+				http.StatusBadGateway,
+				http.StatusServiceUnavailable,
+				http.StatusGatewayTimeout:
+				retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+				_ = resp.Body.Close()
+				lastErr = fmt.Errorf("order gateway: status=%d", resp.StatusCode)
+			case http.StatusNotFound:
+				_ = resp.Body.Close()
+				return false, fmt.Errorf("order gateway: %w", ErrOrderNotFound)
+			default:
+				_ = resp.Body.Close()
+				return resp.StatusCode >= http.StatusInternalServerError, fmt.Errorf("order gateway: status=%d", resp.StatusCode)
 			}
-			return &order, nil
-		case http.StatusTooManyRequests,
-			http.StatusBadGateway,
-			http.StatusServiceUnavailable,
-			http.StatusGatewayTimeout:
-			_ = resp.Body.Close()
-			lastErr = fmt.Errorf("order gateway: status=%d", resp.StatusCode)
-		default:
-			_ = resp.Body.Close()
-			return nil, fmt.Errorf("order gateway: status=%d", resp.StatusCode)
 		}
+
 		if i < RetryAttempts {
 			select {
 			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(RetryDelay):
+				return false, ctx.Err()
+			case <-time.After(retryDelay(i, retryAfter)):
 			}
 		}
 
 	}
 
-	return nil, fmt.Errorf("order gateway: failed after %d attempts: %w", RetryAttempts, lastErr)
+	return true, fmt.Errorf("order gateway: %w after %d attempts: %w", ErrUnavailable, RetryAttempts, lastErr)
 
 }
+
+// retryDelay doubles RetryDelay with every attempt, adds up to 50% jitter and
+// never waits less than the server asked for in Retry-After.
+func retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	delay := RetryDelay << (attempt - 1)
+	if delay > MaxRetryDelay || delay <= 0 {
+		delay = MaxRetryDelay
+	}
+	delay += time.Duration(rand.Int64N(int64(delay)/2 + 1))
+
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if delay > MaxRetryAfter {
+		delay = MaxRetryAfter
+	}
+	return delay
+}
+
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -2,8 +2,11 @@ package order
 
 import (
 	"context"
+	"course-go-avito-SitnikovArtem06/internal/gateway/breaker"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -66,8 +69,8 @@ func TestGetOrder_RetryOn429_Exhausted(t *testing.T) {
 	_, err := gw.GetOrder(context.Background(), "123")
 	end := time.Since(start)
 
-	if err == nil {
-		t.Fatalf("expected error, got nil")
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected unavailable, got %v", err)
 	}
 
 	if got := atomic.LoadInt32(&calls); got != int32(RetryAttempts) {
@@ -79,3 +82,240 @@ func TestGetOrder_RetryOn429_Exhausted(t *testing.T) {
 		t.Fatalf("expected elapsed >= %v, got %v", minExpected, end)
 	}
 }
+
+func TestGetOrder_RetryOnNetworkError_ThenSuccess(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatalf("hijacking not supported")
+			}
+			conn, _, _ := hj.Hijack()
+			_ = conn.Close()
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"order_id":"123","status":"created"}`))
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	order, err := gw.GetOrder(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("expected success, got err: %v", err)
+	}
+	if order.Status != "created" {
+		t.Fatalf("unexpected status %q", order.Status)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestGetOrder_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	start := time.Now()
+	_, err := gw.GetOrder(context.Background(), "123")
+	end := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("expected success, got err: %v", err)
+	}
+	if end < time.Second {
+		t.Fatalf("expected to wait Retry-After, elapsed %v", end)
+	}
+}
+
+func TestGetOrder_NotFound_NoRetry(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	for i := 0; i < BreakerThreshold+1; i++ {
+		if _, err := gw.GetOrder(context.Background(), "123"); err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != BreakerThreshold+1 {
+		t.Fatalf("expected %d calls, got %d", BreakerThreshold+1, got)
+	}
+	if gw.breaker.State() != breaker.StateClosed {
+		t.Fatalf("client errors must not open the breaker")
+	}
+}
+
+func TestGetOrder_BreakerOpensOnServerErrors(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	for i := 0; i < BreakerThreshold; i++ {
+		_, _ = gw.GetOrder(context.Background(), "123")
+	}
+
+	_, err := gw.GetOrder(context.Background(), "123")
+	if !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("expected open breaker, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != BreakerThreshold {
+		t.Fatalf("expected %d calls, got %d", BreakerThreshold, got)
+	}
+}
+
+func TestGetOrder_BreakerRecoversFromCancelledProbe(t *testing.T) {
+	var calls int32
+	probing := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			close(probing)
+			<-r.Context().Done()
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+	gw.breaker = breaker.New("test", 1, 10*time.Millisecond, 1)
+
+	// Open the breaker without waiting out the server error retries.
+	gw.breaker.Failure()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-probing
+		cancel()
+	}()
+	if _, err := gw.GetOrder(ctx, "123"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled probe, got %v", err)
+	}
+
+	if _, err := gw.GetOrder(context.Background(), "123"); err != nil {
+		t.Fatalf("expected the next probe to go through, got %v", err)
+	}
+	if got := gw.breaker.State(); got != breaker.StateClosed {
+		t.Fatalf("expected closed breaker, got %v", got)
+	}
+}
+
+func TestRetryDelay_Backoff(t *testing.T) {
+	for attempt := 1; attempt <= 6; attempt++ {
+		base := RetryDelay << (attempt - 1)
+		if base > MaxRetryDelay {
+			base = MaxRetryDelay
+		}
+		got := retryDelay(attempt, 0)
+		if got < base || got > base+base/2 {
+			t.Fatalf("attempt %d: delay %v out of [%v, %v]", attempt, got, base, base+base/2)
+		}
+	}
+
+	if got := retryDelay(1, 3*time.Second); got != 3*time.Second {
+		t.Fatalf("expected Retry-After to win, got %v", got)
+	}
+	if got := retryDelay(1, time.Hour); got != MaxRetryAfter {
+		t.Fatalf("expected Retry-After to be capped, got %v", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+
+	if got := parseRetryAfter("7", now); got != 7*time.Second {
+		t.Fatalf("seconds: got %v", got)
+	}
+	date := now.Add(90 * time.Second).Format(http.TimeFormat)
+	if got := parseRetryAfter(date, now); got != 90*time.Second {
+		t.Fatalf("http date: got %v", got)
+	}
+	if got := parseRetryAfter("soon", now); got != 0 {
+		t.Fatalf("garbage: got %v", got)
+	}
+}
+
+func TestBatchGet_BoundedConcurrencyAndPerOrderErrors(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/public/api/v1/order/")
+		switch id {
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "bad":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			_, _ = w.Write([]byte(`{"order_id":"` + id + `","status":"created"}`))
+		}
+	}))
+	defer srv.Close()
+
+	gw := NewHttpGateway(srv.URL, &http.Client{Timeout: 2 * time.Second})
+
+	ids := []string{"missing", "bad"}
+	for i := 0; i < 3*BatchConcurrency; i++ {
+		ids = append(ids, "o"+strconv.Itoa(i))
+	}
+
+	results := gw.BatchGet(context.Background(), ids)
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(results))
+	}
+	if !errors.Is(results[0].Err, ErrOrderNotFound) {
+		t.Fatalf("expected not found, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || results[1].Order != nil {
+		t.Fatalf("expected error for bad order, got %+v", results[1])
+	}
+	for i, r := range results[2:] {
+		if r.Err != nil || r.Order.Id != ids[i+2] {
+			t.Fatalf("result %d: %+v", i+2, r)
+		}
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > BatchConcurrency || got < 2 {
+		t.Fatalf("expected parallel calls bounded by %d, got %d", BatchConcurrency, got)
+	}
+}
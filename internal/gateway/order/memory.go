@@ -0,0 +1,60 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryGateway serves orders from memory. It is meant for tests and the
+// fake-orders player, which fill it themselves; services reach the order
+// service over HTTP or gRPC.
+type MemoryGateway struct {
+	mu     sync.RWMutex
+	orders map[string]model.Order
+}
+
+func NewMemoryGateway(orders ...model.Order) *MemoryGateway {
+	g := &MemoryGateway{orders: make(map[string]model.Order, len(orders))}
+	for _, order := range orders {
+		g.Put(order)
+	}
+	return g
+}
+
+// Put adds or replaces an order.
+func (g *MemoryGateway) Put(order model.Order) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.orders[order.Id] = order
+}
+
+func (g *MemoryGateway) GetOrder(ctx context.Context, orderId string) (*model.Order, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	order, ok := g.orders[orderId]
+	if !ok {
+		return nil, fmt.Errorf("order gateway: %w", ErrOrderNotFound)
+	}
+	return &order, nil
+}
+
+func (g *MemoryGateway) ListSince(ctx context.Context, from time.Time, limit int, pageToken string) (*model.OrdersPage, error) {
+	g.mu.RLock()
+	orders := make([]model.Order, 0, len(g.orders))
+	for _, order := range g.orders {
+		orders = append(orders, order)
+	}
+	g.mu.RUnlock()
+
+	SortOrders(orders)
+
+	return Paginate(orders, from, limit, pageToken)
+}
+
+func (g *MemoryGateway) BatchGet(ctx context.Context, orderIds []string) []BatchResult {
+	return batchGet(ctx, orderIds, 1, g.GetOrder)
+}
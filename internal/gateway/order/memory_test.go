@@ -0,0 +1,90 @@
+package order
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryGateway_ListSince_OrderedAndInclusive(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	gw := NewMemoryGateway(
+		model.Order{Id: "o3", CreatedAt: t0.Add(time.Minute)},
+		model.Order{Id: "o2", CreatedAt: t0},
+		model.Order{Id: "o1", CreatedAt: t0},
+		model.Order{Id: "old", CreatedAt: t0.Add(-time.Minute)},
+	)
+
+	page, err := gw.ListSince(context.Background(), t0, 0, "")
+	require.NoError(t, err)
+	require.Empty(t, page.NextPageToken)
+	require.Equal(t, []string{"o1", "o2", "o3"}, orderIds(page.Orders))
+}
+
+func TestMemoryGateway_ListSince_Pages(t *testing.T) {
+	t.Parallel()
+
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	gw := NewMemoryGateway(
+		model.Order{Id: "o1", CreatedAt: t0},
+		model.Order{Id: "o2", CreatedAt: t0},
+		model.Order{Id: "o3", CreatedAt: t0},
+		model.Order{Id: "o4", CreatedAt: t0.Add(time.Minute)},
+	)
+
+	first, err := gw.ListSince(context.Background(), t0, 2, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"o1", "o2"}, orderIds(first.Orders))
+	require.NotEmpty(t, first.NextPageToken)
+
+	second, err := gw.ListSince(context.Background(), t0, 2, first.NextPageToken)
+	require.NoError(t, err)
+	require.Equal(t, []string{"o3", "o4"}, orderIds(second.Orders))
+	require.Empty(t, second.NextPageToken)
+}
+
+func TestMemoryGateway_ListSince_InvalidToken(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMemoryGateway().ListSince(context.Background(), time.Time{}, 1, "%%%")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func orderIds(orders []model.Order) []string {
+	ids := make([]string, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.Id)
+	}
+	return ids
+}
+
+func TestMemoryGateway_GetOrder_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMemoryGateway().GetOrder(context.Background(), "missing")
+	require.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+func TestMemoryGateway_BatchGet_PerOrderResults(t *testing.T) {
+	t.Parallel()
+
+	gw := NewMemoryGateway(model.Order{Id: "o1"}, model.Order{Id: "o2"})
+
+	results := gw.BatchGet(context.Background(), []string{"o2", "missing", "o1", "o2"})
+	require.Len(t, results, 4)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "o2", results[0].Order.Id)
+
+	require.Equal(t, "missing", results[1].OrderId)
+	require.Nil(t, results[1].Order)
+	require.ErrorIs(t, results[1].Err, ErrOrderNotFound)
+
+	require.Equal(t, "o1", results[2].Order.Id)
+	require.Equal(t, "o2", results[3].Order.Id)
+}
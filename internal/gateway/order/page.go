@@ -0,0 +1,75 @@
+package order
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodePageToken turns the last order of a page into an opaque token.
+func EncodePageToken(last *model.Order) string {
+	raw := strconv.FormatInt(last.CreatedAt.UnixNano(), 10) + ":" + last.Id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodePageToken(token string) (model.OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return model.OrderCursor{}, ErrInvalidPageToken
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return model.OrderCursor{}, ErrInvalidPageToken
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return model.OrderCursor{}, ErrInvalidPageToken
+	}
+
+	return model.OrderCursor{CreatedAt: time.Unix(0, n).UTC(), OrderId: id}, nil
+}
+
+// SortOrders sorts orders by (created_at, id).
+func SortOrders(orders []model.Order) {
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+		}
+		return orders[i].Id < orders[j].Id
+	})
+}
+
+// Paginate cuts one page out of orders created at or after from. orders must
+// be sorted with SortOrders. A zero limit returns everything that is left.
+func Paginate(orders []model.Order, from time.Time, limit int, pageToken string) (*model.OrdersPage, error) {
+	var after *model.OrderCursor
+	if pageToken != "" {
+		cursor, err := DecodePageToken(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		after = &cursor
+	}
+
+	page := &model.OrdersPage{Orders: make([]model.Order, 0)}
+	for i := range orders {
+		o := &orders[i]
+		if o.CreatedAt.Before(from) {
+			continue
+		}
+		if after != nil && !after.Precedes(o) {
+			continue
+		}
+		if limit > 0 && len(page.Orders) == limit {
+			page.NextPageToken = EncodePageToken(&page.Orders[limit-1])
+			break
+		}
+		page.Orders = append(page.Orders, *o)
+	}
+
+	return page, nil
+}
@@ -1,6 +1,7 @@
 package assign_handler
 
 import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
 	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"encoding/json"
 	"errors"
@@ -9,99 +10,69 @@ import (
 
 type AssignHandler struct {
 	as assignService
+	m  Mapper
 }
 
-func NewAssignHandler(service assignService) *AssignHandler {
-	return &AssignHandler{as: service}
+func NewAssignHandler(service assignService, m Mapper) *AssignHandler {
+	return &AssignHandler{as: service, m: m}
 }
 
 func (h *AssignHandler) AssignCourier(w http.ResponseWriter, r *http.Request) {
 
-	var orderReq order
-
-	json.NewDecoder(r.Body).Decode(&orderReq)
-
-	if orderReq.OrderId == "" {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "invalid order_id",
-		})
+	orderId, err := h.m.OrderRequest(w, r)
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	assign, err := h.as.AssignCourier(r.Context(), orderReq.OrderId)
+	assign, err := h.as.AssignCourier(r.Context(), orderId)
 	if err != nil {
-		switch {
-		case errors.Is(err, assign_service.ErrNotAvailableCourier):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-		case errors.Is(err, assign_service.ErrOrderAlreadyAssign):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
+		problem.Write(w, r, err)
 		return
-
-	}
-
-	resp := assignCourierResp{
-		CourierId: assign.CourierId,
-		OrderId:   assign.OrderId,
-		Transport: assign.Transport.String(),
-		Deadline:  assign.Deadline,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	json.NewEncoder(w).Encode(h.m.Assigned(assign))
 
 }
 
 func (h *AssignHandler) UnassignCourier(w http.ResponseWriter, r *http.Request) {
 
-	var orderReq order
-
-	json.NewDecoder(r.Body).Decode(&orderReq)
+	orderId, err := h.m.OrderRequest(w, r)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
 
-	if orderReq.OrderId == "" {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "invalid order_id",
-		})
+	unassign, err := h.as.UnassignCourier(r.Context(), orderId)
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	unassign, err := h.as.UnassignCourier(r.Context(), orderReq.OrderId)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.m.Unassigned(unassign))
+
+}
+
+// AssignCouriersBatch assigns a list of orders in one pass. The response
+// reports every order; whether the successful ones are kept when others
+// fail depends on the batch policy.
+func (h *AssignHandler) AssignCouriersBatch(w http.ResponseWriter, r *http.Request) {
+
+	orderIds, policy, err := h.m.BatchRequest(w, r)
 	if err != nil {
-		switch {
-		case errors.Is(err, assign_service.ErrNotAssignedCourier):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		problem.Write(w, r, err)
 		return
 	}
 
-	resp := unassignCourierResp{
-		OrderId:   unassign.OrderId,
-		Status:    unassign.Status.String(),
-		CourierId: unassign.CourierId,
+	results, err := h.as.AssignCouriers(r.Context(), orderIds, policy)
+	if err != nil && !errors.Is(err, assign_service.ErrBatchAborted) {
+		problem.Write(w, r, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	json.NewEncoder(w).Encode(h.m.Batch(policy, results))
 
 }
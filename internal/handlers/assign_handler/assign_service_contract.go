@@ -8,5 +8,7 @@ import (
 type assignService interface {
 	AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error)
 
+	AssignCouriers(ctx context.Context, orderIds []string, policy model.BatchPolicy) ([]model.AssignResult, error)
+
 	UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error)
 }
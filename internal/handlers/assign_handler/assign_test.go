@@ -1,6 +1,7 @@
 ﻿package assign_handler
 
 import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
 	"bytes"
 	assign_handler "course-go-avito-SitnikovArtem06/internal/handlers/assign_handler/mocks"
 	"course-go-avito-SitnikovArtem06/internal/model"
@@ -23,7 +24,7 @@ func TestAssignCourier_Success(t *testing.T) {
 
 	svc := assign_handler.NewMockassignService(ctrl)
 
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	orderID := "123"
 	deadline := time.Now().UTC()
@@ -44,6 +45,7 @@ func TestAssignCourier_Success(t *testing.T) {
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.AssignCourier(rec, req)
@@ -68,23 +70,24 @@ func TestAssignCourier_InvalidOrderID(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	body, _ := json.Marshal(map[string]string{
 		"order_id": "",
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.AssignCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, "invalid order_id", resp["error"])
+	require.Equal(t, "invalid order_id", resp["detail"])
 }
 
 func TestAssignCourier_NotAvailable(t *testing.T) {
@@ -94,7 +97,7 @@ func TestAssignCourier_NotAvailable(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	orderID := "123"
 
@@ -107,16 +110,17 @@ func TestAssignCourier_NotAvailable(t *testing.T) {
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.AssignCourier(rec, req)
 
 	require.Equal(t, http.StatusConflict, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, assign_service.ErrNotAvailableCourier.Error(), resp["error"])
+	require.Equal(t, assign_service.ErrNotAvailableCourier.Error(), resp["detail"])
 }
 
 func TestAssignCourier_AlreadyAssigned(t *testing.T) {
@@ -126,7 +130,7 @@ func TestAssignCourier_AlreadyAssigned(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	orderID := "123"
 
@@ -139,16 +143,17 @@ func TestAssignCourier_AlreadyAssigned(t *testing.T) {
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.AssignCourier(rec, req)
 
 	require.Equal(t, http.StatusConflict, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, assign_service.ErrOrderAlreadyAssign.Error(), resp["error"])
+	require.Equal(t, assign_service.ErrOrderAlreadyAssign.Error(), resp["detail"])
 }
 
 func TestAssignCourier_InternalError(t *testing.T) {
@@ -158,7 +163,7 @@ func TestAssignCourier_InternalError(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	orderID := "123"
 	internalErr := errors.New("db error")
@@ -172,6 +177,7 @@ func TestAssignCourier_InternalError(t *testing.T) {
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.AssignCourier(rec, req)
@@ -186,7 +192,7 @@ func TestUnassignCourier_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	orderID := "123"
 
@@ -203,6 +209,7 @@ func TestUnassignCourier_Success(t *testing.T) {
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/unassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UnassignCourier(rec, req)
@@ -225,23 +232,24 @@ func TestUnassignCourier_InvalidOrderID(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	body, _ := json.Marshal(map[string]string{
 		"order_id": "",
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/unassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UnassignCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, "invalid order_id", resp["error"])
+	require.Equal(t, "invalid order_id", resp["detail"])
 }
 
 func TestUnassignCourier_NotAssigned(t *testing.T) {
@@ -251,7 +259,7 @@ func TestUnassignCourier_NotAssigned(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	orderID := "123"
 
@@ -264,16 +272,18 @@ func TestUnassignCourier_NotAssigned(t *testing.T) {
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/unassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UnassignCourier(rec, req)
 
 	require.Equal(t, http.StatusNotFound, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, assign_service.ErrNotAssignedCourier.Error(), resp["error"])
+	require.Equal(t, assign_service.ErrNotAssignedCourier.Error(), resp["detail"])
+	require.Equal(t, string(problem.CodeCourierNotAssigned), resp["code"])
 }
 
 func TestUnassignCourier_InternalError(t *testing.T) {
@@ -283,7 +293,7 @@ func TestUnassignCourier_InternalError(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := assign_handler.NewMockassignService(ctrl)
-	h := NewAssignHandler(svc)
+	h := NewAssignHandler(svc, V1)
 
 	orderID := "123"
 	internalErr := errors.New("db error")
@@ -297,9 +307,118 @@ func TestUnassignCourier_InternalError(t *testing.T) {
 	})
 
 	req := httptest.NewRequest(http.MethodPost, "/delivery/unassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UnassignCourier(rec, req)
 
 	require.Equal(t, http.StatusInternalServerError, rec.Code)
 }
+
+func postBatch(h *AssignHandler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/delivery/assign/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.AssignCouriersBatch(rec, req)
+	return rec
+}
+
+func TestAssignCouriersBatch_Partial(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc := assign_handler.NewMockassignService(ctrl)
+	h := NewAssignHandler(svc, V1)
+
+	deadline := time.Date(2026, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	svc.EXPECT().
+		AssignCouriers(gomock.Any(), []string{"o-1", "o-2"}, model.BatchPartial).
+		Return([]model.AssignResult{
+			{OrderId: "o-1", Assign: &model.AssignCourier{CourierId: 1, OrderId: "o-1", Transport: model.Car, Deadline: deadline}},
+			{OrderId: "o-2", Err: assign_service.ErrNotAvailableCourier},
+		}, nil)
+
+	rec := postBatch(h, `{"order_ids":["o-1","o-2"]}`)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{
+		"policy": "partial",
+		"assigned": 1,
+		"failed": 1,
+		"results": [
+			{"order_id": "o-1", "status": "assigned", "courier_id": 1, "transport_type": "car", "delivery_deadline": "2026-10-01T12:30:00Z"},
+			{"order_id": "o-2", "status": "failed", "error": {"code": "no_available_courier", "detail": "no available couriers now"}}
+		]
+	}`, rec.Body.String())
+}
+
+func TestAssignCouriersBatch_AllOrNothingAborted(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc := assign_handler.NewMockassignService(ctrl)
+	h := NewAssignHandler(svc, V1)
+
+	svc.EXPECT().
+		AssignCouriers(gomock.Any(), []string{"o-1", "o-2"}, model.BatchAllOrNothing).
+		Return([]model.AssignResult{
+			{OrderId: "o-1", Err: assign_service.ErrBatchAborted},
+			{OrderId: "o-2", Err: assign_service.ErrNotAvailableCourier},
+		}, assign_service.ErrBatchAborted)
+
+	rec := postBatch(h, `{"order_ids":["o-1","o-2"],"policy":"all_or_nothing"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp batchAssignResp
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, 0, resp.Assigned)
+	require.Equal(t, 2, resp.Failed)
+	require.Equal(t, string(problem.CodeBatchAborted), resp.Results[0].Error.Code)
+	require.Equal(t, string(problem.CodeNoAvailableCourier), resp.Results[1].Error.Code)
+}
+
+func TestAssignCouriersBatch_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	h := NewAssignHandler(assign_handler.NewMockassignService(ctrl), V1)
+
+	tests := []struct {
+		name string
+		body string
+		code problem.Code
+	}{
+		{"empty", `{"order_ids":[]}`, problem.CodeInvalidRequest},
+		{"blank order", `{"order_ids":[""]}`, problem.CodeInvalidOrderId},
+		{"duplicate", `{"order_ids":["o-1","o-1"]}`, problem.CodeDuplicateOrderId},
+		{"policy", `{"order_ids":["o-1"],"policy":"best_effort"}`, problem.CodeInvalidPolicy},
+	}
+
+	for _, tt := range tests {
+		rec := postBatch(h, tt.body)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, tt.name)
+
+		var p problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+		require.Equal(t, tt.code, p.Code, tt.name)
+	}
+}
+
+func TestAssignCouriersBatch_InternalError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc := assign_handler.NewMockassignService(ctrl)
+	h := NewAssignHandler(svc, V1)
+
+	svc.EXPECT().
+		AssignCouriers(gomock.Any(), []string{"o-1"}, model.BatchAllOrNothing).
+		Return(nil, errors.New("db down"))
+
+	rec := postBatch(h, `{"order_ids":["o-1"],"policy":"all_or_nothing"}`)
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
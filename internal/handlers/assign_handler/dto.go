@@ -1,11 +1,66 @@
 package assign_handler
 
-import "time"
+import (
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxOrderIdLen mirrors maxLength of order_id in the OpenAPI spec.
+const MaxOrderIdLen = 64
+
+// MaxBatchSize mirrors maxItems of order_ids in the OpenAPI spec.
+const MaxBatchSize = 500
 
 type order struct {
 	OrderId string `json:"order_id"`
 }
 
+func (o order) validate() error {
+	if o.OrderId == "" {
+		return ErrInvalidOrderId
+	}
+	if utf8.RuneCountInString(o.OrderId) > MaxOrderIdLen {
+		return ErrOrderIdTooLong
+	}
+	return nil
+}
+
+type batchAssignReq struct {
+	OrderIds []string `json:"order_ids"`
+	Policy   string   `json:"policy,omitempty"`
+}
+
+// validate checks the batch and returns its policy, partial by default.
+func (b batchAssignReq) validate() (model.BatchPolicy, error) {
+	switch {
+	case len(b.OrderIds) == 0:
+		return "", ErrEmptyBatch
+	case len(b.OrderIds) > MaxBatchSize:
+		return "", ErrBatchTooLarge
+	}
+
+	seen := make(map[string]struct{}, len(b.OrderIds))
+	for _, orderId := range b.OrderIds {
+		if err := (order{OrderId: orderId}).validate(); err != nil {
+			return "", err
+		}
+		if _, ok := seen[orderId]; ok {
+			return "", ErrDuplicateOrderId
+		}
+		seen[orderId] = struct{}{}
+	}
+
+	policy := model.BatchPolicy(b.Policy)
+	if policy == "" {
+		policy = model.BatchPartial
+	}
+	if !policy.IsValid() {
+		return "", ErrInvalidPolicy
+	}
+	return policy, nil
+}
+
 type assignCourierResp struct {
 	CourierId int64 `json:"courier_id"`
 
@@ -23,3 +78,25 @@ type unassignCourierResp struct {
 
 	CourierId int64 `json:"courier_id"`
 }
+
+type batchAssignResp struct {
+	Policy   string           `json:"policy"`
+	Assigned int              `json:"assigned"`
+	Failed   int              `json:"failed"`
+	Results  []batchResultDTO `json:"results"`
+}
+
+type batchResultDTO struct {
+	OrderId string `json:"order_id"`
+	// Status is assigned or failed.
+	Status    string         `json:"status"`
+	CourierId int64          `json:"courier_id,omitempty"`
+	Transport string         `json:"transport_type,omitempty"`
+	Deadline  *time.Time     `json:"delivery_deadline,omitempty"`
+	Error     *batchErrorDTO `json:"error,omitempty"`
+}
+
+type batchErrorDTO struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
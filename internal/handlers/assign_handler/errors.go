@@ -0,0 +1,17 @@
+package assign_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrInvalidOrderId = problem.New(http.StatusBadRequest, problem.CodeInvalidOrderId, "invalid order_id")
+	ErrOrderIdTooLong = problem.New(http.StatusBadRequest, problem.CodeFieldTooLong, fmt.Sprintf("order_id is longer than %d characters", MaxOrderIdLen))
+
+	ErrEmptyBatch       = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, "order_ids is empty")
+	ErrBatchTooLarge    = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, fmt.Sprintf("order_ids has more than %d items", MaxBatchSize))
+	ErrDuplicateOrderId = problem.New(http.StatusBadRequest, problem.CodeDuplicateOrderId, "order_ids contains an order twice")
+	ErrInvalidPolicy    = problem.New(http.StatusBadRequest, problem.CodeInvalidPolicy, "policy must be partial or all_or_nothing")
+)
@@ -0,0 +1,94 @@
+package assign_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/jsonbody"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"net/http"
+)
+
+// Mapper converts between the assignment models and the wire format of one
+// API version: it decodes and validates request bodies and builds response
+// bodies.
+type Mapper interface {
+	OrderRequest(w http.ResponseWriter, r *http.Request) (string, error)
+	BatchRequest(w http.ResponseWriter, r *http.Request) ([]string, model.BatchPolicy, error)
+	Assigned(a *model.AssignCourier) any
+	Unassigned(u *model.UnassignCourier) any
+	Batch(policy model.BatchPolicy, results []model.AssignResult) any
+}
+
+// V1 is the /api/v1 format.
+var V1 Mapper = v1Mapper{}
+
+type v1Mapper struct{}
+
+func (v1Mapper) OrderRequest(w http.ResponseWriter, r *http.Request) (string, error) {
+	var o order
+	if err := jsonbody.Decode(w, r, &o); err != nil {
+		return "", err
+	}
+	if err := o.validate(); err != nil {
+		return "", err
+	}
+	return o.OrderId, nil
+}
+
+func (v1Mapper) BatchRequest(w http.ResponseWriter, r *http.Request) ([]string, model.BatchPolicy, error) {
+	var b batchAssignReq
+	if err := jsonbody.Decode(w, r, &b); err != nil {
+		return nil, "", err
+	}
+	policy, err := b.validate()
+	if err != nil {
+		return nil, "", err
+	}
+	return b.OrderIds, policy, nil
+}
+
+func (v1Mapper) Assigned(a *model.AssignCourier) any {
+	return assignCourierResp{
+		CourierId: a.CourierId,
+		OrderId:   a.OrderId,
+		Transport: a.Transport.String(),
+		Deadline:  a.Deadline,
+	}
+}
+
+func (v1Mapper) Unassigned(u *model.UnassignCourier) any {
+	return unassignCourierResp{
+		OrderId:   u.OrderId,
+		Status:    u.Status.String(),
+		CourierId: u.CourierId,
+	}
+}
+
+func (v1Mapper) Batch(policy model.BatchPolicy, results []model.AssignResult) any {
+	return toBatchResp(policy, results)
+}
+
+func toBatchResp(policy model.BatchPolicy, results []model.AssignResult) batchAssignResp {
+	resp := batchAssignResp{
+		Policy:  policy.String(),
+		Results: make([]batchResultDTO, 0, len(results)),
+	}
+
+	for _, res := range results {
+		dto := batchResultDTO{OrderId: res.OrderId}
+		if res.Assign != nil {
+			resp.Assigned++
+			dto.Status = "assigned"
+			dto.CourierId = res.Assign.CourierId
+			dto.Transport = res.Assign.Transport.String()
+			dto.Deadline = &res.Assign.Deadline
+		} else {
+			resp.Failed++
+			code, detail := problem.Describe(res.Err)
+			dto.Status = "failed"
+			dto.Error = &batchErrorDTO{Code: string(code), Detail: detail}
+		}
+		resp.Results = append(resp.Results, dto)
+	}
+
+	return resp
+}
@@ -56,6 +56,21 @@ func (mr *MockassignServiceMockRecorder) AssignCourier(ctx, orderId any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourier", reflect.TypeOf((*MockassignService)(nil).AssignCourier), ctx, orderId)
 }
 
+// AssignCouriers mocks base method.
+func (m *MockassignService) AssignCouriers(ctx context.Context, orderIds []string, policy model.BatchPolicy) ([]model.AssignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCouriers", ctx, orderIds, policy)
+	ret0, _ := ret[0].([]model.AssignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignCouriers indicates an expected call of AssignCouriers.
+func (mr *MockassignServiceMockRecorder) AssignCouriers(ctx, orderIds, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCouriers", reflect.TypeOf((*MockassignService)(nil).AssignCouriers), ctx, orderIds, policy)
+}
+
 // UnassignCourier mocks base method.
 func (m *MockassignService) UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,83 @@
+package courier_grpc
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyMetadata carries an API key, like the X-Api-Key header of the HTTP API.
+// Bearer tokens go in the authorization metadata.
+const APIKeyMetadata = "x-api-key"
+
+// methodRoles holds the role rules of the HTTP routes with the same effect.
+// Methods missing here are refused.
+var methodRoles = map[string][]auth.Role{
+	pb.CouriersService_CreateCourier_FullMethodName:    auth.Staff,
+	pb.CouriersService_GetCourier_FullMethodName:       auth.AnyRole,
+	pb.CouriersService_ListCouriers_FullMethodName:     auth.StaffService,
+	pb.CouriersService_UpdateCourier_FullMethodName:    auth.StaffCourier,
+	pb.CouriersService_AssignCourier_FullMethodName:    auth.StaffService,
+	pb.CouriersService_UnassignCourier_FullMethodName:  auth.StaffService,
+	pb.CouriersService_CompleteDelivery_FullMethodName: auth.StaffService,
+	pb.CouriersService_Watch_FullMethodName:            auth.StaffService,
+}
+
+// UnaryAuth authenticates every unary call with a and applies the method's
+// role rule before the handler runs.
+func UnaryAuth(a *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, a, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth is UnaryAuth for streaming calls.
+func StreamAuth(a *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), a, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authorize(ctx context.Context, a *auth.Authenticator, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	p, err := a.Resolve(first(md, APIKeyMetadata), first(md, "authorization"))
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	if !slices.Contains(methodRoles[method], p.Role) {
+		return nil, ErrForbidden
+	}
+
+	return auth.WithPrincipal(ctx, p), nil
+}
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// principalStream hands the authenticated context to stream handlers.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,109 @@
+package courier_grpc
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/handlers/courier_grpc/mocks"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func newKeyAuthenticator(t *testing.T) *auth.Authenticator {
+	t.Helper()
+
+	keys, err := auth.ParseAPIKeys([]byte(`[
+		{"key": "dispatch-key", "subject": "dispatch-1", "role": "dispatcher"},
+		{"key": "courier-key", "subject": "courier-1", "role": "courier", "courier_id": 1}
+	]`))
+	require.NoError(t, err)
+
+	return auth.NewAuthenticator(func() map[[sha256.Size]byte]*auth.Principal { return keys }, nil, "", "")
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), APIKeyMetadata, key)
+}
+
+func TestAuth_RejectsMissingAndInvalidCredentials(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := newAuthClient(t, NewServer(mocks.NewMockcourierService(ctrl), mocks.NewMockassignService(ctrl), events.NewHub(0)), newKeyAuthenticator(t))
+
+	_, err := client.ListCouriers(context.Background(), &pb.ListCouriersRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.ListCouriers(withKey("wrong"), &pb.ListCouriersRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := client.Watch(context.Background(), &pb.WatchRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuth_AppliesRoleRules(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	couriers := mocks.NewMockcourierService(ctrl)
+	client := newAuthClient(t, NewServer(couriers, mocks.NewMockassignService(ctrl), events.NewHub(0)), newKeyAuthenticator(t))
+
+	couriers.EXPECT().GetAllCouriers(gomock.Any()).Return(nil, nil)
+
+	_, err := client.ListCouriers(withKey("dispatch-key"), &pb.ListCouriersRequest{})
+	require.NoError(t, err)
+
+	_, err = client.ListCouriers(withKey("courier-key"), &pb.ListCouriersRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.AssignCourier(withKey("courier-key"), &pb.AssignCourierRequest{OrderId: "o1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	stream, err := client.Watch(withKey("courier-key"), &pb.WatchRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuth_CourierSelfAccess(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	couriers := mocks.NewMockcourierService(ctrl)
+	client := newAuthClient(t, NewServer(couriers, mocks.NewMockassignService(ctrl), events.NewHub(0)), newKeyAuthenticator(t))
+
+	couriers.EXPECT().
+		GetCourierById(gomock.Any(), int64(1)).
+		DoAndReturn(func(ctx context.Context, id int64) (*model.Courier, error) {
+			p, ok := auth.FromContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, "courier-1", p.Subject)
+			return &model.Courier{Id: 1, Status: model.CourierStatusAvailable, Transport: model.Car}, nil
+		})
+
+	_, err := client.GetCourier(withKey("courier-key"), &pb.GetCourierRequest{Id: 1})
+	require.NoError(t, err)
+
+	_, err = client.GetCourier(withKey("courier-key"), &pb.GetCourierRequest{Id: 2})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	name := "Other"
+	_, err = client.UpdateCourier(withKey("courier-key"), &pb.UpdateCourierRequest{Id: 2, Name: &name})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,50 @@
+package courier_grpc
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"course-go-avito-SitnikovArtem06/internal/service/courier_service"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidId      = status.Error(codes.InvalidArgument, "invalid ID")
+	ErrEmptyName      = status.Error(codes.InvalidArgument, "name is empty")
+	ErrInvalidOrderId = status.Error(codes.InvalidArgument, "invalid order_id")
+	ErrFellBehind     = status.Error(codes.ResourceExhausted, "event stream fell behind, resume with after_id")
+
+	ErrUnauthenticated = status.Error(codes.Unauthenticated, "authentication required")
+	ErrForbidden       = status.Error(codes.PermissionDenied, "role is not allowed to perform this call")
+	ErrForeignCourier  = status.Error(codes.PermissionDenied, "couriers may only access their own record")
+)
+
+// toStatus maps service errors to the gRPC codes that match the HTTP API:
+// 400 -> InvalidArgument, 404 -> NotFound, 409 -> AlreadyExists or
+// FailedPrecondition.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, courier_service.ErrNotFound),
+		errors.Is(err, assign_service.ErrNotAssignedCourier),
+		errors.Is(err, assign_service.ErrNotFoundOrder):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, courier_service.ErrInvalidStatus),
+		errors.Is(err, courier_service.ErrInvalidPhoneNumber),
+		errors.Is(err, courier_service.ErrInvalidTransport):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, courier_service.ErrDuplicatePhone),
+		errors.Is(err, assign_service.ErrOrderAlreadyAssign):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, assign_service.ErrNotAvailableCourier),
+		errors.Is(err, assign_service.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,62 @@
+package courier_grpc
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProto(c *model.Courier) *pb.Courier {
+	return &pb.Courier{
+		Id:            c.Id,
+		Name:          c.Name,
+		Phone:         c.Phone,
+		Status:        c.Status.String(),
+		TransportType: c.Transport.String(),
+		CreatedAt:     timestamp(c.CreatedAt),
+		UpdatedAt:     timestamp(c.UpdatedAt),
+	}
+}
+
+func fromCreateProto(req *pb.CreateCourierRequest) model.CreateCourierRequest {
+	return model.CreateCourierRequest{
+		Name:      req.GetName(),
+		Phone:     req.GetPhone(),
+		Status:    model.CourierStatus(req.GetStatus()),
+		Transport: model.TransportType(req.GetTransportType()),
+	}
+}
+
+func fromUpdateProto(req *pb.UpdateCourierRequest) model.UpdateCourierRequest {
+	id := req.GetId()
+	return model.UpdateCourierRequest{
+		Id:        &id,
+		Name:      req.Name,
+		Phone:     req.Phone,
+		Status:    (*model.CourierStatus)(req.Status),
+		Transport: (*model.TransportType)(req.TransportType),
+	}
+}
+
+func eventToProto(e events.Event) *pb.Event {
+	return &pb.Event{
+		Id:            e.Id,
+		Type:          e.Type,
+		CourierId:     e.CourierId,
+		CourierStatus: e.CourierStatus,
+		TransportType: e.Transport,
+		OrderId:       e.OrderId,
+		Deadline:      timestamp(e.Deadline),
+		At:            timestamp(e.At),
+	}
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
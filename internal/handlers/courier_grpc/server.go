@@ -0,0 +1,167 @@
+package courier_grpc
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server exposes CourierService and AssignService as couriers.v1.CouriersService.
+type Server struct {
+	pb.UnimplementedCouriersServiceServer
+	couriers courierService
+	assign   assignService
+	events   eventSource
+}
+
+func NewServer(couriers courierService, assign assignService, events eventSource) *Server {
+	return &Server{couriers: couriers, assign: assign, events: events}
+}
+
+func (s *Server) CreateCourier(ctx context.Context, req *pb.CreateCourierRequest) (*pb.Courier, error) {
+	if req.GetName() == "" {
+		return nil, ErrEmptyName
+	}
+
+	create := fromCreateProto(req)
+
+	c, err := s.couriers.CreateCourier(ctx, &create)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProto(c), nil
+}
+
+func (s *Server) GetCourier(ctx context.Context, req *pb.GetCourierRequest) (*pb.Courier, error) {
+	if req.GetId() <= 0 {
+		return nil, ErrInvalidId
+	}
+	if !auth.CanAccessCourier(ctx, req.GetId()) {
+		return nil, ErrForeignCourier
+	}
+
+	c, err := s.couriers.GetCourierById(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProto(c), nil
+}
+
+func (s *Server) ListCouriers(ctx context.Context, req *pb.ListCouriersRequest) (*pb.ListCouriersResponse, error) {
+	couriers, err := s.couriers.GetAllCouriers(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListCouriersResponse{Couriers: make([]*pb.Courier, 0, len(couriers))}
+	for i := range couriers {
+		resp.Couriers = append(resp.Couriers, toProto(&couriers[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateCourier(ctx context.Context, req *pb.UpdateCourierRequest) (*pb.Courier, error) {
+	if req.GetId() <= 0 {
+		return nil, ErrInvalidId
+	}
+	if req.Name != nil && req.GetName() == "" {
+		return nil, ErrEmptyName
+	}
+	if !auth.CanAccessCourier(ctx, req.GetId()) {
+		return nil, ErrForeignCourier
+	}
+
+	update := fromUpdateProto(req)
+
+	if err := s.couriers.UpdateCourier(ctx, &update); err != nil {
+		return nil, toStatus(err)
+	}
+
+	c, err := s.couriers.GetCourierById(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toProto(c), nil
+}
+
+func (s *Server) AssignCourier(ctx context.Context, req *pb.AssignCourierRequest) (*pb.Assignment, error) {
+	if req.GetOrderId() == "" {
+		return nil, ErrInvalidOrderId
+	}
+
+	assign, err := s.assign.AssignCourier(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.Assignment{
+		CourierId:     assign.CourierId,
+		OrderId:       assign.OrderId,
+		TransportType: assign.Transport.String(),
+		Deadline:      timestamppb.New(assign.Deadline),
+	}, nil
+}
+
+func (s *Server) UnassignCourier(ctx context.Context, req *pb.UnassignCourierRequest) (*pb.UnassignCourierResponse, error) {
+	if req.GetOrderId() == "" {
+		return nil, ErrInvalidOrderId
+	}
+
+	unassign, err := s.assign.UnassignCourier(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.UnassignCourierResponse{
+		CourierId: unassign.CourierId,
+		OrderId:   unassign.OrderId,
+		Status:    unassign.Status.String(),
+	}, nil
+}
+
+func (s *Server) CompleteDelivery(ctx context.Context, req *pb.CompleteDeliveryRequest) (*pb.CompleteDeliveryResponse, error) {
+	if req.GetOrderId() == "" {
+		return nil, ErrInvalidOrderId
+	}
+
+	if _, err := s.assign.CompleteCourier(ctx, req.GetOrderId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CompleteDeliveryResponse{}, nil
+}
+
+// Watch streams events until the client goes away. A client that cannot keep
+// up gets ResourceExhausted and may resume with after_id.
+func (s *Server) Watch(req *pb.WatchRequest, stream grpc.ServerStreamingServer[pb.Event]) error {
+	filter := events.Filter{
+		CourierId: req.GetCourierId(),
+		Types:     req.GetTypes(),
+		Statuses:  req.GetStatuses(),
+	}
+
+	ch, cancel := s.events.Subscribe(filter, req.GetAfterId(), events.DefaultBufferSize)
+	defer cancel()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-ch:
+			if !ok {
+				return ErrFellBehind
+			}
+			if err := stream.Send(eventToProto(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
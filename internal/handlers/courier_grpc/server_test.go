@@ -0,0 +1,178 @@
+package courier_grpc
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/handlers/courier_grpc/mocks"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/pb"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"course-go-avito-SitnikovArtem06/internal/service/courier_service"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newClient(t *testing.T, srv *Server) pb.CouriersServiceClient {
+	t.Helper()
+	return newAuthClient(t, srv, auth.Disabled())
+}
+
+func newAuthClient(t *testing.T, srv *Server, authn *auth.Authenticator) pb.CouriersServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(UnaryAuth(authn)), grpc.ChainStreamInterceptor(StreamAuth(authn)))
+	pb.RegisterCouriersServiceServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewCouriersServiceClient(conn)
+}
+
+func TestCreateCourier_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	couriers := mocks.NewMockcourierService(ctrl)
+	client := newClient(t, NewServer(couriers, mocks.NewMockassignService(ctrl), events.NewHub(0)))
+
+	couriers.EXPECT().
+		CreateCourier(gomock.Any(), &model.CreateCourierRequest{Name: "Artem", Phone: "+79119568101", Status: model.CourierStatusAvailable, Transport: model.Car}).
+		Return(&model.Courier{Id: 1, Name: "Artem", Phone: "+79119568101", Status: model.CourierStatusAvailable, Transport: model.Car}, nil)
+
+	resp, err := client.CreateCourier(context.Background(), &pb.CreateCourierRequest{
+		Name: "Artem", Phone: "+79119568101", Status: "available", TransportType: "car",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.GetId())
+	require.Equal(t, "car", resp.GetTransportType())
+}
+
+func TestCreateCourier_EmptyName(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := newClient(t, NewServer(mocks.NewMockcourierService(ctrl), mocks.NewMockassignService(ctrl), events.NewHub(0)))
+
+	_, err := client.CreateCourier(context.Background(), &pb.CreateCourierRequest{Phone: "+79119568101"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestErrorMapping(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{courier_service.ErrNotFound, codes.NotFound},
+		{courier_service.ErrInvalidPhoneNumber, codes.InvalidArgument},
+		{courier_service.ErrDuplicatePhone, codes.AlreadyExists},
+		{assign_service.ErrOrderAlreadyAssign, codes.AlreadyExists},
+		{assign_service.ErrNotAvailableCourier, codes.FailedPrecondition},
+		{assign_service.ErrNotAssignedCourier, codes.NotFound},
+		{errors.New("db down"), codes.Internal},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.code, status.Code(toStatus(tc.err)), tc.err.Error())
+	}
+	require.Equal(t, "internal error", status.Convert(toStatus(errors.New("db down"))).Message())
+}
+
+func TestUpdateCourier_OnlyPassedFields(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	couriers := mocks.NewMockcourierService(ctrl)
+	client := newClient(t, NewServer(couriers, mocks.NewMockassignService(ctrl), events.NewHub(0)))
+
+	couriers.EXPECT().UpdateCourier(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *model.UpdateCourierRequest) error {
+			require.Equal(t, int64(5), *req.Id)
+			require.Nil(t, req.Name)
+			require.Nil(t, req.Phone)
+			require.Equal(t, model.CourierStatusPaused, *req.Status)
+			return nil
+		})
+	couriers.EXPECT().GetCourierById(gomock.Any(), int64(5)).Return(&model.Courier{Id: 5, Status: model.CourierStatusPaused}, nil)
+
+	paused := "paused"
+	resp, err := client.UpdateCourier(context.Background(), &pb.UpdateCourierRequest{Id: 5, Status: &paused})
+	require.NoError(t, err)
+	require.Equal(t, "paused", resp.GetStatus())
+}
+
+func TestAssignCourier_NoCourier(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assign := mocks.NewMockassignService(ctrl)
+	client := newClient(t, NewServer(mocks.NewMockcourierService(ctrl), assign, events.NewHub(0)))
+
+	assign.EXPECT().AssignCourier(gomock.Any(), "o1").Return(nil, assign_service.ErrNotAvailableCourier)
+
+	_, err := client.AssignCourier(context.Background(), &pb.AssignCourierRequest{OrderId: "o1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.AssignCourier(context.Background(), &pb.AssignCourierRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatch_StreamsFilteredEventsAndResumes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := events.NewHub(events.DefaultHistorySize)
+	client := newClient(t, NewServer(mocks.NewMockcourierService(ctrl), mocks.NewMockassignService(ctrl), hub))
+
+	seen := hub.Publish(events.Event{Type: events.TypeCourierCreated, CourierId: 1})
+	hub.Publish(events.Event{Type: events.TypeDeliveryAssigned, CourierId: 1, OrderId: "o1"})
+	hub.Publish(events.Event{Type: events.TypeDeliveryAssigned, CourierId: 2, OrderId: "o2"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &pb.WatchRequest{CourierId: 1, AfterId: seen.Id})
+	require.NoError(t, err)
+
+	e, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "o1", e.GetOrderId())
+
+	hub.Publish(events.Event{Type: events.TypeDeliveryUnassigned, CourierId: 2, OrderId: "o2"})
+	hub.Publish(events.Event{Type: events.TypeDeliveryCompleted, CourierId: 1, OrderId: "o3"})
+
+	e, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "o3", e.GetOrderId())
+	require.Equal(t, events.TypeDeliveryCompleted, e.GetType())
+}
@@ -0,0 +1,24 @@
+package courier_grpc
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/model"
+)
+
+type courierService interface {
+	CreateCourier(ctx context.Context, c *model.CreateCourierRequest) (*model.Courier, error)
+	GetCourierById(ctx context.Context, id int64) (*model.Courier, error)
+	GetAllCouriers(ctx context.Context) ([]model.Courier, error)
+	UpdateCourier(ctx context.Context, req *model.UpdateCourierRequest) error
+}
+
+type assignService interface {
+	AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error)
+	UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error)
+	CompleteCourier(ctx context.Context, orderId string) (*model.CompleteCourier, error)
+}
+
+type eventSource interface {
+	Subscribe(filter events.Filter, afterId int64, buffer int) (<-chan events.Event, func())
+}
@@ -1,9 +1,9 @@
 package courier_handler
 
 import (
-	"course-go-avito-SitnikovArtem06/internal/service/courier_service"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/chi/v5"
 	"net/http"
 	"strconv"
@@ -11,10 +11,11 @@ import (
 
 type Handler struct {
 	sc courierService
+	m  Mapper
 }
 
-func NewHandler(sc courierService) *Handler {
-	return &Handler{sc: sc}
+func NewHandler(sc courierService, m Mapper) *Handler {
+	return &Handler{sc: sc, m: m}
 }
 
 func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
@@ -23,45 +24,24 @@ func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
 
 	id, err := strconv.Atoi(idStr)
 
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": ErrInvalidId.Error(),
-		})
+	if err != nil || id <= 0 {
+		problem.Write(w, r, ErrInvalidId)
 		return
 	}
 
-	if id <= 0 {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": ErrInvalidId.Error(),
-		})
+	if !auth.CanAccessCourier(r.Context(), int64(id)) {
+		problem.Write(w, r, ErrForeignCourier)
 		return
 	}
 
 	c, err := h.sc.GetCourierById(r.Context(), int64(id))
 
 	if err != nil {
-
-		switch {
-
-		case errors.Is(err, courier_service.ErrNotFound):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-
-		}
+		problem.Write(w, r, err)
 		return
 	}
 
-	resp := toDTO(c)
+	resp := h.m.Courier(c)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -73,70 +53,22 @@ func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) CreateCourier(w http.ResponseWriter, r *http.Request) {
 
-	var reqDto createCourierDTO
-
-	json.NewDecoder(r.Body).Decode(&reqDto)
-
-	if err := reqDto.validateCreate(); err != nil {
-		switch {
-
-		case errors.Is(err, ErrEmptyName):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-		}
+	req, err := h.m.CreateRequest(w, r)
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	req := fromCreateDTO(reqDto)
-
 	c, err := h.sc.CreateCourier(r.Context(), &req)
 
 	if err != nil {
-		switch {
-
-		case errors.Is(err, courier_service.ErrInvalidStatus):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, courier_service.ErrInvalidPhoneNumber):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, courier_service.ErrDuplicatePhone):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, courier_service.ErrInvalidTransport):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
+		problem.Write(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]int64{
-		"id": c.Id,
-	})
+	json.NewEncoder(w).Encode(h.m.Created(c))
 
 }
 
@@ -145,7 +77,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 	couriers, err := h.sc.GetAllCouriers(r.Context())
 
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -153,7 +85,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	enc := json.NewEncoder(w)
 
-	respCouriers := toDTOs(couriers)
+	respCouriers := h.m.Couriers(couriers)
 
 	enc.SetIndent("", "  ")
 	enc.Encode(respCouriers)
@@ -162,77 +94,21 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) UpdateCourier(w http.ResponseWriter, r *http.Request) {
 
-	var reqDto updateCourierDTO
-
-	json.NewDecoder(r.Body).Decode(&reqDto)
-
-	if err := reqDto.validateUpdate(); err != nil {
-		switch {
-
-		case errors.Is(err, ErrEmptyName):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, ErrInvalidId):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-		}
+	req, err := h.m.UpdateRequest(w, r)
+	if err != nil {
+		problem.Write(w, r, err)
 		return
 	}
 
-	req := fromUpdateDTO(reqDto)
+	if !auth.CanAccessCourier(r.Context(), *req.Id) {
+		problem.Write(w, r, ErrForeignCourier)
+		return
+	}
 
-	err := h.sc.UpdateCourier(r.Context(), &req)
+	err = h.sc.UpdateCourier(r.Context(), &req)
 
 	if err != nil {
-
-		switch {
-
-		case errors.Is(err, courier_service.ErrInvalidStatus):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, courier_service.ErrInvalidPhoneNumber):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, courier_service.ErrNotFound):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, courier_service.ErrInvalidTransport):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		case errors.Is(err, courier_service.ErrDuplicatePhone):
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(map[string]string{
-				"error": err.Error(),
-			})
-
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
+		problem.Write(w, r, err)
 		return
 	}
 
@@ -1,6 +1,8 @@
 ﻿package courier_handler
 
 import (
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
 	"bytes"
 	"context"
 	courier_handler "course-go-avito-SitnikovArtem06/internal/handlers/courier_handler/mocks"
@@ -13,6 +15,7 @@ import (
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -24,6 +27,13 @@ func withIDParam(req *http.Request, id string) *http.Request {
 	return req.WithContext(ctx)
 }
 
+// asPrincipal attaches p to req, as the authenticate middleware would.
+func asPrincipal(req *http.Request, p *auth.Principal) *http.Request {
+	return req.WithContext(auth.WithPrincipal(req.Context(), p))
+}
+
+var dispatcher = &auth.Principal{Subject: "dispatch-1", Role: auth.RoleDispatcher}
+
 func TestGetById_Success(t *testing.T) {
 	t.Parallel()
 
@@ -31,7 +41,7 @@ func TestGetById_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	courier := &model.Courier{
 		Id:        1,
@@ -45,7 +55,7 @@ func TestGetById_Success(t *testing.T) {
 		GetCourierById(gomock.Any(), int64(1)).
 		Return(courier, nil)
 
-	req := httptest.NewRequest(http.MethodGet, "/courier/1", nil)
+	req := asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/1", nil), dispatcher)
 	req = withIDParam(req, "1")
 
 	rec := httptest.NewRecorder()
@@ -72,9 +82,9 @@ func TestGetById_InvalidId_NotNumber(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
-	req := httptest.NewRequest(http.MethodGet, "/courier/abc", nil)
+	req := asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/abc", nil), dispatcher)
 	req = withIDParam(req, "abc")
 
 	rec := httptest.NewRecorder()
@@ -82,11 +92,11 @@ func TestGetById_InvalidId_NotNumber(t *testing.T) {
 	h.GetById(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, ErrInvalidId.Error(), resp["error"])
+	require.Equal(t, ErrInvalidId.Error(), resp["detail"])
 }
 
 func TestGetById_InvalidId_NonPositive(t *testing.T) {
@@ -96,10 +106,10 @@ func TestGetById_InvalidId_NonPositive(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	for _, idStr := range []string{"0", "-1"} {
-		req := httptest.NewRequest(http.MethodGet, "/courier/"+idStr, nil)
+		req := asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/"+idStr, nil), dispatcher)
 		req = withIDParam(req, idStr)
 
 		rec := httptest.NewRecorder()
@@ -107,11 +117,11 @@ func TestGetById_InvalidId_NonPositive(t *testing.T) {
 		h.GetById(rec, req)
 
 		require.Equal(t, http.StatusBadRequest, rec.Code)
-		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-		var resp map[string]string
+		var resp map[string]any
 		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-		require.Equal(t, ErrInvalidId.Error(), resp["error"])
+		require.Equal(t, ErrInvalidId.Error(), resp["detail"])
 	}
 }
 
@@ -122,13 +132,13 @@ func TestGetById_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	svc.EXPECT().
 		GetCourierById(gomock.Any(), int64(1)).
 		Return(nil, courier_service.ErrNotFound)
 
-	req := httptest.NewRequest(http.MethodGet, "/courier/1", nil)
+	req := asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/1", nil), dispatcher)
 	req = withIDParam(req, "1")
 
 	rec := httptest.NewRecorder()
@@ -136,11 +146,11 @@ func TestGetById_NotFound(t *testing.T) {
 	h.GetById(rec, req)
 
 	require.Equal(t, http.StatusNotFound, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrNotFound.Error(), resp["error"])
+	require.Equal(t, string(problem.CodeCourierNotFound), resp["code"])
 }
 
 func TestGetById_InternalError(t *testing.T) {
@@ -150,7 +160,7 @@ func TestGetById_InternalError(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	dbErr := errors.New("db error")
 
@@ -158,7 +168,7 @@ func TestGetById_InternalError(t *testing.T) {
 		GetCourierById(gomock.Any(), int64(1)).
 		Return(nil, dbErr)
 
-	req := httptest.NewRequest(http.MethodGet, "/courier/1", nil)
+	req := asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/1", nil), dispatcher)
 	req = withIDParam(req, "1")
 
 	rec := httptest.NewRecorder()
@@ -175,7 +185,7 @@ func TestCreateCourier_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	reqDTO := createCourierDTO{
 		Name:      "Artem",
@@ -190,7 +200,8 @@ func TestCreateCourier_Success(t *testing.T) {
 		CreateCourier(gomock.Any(), gomock.Any()).
 		Return(&model.Courier{Id: 1}, nil)
 
-	req := httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.CreateCourier(rec, req)
@@ -210,7 +221,7 @@ func TestCreateCourier_EmptyName(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	reqDTO := createCourierDTO{
 		Name:      "",
@@ -221,17 +232,85 @@ func TestCreateCourier_EmptyName(t *testing.T) {
 
 	body, _ := json.Marshal(reqDTO)
 
-	req := httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.CreateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, ErrEmptyName.Error(), resp["error"])
+	require.Equal(t, ErrEmptyName.Error(), resp["detail"])
+}
+
+func TestCreateCourier_NameTooLong(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := courier_handler.NewMockcourierService(ctrl)
+	h := NewHandler(svc, V1)
+
+	reqDTO := createCourierDTO{
+		Name:      strings.Repeat("я", MaxNameLen+1),
+		Phone:     "+79119568101",
+		Status:    string(model.CourierStatusAvailable),
+		Transport: string(model.OnFoot),
+	}
+
+	body, _ := json.Marshal(reqDTO)
+
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.CreateCourier(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, string(problem.CodeFieldTooLong), resp["code"])
+}
+
+func TestCreateCourier_RejectsMalformedBodies(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := courier_handler.NewMockcourierService(ctrl)
+	h := NewHandler(svc, V1)
+
+	cases := []struct {
+		contentType string
+		body        string
+		status      int
+		code        problem.Code
+	}{
+		{"text/plain", `{"name":"Artem"}`, http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType},
+		{"application/json", `{"name":"Artem",`, http.StatusBadRequest, problem.CodeMalformedJSON},
+		{"application/json", `{"name":"Artem","age":30}`, http.StatusBadRequest, problem.CodeUnknownField},
+		{"application/json", `{"name":1}`, http.StatusBadRequest, problem.CodeInvalidRequest},
+	}
+
+	for _, tc := range cases {
+		req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", strings.NewReader(tc.body)), dispatcher)
+		req.Header.Set("Content-Type", tc.contentType)
+		rec := httptest.NewRecorder()
+
+		h.CreateCourier(rec, req)
+
+		require.Equal(t, tc.status, rec.Code, tc.body)
+
+		var resp map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Equal(t, string(tc.code), resp["code"], tc.body)
+	}
 }
 
 func TestCreateCourier_InvalidStatus(t *testing.T) {
@@ -241,7 +320,7 @@ func TestCreateCourier_InvalidStatus(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	reqDTO := createCourierDTO{
 		Name:      "Artem",
@@ -256,17 +335,18 @@ func TestCreateCourier_InvalidStatus(t *testing.T) {
 		CreateCourier(gomock.Any(), gomock.Any()).
 		Return(nil, courier_service.ErrInvalidStatus)
 
-	req := httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.CreateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrInvalidStatus.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrInvalidStatus.Error(), resp["detail"])
 }
 
 func TestCreateCourier_InvalidPhone(t *testing.T) {
@@ -276,7 +356,7 @@ func TestCreateCourier_InvalidPhone(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	reqDTO := createCourierDTO{
 		Name:      "Artem",
@@ -291,17 +371,18 @@ func TestCreateCourier_InvalidPhone(t *testing.T) {
 		CreateCourier(gomock.Any(), gomock.Any()).
 		Return(nil, courier_service.ErrInvalidPhoneNumber)
 
-	req := httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.CreateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrInvalidPhoneNumber.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrInvalidPhoneNumber.Error(), resp["detail"])
 }
 
 func TestCreateCourier_InvalidTransport(t *testing.T) {
@@ -311,7 +392,7 @@ func TestCreateCourier_InvalidTransport(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	reqDTO := createCourierDTO{
 		Name:      "Artem",
@@ -326,17 +407,18 @@ func TestCreateCourier_InvalidTransport(t *testing.T) {
 		CreateCourier(gomock.Any(), gomock.Any()).
 		Return(nil, courier_service.ErrInvalidTransport)
 
-	req := httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.CreateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrInvalidTransport.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrInvalidTransport.Error(), resp["detail"])
 }
 
 func TestCreateCourier_DuplicatePhone(t *testing.T) {
@@ -346,7 +428,7 @@ func TestCreateCourier_DuplicatePhone(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	reqDTO := createCourierDTO{
 		Name:      "Artem",
@@ -361,17 +443,18 @@ func TestCreateCourier_DuplicatePhone(t *testing.T) {
 		CreateCourier(gomock.Any(), gomock.Any()).
 		Return(nil, courier_service.ErrDuplicatePhone)
 
-	req := httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.CreateCourier(rec, req)
 
 	require.Equal(t, http.StatusConflict, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrDuplicatePhone.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrDuplicatePhone.Error(), resp["detail"])
 }
 
 func TestCreateCourier_InternalError(t *testing.T) {
@@ -381,7 +464,7 @@ func TestCreateCourier_InternalError(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	reqDTO := createCourierDTO{
 		Name:      "Artem",
@@ -398,7 +481,8 @@ func TestCreateCourier_InternalError(t *testing.T) {
 		CreateCourier(gomock.Any(), gomock.Any()).
 		Return(nil, internalErr)
 
-	req := httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.CreateCourier(rec, req)
@@ -413,7 +497,7 @@ func TestGetAll_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	couriers := []model.Courier{
 		{
@@ -429,7 +513,7 @@ func TestGetAll_Success(t *testing.T) {
 		GetAllCouriers(gomock.Any()).
 		Return(couriers, nil)
 
-	req := httptest.NewRequest(http.MethodGet, "/couriers", nil)
+	req := asPrincipal(httptest.NewRequest(http.MethodGet, "/couriers", nil), dispatcher)
 	rec := httptest.NewRecorder()
 
 	h.GetAll(rec, req)
@@ -455,13 +539,13 @@ func TestGetAll_InternalError(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	svc.EXPECT().
 		GetAllCouriers(gomock.Any()).
 		Return(nil, errors.New("db error"))
 
-	req := httptest.NewRequest(http.MethodGet, "/couriers", nil)
+	req := asPrincipal(httptest.NewRequest(http.MethodGet, "/couriers", nil), dispatcher)
 	rec := httptest.NewRecorder()
 
 	h.GetAll(rec, req)
@@ -476,7 +560,7 @@ func TestUpdateCourier_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 	name := "Artem"
@@ -498,7 +582,8 @@ func TestUpdateCourier_Success(t *testing.T) {
 		UpdateCourier(gomock.Any(), gomock.Any()).
 		Return(nil)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
@@ -513,7 +598,7 @@ func TestUpdateCourier_EmptyName(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 	name := ""
@@ -525,17 +610,18 @@ func TestUpdateCourier_EmptyName(t *testing.T) {
 
 	body, _ := json.Marshal(reqDTO)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, ErrEmptyName.Error(), resp["error"])
+	require.Equal(t, ErrEmptyName.Error(), resp["detail"])
 }
 
 func TestUpdateCourier_InvalidId(t *testing.T) {
@@ -545,7 +631,7 @@ func TestUpdateCourier_InvalidId(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(0)
 
@@ -555,17 +641,18 @@ func TestUpdateCourier_InvalidId(t *testing.T) {
 
 	body, _ := json.Marshal(reqDTO)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, ErrInvalidId.Error(), resp["error"])
+	require.Equal(t, ErrInvalidId.Error(), resp["detail"])
 }
 
 func TestUpdateCourier_InvalidStatus(t *testing.T) {
@@ -575,7 +662,7 @@ func TestUpdateCourier_InvalidStatus(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 	status := "invalid"
@@ -591,17 +678,18 @@ func TestUpdateCourier_InvalidStatus(t *testing.T) {
 		UpdateCourier(gomock.Any(), gomock.Any()).
 		Return(courier_service.ErrInvalidStatus)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrInvalidStatus.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrInvalidStatus.Error(), resp["detail"])
 }
 
 func TestUpdateCourier_InvalidPhone(t *testing.T) {
@@ -611,7 +699,7 @@ func TestUpdateCourier_InvalidPhone(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 	phone := "123"
@@ -627,17 +715,18 @@ func TestUpdateCourier_InvalidPhone(t *testing.T) {
 		UpdateCourier(gomock.Any(), gomock.Any()).
 		Return(courier_service.ErrInvalidPhoneNumber)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrInvalidPhoneNumber.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrInvalidPhoneNumber.Error(), resp["detail"])
 }
 
 func TestUpdateCourier_InvalidTransport(t *testing.T) {
@@ -647,7 +736,7 @@ func TestUpdateCourier_InvalidTransport(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 	transport := "invalid"
@@ -663,17 +752,18 @@ func TestUpdateCourier_InvalidTransport(t *testing.T) {
 		UpdateCourier(gomock.Any(), gomock.Any()).
 		Return(courier_service.ErrInvalidTransport)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrInvalidTransport.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrInvalidTransport.Error(), resp["detail"])
 }
 
 func TestUpdateCourier_NotFound(t *testing.T) {
@@ -683,7 +773,7 @@ func TestUpdateCourier_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 
@@ -697,17 +787,18 @@ func TestUpdateCourier_NotFound(t *testing.T) {
 		UpdateCourier(gomock.Any(), gomock.Any()).
 		Return(courier_service.ErrNotFound)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusNotFound, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrNotFound.Error(), resp["error"])
+	require.Equal(t, string(problem.CodeCourierNotFound), resp["code"])
 }
 
 func TestUpdateCourier_DuplicatePhone(t *testing.T) {
@@ -717,7 +808,7 @@ func TestUpdateCourier_DuplicatePhone(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 	phone := "+79119568101"
@@ -733,17 +824,18 @@ func TestUpdateCourier_DuplicatePhone(t *testing.T) {
 		UpdateCourier(gomock.Any(), gomock.Any()).
 		Return(courier_service.ErrDuplicatePhone)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusConflict, rec.Code)
-	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
 
-	var resp map[string]string
+	var resp map[string]any
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
-	require.Equal(t, courier_service.ErrDuplicatePhone.Error(), resp["error"])
+	require.Equal(t, courier_service.ErrDuplicatePhone.Error(), resp["detail"])
 }
 
 func TestUpdateCourier_InternalError(t *testing.T) {
@@ -753,7 +845,7 @@ func TestUpdateCourier_InternalError(t *testing.T) {
 	defer ctrl.Finish()
 
 	svc := courier_handler.NewMockcourierService(ctrl)
-	h := NewHandler(svc)
+	h := NewHandler(svc, V1)
 
 	id := int64(1)
 
@@ -769,10 +861,76 @@ func TestUpdateCourier_InternalError(t *testing.T) {
 		UpdateCourier(gomock.Any(), gomock.Any()).
 		Return(internalErr)
 
-	req := httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body))
+	req := asPrincipal(httptest.NewRequest(http.MethodPut, "/courier", bytes.NewReader(body)), dispatcher)
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	h.UpdateCourier(rec, req)
 
 	require.Equal(t, http.StatusInternalServerError, rec.Code)
 }
+
+func TestGetById_CourierSelfAccess(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := courier_handler.NewMockcourierService(ctrl)
+	h := NewHandler(svc, V1)
+
+	self := &auth.Principal{Subject: "courier-1", Role: auth.RoleCourier, CourierId: 1}
+
+	svc.EXPECT().
+		GetCourierById(gomock.Any(), int64(1)).
+		Return(&model.Courier{Id: 1, Status: model.CourierStatusAvailable, Transport: model.OnFoot}, nil)
+
+	rec := httptest.NewRecorder()
+	h.GetById(rec, withIDParam(asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/1", nil), self), "1"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.GetById(rec, withIDParam(asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/2", nil), self), "2"))
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestGetById_NoPrincipalForbidden(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHandler(courier_handler.NewMockcourierService(ctrl), V1)
+
+	rec := httptest.NewRecorder()
+	h.GetById(rec, withIDParam(httptest.NewRequest(http.MethodGet, "/courier/1", nil), "1"))
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+// renamingMapper stands in for a later version that renames a field.
+type renamingMapper struct{ Mapper }
+
+func (renamingMapper) Courier(c *model.Courier) any {
+	return map[string]any{"courier_id": c.Id}
+}
+
+func TestGetById_UsesVersionMapper(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := courier_handler.NewMockcourierService(ctrl)
+	h := NewHandler(svc, renamingMapper{V1})
+
+	svc.EXPECT().
+		GetCourierById(gomock.Any(), int64(1)).
+		Return(&model.Courier{Id: 1, Status: model.CourierStatusAvailable, Transport: model.Car}, nil)
+
+	rec := httptest.NewRecorder()
+	h.GetById(rec, withIDParam(asPrincipal(httptest.NewRequest(http.MethodGet, "/courier/1", nil), dispatcher), "1"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"courier_id": 1}`, rec.Body.String())
+}
@@ -1,5 +1,15 @@
 package courier_handler
 
+import "unicode/utf8"
+
+// Field length limits, in characters. They mirror maxLength in the OpenAPI
+// spec.
+const (
+	MaxNameLen  = 100
+	MaxPhoneLen = 20
+	MaxEnumLen  = 32
+)
+
 type courierDTO struct {
 	ID        int64  `json:"id"`
 	Name      string `json:"name"`
@@ -8,6 +18,10 @@ type courierDTO struct {
 	Transport string `json:"transport_type"`
 }
 
+type createdCourierDTO struct {
+	ID int64 `json:"id"`
+}
+
 type createCourierDTO struct {
 	Name      string `json:"name"`
 	Phone     string `json:"phone"`
@@ -31,12 +45,30 @@ func (d updateCourierDTO) validateUpdate() error {
 	if d.Name != nil && *d.Name == "" {
 		return ErrEmptyName
 	}
-	return nil
+	return validateLengths(d.Name, d.Phone, d.Status, d.Transport)
 }
 
 func (c createCourierDTO) validateCreate() error {
 	if c.Name == "" {
 		return ErrEmptyName
 	}
+	return validateLengths(&c.Name, &c.Phone, &c.Status, &c.Transport)
+}
+
+func validateLengths(name, phone, status, transport *string) error {
+	switch {
+	case tooLong(name, MaxNameLen):
+		return ErrNameTooLong
+	case tooLong(phone, MaxPhoneLen):
+		return ErrPhoneTooLong
+	case tooLong(status, MaxEnumLen):
+		return ErrStatusTooLong
+	case tooLong(transport, MaxEnumLen):
+		return ErrTransportTooLong
+	}
 	return nil
 }
+
+func tooLong(s *string, max int) bool {
+	return s != nil && utf8.RuneCountInString(*s) > max
+}
@@ -1,8 +1,19 @@
 package courier_handler
 
-import "errors"
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"fmt"
+	"net/http"
+)
 
 var (
-	ErrInvalidId = errors.New("invalid ID")
-	ErrEmptyName = errors.New("name is empty")
+	ErrInvalidId = problem.New(http.StatusBadRequest, problem.CodeInvalidId, "invalid ID")
+	ErrEmptyName = problem.New(http.StatusBadRequest, problem.CodeInvalidName, "name is empty")
+
+	ErrForeignCourier = problem.New(http.StatusForbidden, problem.CodeForbidden, "couriers may only access their own record")
+
+	ErrNameTooLong      = problem.New(http.StatusBadRequest, problem.CodeFieldTooLong, fmt.Sprintf("name is longer than %d characters", MaxNameLen))
+	ErrPhoneTooLong     = problem.New(http.StatusBadRequest, problem.CodeFieldTooLong, fmt.Sprintf("phone is longer than %d characters", MaxPhoneLen))
+	ErrStatusTooLong    = problem.New(http.StatusBadRequest, problem.CodeFieldTooLong, fmt.Sprintf("status is longer than %d characters", MaxEnumLen))
+	ErrTransportTooLong = problem.New(http.StatusBadRequest, problem.CodeFieldTooLong, fmt.Sprintf("transport_type is longer than %d characters", MaxEnumLen))
 )
@@ -1,9 +1,60 @@
 package courier_handler
 
 import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/jsonbody"
 	"course-go-avito-SitnikovArtem06/internal/model"
+	"net/http"
 )
 
+// Mapper converts between the courier models and the wire format of one API
+// version: it decodes and validates request bodies and builds response bodies.
+type Mapper interface {
+	CreateRequest(w http.ResponseWriter, r *http.Request) (model.CreateCourierRequest, error)
+	UpdateRequest(w http.ResponseWriter, r *http.Request) (model.UpdateCourierRequest, error)
+	Courier(c *model.Courier) any
+	Couriers(cs []model.Courier) any
+	Created(c *model.Courier) any
+}
+
+// V1 is the /api/v1 format.
+var V1 Mapper = v1Mapper{}
+
+type v1Mapper struct{}
+
+func (v1Mapper) CreateRequest(w http.ResponseWriter, r *http.Request) (model.CreateCourierRequest, error) {
+	var d createCourierDTO
+	if err := jsonbody.Decode(w, r, &d); err != nil {
+		return model.CreateCourierRequest{}, err
+	}
+	if err := d.validateCreate(); err != nil {
+		return model.CreateCourierRequest{}, err
+	}
+	return fromCreateDTO(d), nil
+}
+
+func (v1Mapper) UpdateRequest(w http.ResponseWriter, r *http.Request) (model.UpdateCourierRequest, error) {
+	var d updateCourierDTO
+	if err := jsonbody.Decode(w, r, &d); err != nil {
+		return model.UpdateCourierRequest{}, err
+	}
+	if err := d.validateUpdate(); err != nil {
+		return model.UpdateCourierRequest{}, err
+	}
+	return fromUpdateDTO(d), nil
+}
+
+func (v1Mapper) Courier(c *model.Courier) any {
+	return toDTO(c)
+}
+
+func (v1Mapper) Couriers(cs []model.Courier) any {
+	return toDTOs(cs)
+}
+
+func (v1Mapper) Created(c *model.Courier) any {
+	return createdCourierDTO{ID: c.Id}
+}
+
 func toDTO(c *model.Courier) courierDTO {
 	return courierDTO{
 		ID:        c.Id,
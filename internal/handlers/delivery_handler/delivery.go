@@ -0,0 +1,152 @@
+package delivery_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/delivery_service"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const MaxLimit = delivery_service.MaxPageSize
+
+type Handler struct {
+	ds  deliveryService
+	m   Mapper
+	now func() time.Time
+}
+
+func NewHandler(ds deliveryService, m Mapper) *Handler {
+	return &Handler{ds: ds, m: m, now: time.Now}
+}
+
+func (h *Handler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+
+	orderId := chi.URLParam(r, "order_id")
+	if orderId == "" {
+		problem.Write(w, r, ErrInvalidOrderId)
+		return
+	}
+
+	d, err := h.ds.GetDelivery(r.Context(), orderId)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	// Someone else's delivery is reported as missing, so that couriers cannot
+	// probe which orders exist.
+	if !auth.CanAccessCourier(r.Context(), d.CourierId) {
+		problem.Write(w, r, delivery_service.ErrNotFound)
+		return
+	}
+
+	writeJSON(w, h.m.Delivery(d, h.now()))
+}
+
+func (h *Handler) ListCourierDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	courierId, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || courierId <= 0 {
+		problem.Write(w, r, ErrInvalidCourierId)
+		return
+	}
+
+	if !auth.CanAccessCourier(r.Context(), courierId) {
+		problem.Write(w, r, ErrForeignCourier)
+		return
+	}
+
+	filter, limit, err := parseFilter(r)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	page, err := h.ds.ListCourierDeliveries(r.Context(), courierId, filter, limit, r.URL.Query().Get("page_token"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, h.m.Page(page, h.now()))
+}
+
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	filter, limit, err := parseFilter(r)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	if v := r.URL.Query().Get("courier_id"); v != "" {
+		courierId, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || courierId <= 0 {
+			problem.Write(w, r, ErrInvalidCourierId)
+			return
+		}
+		filter.CourierId = courierId
+	}
+
+	page, err := h.ds.ListDeliveries(r.Context(), filter, limit, r.URL.Query().Get("page_token"))
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, h.m.Page(page, h.now()))
+}
+
+// parseFilter reads the query parameters shared by the list endpoints:
+// status is a comma-separated list, from and to bound assigned_at.
+func parseFilter(r *http.Request) (model.DeliveryFilter, int, error) {
+	var filter model.DeliveryFilter
+	query := r.URL.Query()
+
+	if v := query.Get("status"); v != "" {
+		for _, s := range strings.Split(v, ",") {
+			filter.Statuses = append(filter.Statuses, model.DeliveryStatus(strings.TrimSpace(s)))
+		}
+	}
+
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, 0, ErrInvalidFrom
+		}
+		filter.From = from.UTC()
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, 0, ErrInvalidTo
+		}
+		filter.To = to.UTC()
+	}
+
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > MaxLimit {
+			return filter, 0, ErrInvalidLimit
+		}
+		limit = n
+	}
+
+	return filter, limit, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
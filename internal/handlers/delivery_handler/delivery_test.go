@@ -0,0 +1,208 @@
+package delivery_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	delivery_handler "course-go-avito-SitnikovArtem06/internal/handlers/delivery_handler/mocks"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/delivery_service"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestHandler(t *testing.T) (*Handler, *delivery_handler.MockdeliveryService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	svc := delivery_handler.NewMockdeliveryService(ctrl)
+
+	h := NewHandler(svc, V1)
+	h.now = func() time.Time { return now }
+	return h, svc
+}
+
+func router(h *Handler) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/delivery/{order_id}", h.GetDelivery)
+	r.Get("/courier/{id}/deliveries", h.ListCourierDeliveries)
+	r.Get("/deliveries", h.ListDeliveries)
+	return r
+}
+
+var dispatcher = &auth.Principal{Subject: "dispatch-1", Role: auth.RoleDispatcher}
+
+func get(h http.Handler, path string, p *auth.Principal) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if p != nil {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), p))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func problemCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp["code"].(string)
+}
+
+func TestGetDelivery_Success(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestHandler(t)
+
+	svc.EXPECT().GetDelivery(gomock.Any(), "o-1").Return(&model.Delivery{
+		OrderId:     "o-1",
+		CourierId:   1,
+		CourierName: "Artem",
+		Transport:   model.Car,
+		Status:      model.DeliveryDelivering,
+		AssignedAt:  now.Add(-time.Minute),
+		Deadline:    now.Add(5 * time.Minute),
+	}, nil)
+
+	rec := get(router(h), "/delivery/o-1", dispatcher)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{
+		"order_id": "o-1",
+		"status": "delivering",
+		"courier": {"id": 1, "name": "Artem"},
+		"transport_type": "car",
+		"assigned_at": "2026-10-01T11:59:00Z",
+		"deadline": "2026-10-01T12:05:00Z",
+		"remaining_seconds": 300
+	}`, rec.Body.String())
+}
+
+func TestGetDelivery_RemainingTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   model.DeliveryStatus
+		deadline time.Time
+		want     any
+	}{
+		{"overdue", model.DeliveryAssigned, now.Add(-time.Minute), float64(0)},
+		{"finished", model.DeliveryCompleted, now.Add(time.Minute), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h, svc := newTestHandler(t)
+			svc.EXPECT().GetDelivery(gomock.Any(), "o-1").Return(&model.Delivery{OrderId: "o-1", Status: tt.status, Deadline: tt.deadline}, nil)
+
+			rec := get(router(h), "/delivery/o-1", dispatcher)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var resp map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Equal(t, tt.want, resp["remaining_seconds"])
+		})
+	}
+}
+
+func TestGetDelivery_Errors(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestHandler(t)
+	svc.EXPECT().GetDelivery(gomock.Any(), "missing").Return(nil, delivery_service.ErrNotFound)
+	svc.EXPECT().GetDelivery(gomock.Any(), "foreign").Return(&model.Delivery{OrderId: "foreign", CourierId: 2}, nil)
+
+	rec := get(router(h), "/delivery/missing", dispatcher)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+	courier := &auth.Principal{Subject: "c-1", Role: auth.RoleCourier, CourierId: 1}
+	foreign := get(router(h), "/delivery/foreign", courier)
+	require.Equal(t, http.StatusNotFound, foreign.Code)
+	require.Equal(t, problemCode(t, rec), problemCode(t, foreign), "a foreign order looks like a missing one")
+}
+
+func TestGetDelivery_NoPrincipalNotFound(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestHandler(t)
+	svc.EXPECT().GetDelivery(gomock.Any(), "o-1").Return(&model.Delivery{OrderId: "o-1", CourierId: 1}, nil)
+
+	require.Equal(t, http.StatusNotFound, get(router(h), "/delivery/o-1", nil).Code)
+	require.Equal(t, http.StatusForbidden, get(router(h), "/courier/1/deliveries", nil).Code)
+}
+
+func TestListDeliveries_Filters(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestHandler(t)
+
+	want := model.DeliveryFilter{
+		CourierId: 3,
+		Statuses:  []model.DeliveryStatus{model.DeliveryAssigned, model.DeliveryPickedUp},
+		From:      time.Date(2026, 10, 1, 7, 0, 0, 0, time.UTC),
+		To:        time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+	}
+	svc.EXPECT().ListDeliveries(gomock.Any(), want, 20, "tok").Return(&model.DeliveriesPage{
+		Deliveries:    []model.Delivery{{OrderId: "o-1", Status: model.DeliveryAssigned, Deadline: now}},
+		NextPageToken: "next",
+	}, nil)
+
+	rec := get(router(h), "/deliveries?courier_id=3&status=assigned,picked_up&from=2026-10-01T10:00:00%2B03:00&to=2026-10-02T00:00:00Z&limit=20&page_token=tok", dispatcher)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Deliveries    []map[string]any `json:"deliveries"`
+		NextPageToken string           `json:"next_page_token"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Deliveries, 1)
+	require.Equal(t, "o-1", resp.Deliveries[0]["order_id"])
+	require.Equal(t, "next", resp.NextPageToken)
+}
+
+func TestListDeliveries_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	h, _ := newTestHandler(t)
+
+	for _, path := range []string{
+		"/deliveries?courier_id=x",
+		"/deliveries?from=yesterday",
+		"/deliveries?to=2026-10-01",
+		"/deliveries?limit=0",
+		"/deliveries?limit=201",
+		"/courier/0/deliveries",
+	} {
+		rec := get(router(h), path, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+}
+
+func TestListCourierDeliveries(t *testing.T) {
+	t.Parallel()
+
+	h, svc := newTestHandler(t)
+	svc.EXPECT().ListCourierDeliveries(gomock.Any(), int64(1), model.DeliveryFilter{}, 0, "").Return(&model.DeliveriesPage{}, nil)
+
+	courier := &auth.Principal{Subject: "c-1", Role: auth.RoleCourier, CourierId: 1}
+
+	rec := get(router(h), "/courier/1/deliveries", courier)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"deliveries": []}`, rec.Body.String())
+
+	require.Equal(t, http.StatusForbidden, get(router(h), "/courier/2/deliveries", courier).Code)
+}
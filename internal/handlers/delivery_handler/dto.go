@@ -0,0 +1,25 @@
+package delivery_handler
+
+import "time"
+
+type courierRefDTO struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type deliveryDTO struct {
+	OrderId    string        `json:"order_id"`
+	Status     string        `json:"status"`
+	Courier    courierRefDTO `json:"courier"`
+	Transport  string        `json:"transport_type"`
+	AssignedAt time.Time     `json:"assigned_at"`
+	Deadline   time.Time     `json:"deadline"`
+	// RemainingSeconds is null once the delivery is finished and zero while
+	// it is overdue.
+	RemainingSeconds *int64 `json:"remaining_seconds"`
+}
+
+type deliveriesPageDTO struct {
+	Deliveries    []deliveryDTO `json:"deliveries"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
@@ -0,0 +1,17 @@
+package delivery_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrInvalidOrderId   = problem.New(http.StatusBadRequest, problem.CodeInvalidOrderId, "invalid order_id")
+	ErrInvalidCourierId = problem.New(http.StatusBadRequest, problem.CodeInvalidId, "invalid courier ID")
+	ErrInvalidLimit     = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, fmt.Sprintf("limit must be an integer from 1 to %d", MaxLimit))
+	ErrInvalidFrom      = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, "from must be an RFC 3339 date-time")
+	ErrInvalidTo        = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, "to must be an RFC 3339 date-time")
+
+	ErrForeignCourier = problem.New(http.StatusForbidden, problem.CodeForbidden, "couriers may only access their own deliveries")
+)
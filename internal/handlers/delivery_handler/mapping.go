@@ -0,0 +1,53 @@
+package delivery_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"time"
+)
+
+// Mapper builds the delivery response bodies of one API version. now is the
+// time remaining durations are measured from.
+type Mapper interface {
+	Delivery(d *model.Delivery, now time.Time) any
+	Page(p *model.DeliveriesPage, now time.Time) any
+}
+
+// V1 is the /api/v1 format.
+var V1 Mapper = v1Mapper{}
+
+type v1Mapper struct{}
+
+func (v1Mapper) Delivery(d *model.Delivery, now time.Time) any {
+	return toDTO(d, now)
+}
+
+func (v1Mapper) Page(p *model.DeliveriesPage, now time.Time) any {
+	return toPageDTO(p, now)
+}
+
+func toDTO(d *model.Delivery, now time.Time) deliveryDTO {
+	dto := deliveryDTO{
+		OrderId:    d.OrderId,
+		Status:     d.Status.String(),
+		Courier:    courierRefDTO{ID: d.CourierId, Name: d.CourierName},
+		Transport:  d.Transport.String(),
+		AssignedAt: d.AssignedAt,
+		Deadline:   d.Deadline,
+	}
+	if d.Active() {
+		remaining := int64(d.Remaining(now) / time.Second)
+		dto.RemainingSeconds = &remaining
+	}
+	return dto
+}
+
+func toPageDTO(p *model.DeliveriesPage, now time.Time) deliveriesPageDTO {
+	out := deliveriesPageDTO{
+		Deliveries:    make([]deliveryDTO, 0, len(p.Deliveries)),
+		NextPageToken: p.NextPageToken,
+	}
+	for i := range p.Deliveries {
+		out.Deliveries = append(out.Deliveries, toDTO(&p.Deliveries[i], now))
+	}
+	return out
+}
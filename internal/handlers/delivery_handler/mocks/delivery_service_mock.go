@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/handlers/delivery_handler/service_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/handlers/delivery_handler/service_contract.go -destination=internal/handlers/delivery_handler/mocks/delivery_service_mock.go -package=delivery_handler
+//
+
+// Package delivery_handler is a generated GoMock package.
+package delivery_handler
+
+import (
+	context "context"
+	model "course-go-avito-SitnikovArtem06/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdeliveryService is a mock of deliveryService interface.
+type MockdeliveryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockdeliveryServiceMockRecorder is the mock recorder for MockdeliveryService.
+type MockdeliveryServiceMockRecorder struct {
+	mock *MockdeliveryService
+}
+
+// NewMockdeliveryService creates a new mock instance.
+func NewMockdeliveryService(ctrl *gomock.Controller) *MockdeliveryService {
+	mock := &MockdeliveryService{ctrl: ctrl}
+	mock.recorder = &MockdeliveryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryService) EXPECT() *MockdeliveryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDelivery mocks base method.
+func (m *MockdeliveryService) GetDelivery(ctx context.Context, orderId string) (*model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, orderId)
+	ret0, _ := ret[0].(*model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockdeliveryServiceMockRecorder) GetDelivery(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockdeliveryService)(nil).GetDelivery), ctx, orderId)
+}
+
+// ListCourierDeliveries mocks base method.
+func (m *MockdeliveryService) ListCourierDeliveries(ctx context.Context, courierId int64, filter model.DeliveryFilter, limit int, pageToken string) (*model.DeliveriesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCourierDeliveries", ctx, courierId, filter, limit, pageToken)
+	ret0, _ := ret[0].(*model.DeliveriesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCourierDeliveries indicates an expected call of ListCourierDeliveries.
+func (mr *MockdeliveryServiceMockRecorder) ListCourierDeliveries(ctx, courierId, filter, limit, pageToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourierDeliveries", reflect.TypeOf((*MockdeliveryService)(nil).ListCourierDeliveries), ctx, courierId, filter, limit, pageToken)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockdeliveryService) ListDeliveries(ctx context.Context, filter model.DeliveryFilter, limit int, pageToken string) (*model.DeliveriesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, filter, limit, pageToken)
+	ret0, _ := ret[0].(*model.DeliveriesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockdeliveryServiceMockRecorder) ListDeliveries(ctx, filter, limit, pageToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockdeliveryService)(nil).ListDeliveries), ctx, filter, limit, pageToken)
+}
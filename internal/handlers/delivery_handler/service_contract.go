@@ -0,0 +1,13 @@
+package delivery_handler
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+)
+
+type deliveryService interface {
+	GetDelivery(ctx context.Context, orderId string) (*model.Delivery, error)
+
+	ListDeliveries(ctx context.Context, filter model.DeliveryFilter, limit int, pageToken string) (*model.DeliveriesPage, error)
+	ListCourierDeliveries(ctx context.Context, courierId int64, filter model.DeliveryFilter, limit int, pageToken string) (*model.DeliveriesPage, error)
+}
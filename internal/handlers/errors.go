@@ -0,0 +1,11 @@
+package handlers
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"net/http"
+)
+
+var (
+	ErrRouteNotFound    = problem.New(http.StatusNotFound, problem.CodeNotFound, "no such route")
+	ErrMethodNotAllowed = problem.New(http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, "method not allowed")
+)
@@ -0,0 +1,16 @@
+package event_handler
+
+import "time"
+
+type eventDTO struct {
+	Id            int64      `json:"id"`
+	Type          string     `json:"type"`
+	CourierId     int64      `json:"courier_id,omitempty"`
+	CourierStatus string     `json:"courier_status,omitempty"`
+	Transport     string     `json:"transport_type,omitempty"`
+	OrderId       string     `json:"order_id,omitempty"`
+	Deadline      *time.Time `json:"deadline,omitempty"`
+	Actor         string     `json:"actor,omitempty"`
+	ActorRole     string     `json:"actor_role,omitempty"`
+	At            time.Time  `json:"at"`
+}
@@ -0,0 +1,13 @@
+package event_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"net/http"
+)
+
+var (
+	ErrInvalidCourierId   = problem.New(http.StatusBadRequest, problem.CodeInvalidId, "invalid courier ID")
+	ErrInvalidStatus      = problem.New(http.StatusBadRequest, problem.CodeInvalidStatus, "status must list courier statuses")
+	ErrInvalidType        = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, "type must list event types")
+	ErrInvalidLastEventId = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, "Last-Event-ID must be a non-negative integer")
+)
@@ -0,0 +1,35 @@
+package event_handler
+
+import "course-go-avito-SitnikovArtem06/internal/events"
+
+// Mapper builds the event payloads of one API version.
+type Mapper interface {
+	Event(e events.Event) any
+}
+
+// V1 is the /api/v1 format.
+var V1 Mapper = v1Mapper{}
+
+type v1Mapper struct{}
+
+func (v1Mapper) Event(e events.Event) any {
+	return toDTO(e)
+}
+
+func toDTO(e events.Event) eventDTO {
+	dto := eventDTO{
+		Id:            e.Id,
+		Type:          e.Type,
+		CourierId:     e.CourierId,
+		CourierStatus: e.CourierStatus,
+		Transport:     e.Transport,
+		OrderId:       e.OrderId,
+		Actor:         e.Actor,
+		ActorRole:     e.ActorRole,
+		At:            e.At,
+	}
+	if !e.Deadline.IsZero() {
+		dto.Deadline = &e.Deadline
+	}
+	return dto
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/handlers/event_handler/source_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/handlers/event_handler/source_contract.go -destination=internal/handlers/event_handler/mocks/event_source_mock.go -package=event_handler
+//
+
+// Package event_handler is a generated GoMock package.
+package event_handler
+
+import (
+	events "course-go-avito-SitnikovArtem06/internal/events"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockeventSource is a mock of eventSource interface.
+type MockeventSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockeventSourceMockRecorder is the mock recorder for MockeventSource.
+type MockeventSourceMockRecorder struct {
+	mock *MockeventSource
+}
+
+// NewMockeventSource creates a new mock instance.
+func NewMockeventSource(ctrl *gomock.Controller) *MockeventSource {
+	mock := &MockeventSource{ctrl: ctrl}
+	mock.recorder = &MockeventSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventSource) EXPECT() *MockeventSourceMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockeventSource) Subscribe(filter events.Filter, afterId int64, buffer int) (<-chan events.Event, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", filter, afterId, buffer)
+	ret0, _ := ret[0].(<-chan events.Event)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockeventSourceMockRecorder) Subscribe(filter, afterId, buffer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockeventSource)(nil).Subscribe), filter, afterId, buffer)
+}
@@ -0,0 +1,7 @@
+package event_handler
+
+import "course-go-avito-SitnikovArtem06/internal/events"
+
+type eventSource interface {
+	Subscribe(filter events.Filter, afterId int64, buffer int) (<-chan events.Event, func())
+}
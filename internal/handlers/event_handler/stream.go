@@ -0,0 +1,171 @@
+package event_handler
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LastEventIdHeader = "Last-Event-ID"
+
+	DefaultHeartbeat = 15 * time.Second
+
+	// retryMillis is how long browsers wait before reconnecting.
+	retryMillis = 1000
+)
+
+var eventTypes = []string{
+	events.TypeCourierCreated,
+	events.TypeCourierUpdated,
+	events.TypeDeliveryAssigned,
+	events.TypeDeliveryUnassigned,
+	events.TypeDeliveryPickedUp,
+	events.TypeDeliveryDelivering,
+	events.TypeDeliveryCompleted,
+	events.TypeDeliveryFailed,
+	events.TypeDeliveryReturned,
+}
+
+type Handler struct {
+	source    eventSource
+	m         Mapper
+	heartbeat time.Duration
+}
+
+func NewHandler(source eventSource, m Mapper) *Handler {
+	return &Handler{source: source, m: m, heartbeat: DefaultHeartbeat}
+}
+
+// Stream sends matching events as Server-Sent Events until the client goes
+// away. A client that falls behind is disconnected; EventSource reconnects
+// with Last-Event-ID and gets what it missed from the hub's history.
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+
+	filter, err := parseFilter(r)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	afterId, err := lastEventId(r)
+	if err != nil {
+		problem.Write(w, r, err)
+		return
+	}
+
+	ch, cancel := h.source.Subscribe(filter, afterId, events.DefaultBufferSize)
+	defer cancel()
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := h.writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *Handler) writeEvent(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(h.m.Event(e))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Id, e.Type, data)
+	return err
+}
+
+// parseFilter reads courier_id and the comma-separated status and type
+// query parameters.
+func parseFilter(r *http.Request) (events.Filter, error) {
+	var filter events.Filter
+	query := r.URL.Query()
+
+	if v := query.Get("courier_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return filter, ErrInvalidCourierId
+		}
+		filter.CourierId = id
+	}
+
+	for _, s := range splitList(query.Get("status")) {
+		if !model.CourierStatus(s).IsValid() {
+			return filter, ErrInvalidStatus
+		}
+		filter.Statuses = append(filter.Statuses, s)
+	}
+
+	for _, t := range splitList(query.Get("type")) {
+		if !slices.Contains(eventTypes, t) {
+			return filter, ErrInvalidType
+		}
+		filter.Types = append(filter.Types, t)
+	}
+
+	return filter, nil
+}
+
+// lastEventId prefers the header EventSource sends on reconnect over the
+// query parameter a client can set on its first connection.
+func lastEventId(r *http.Request) (int64, error) {
+	v := r.Header.Get(LastEventIdHeader)
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidLastEventId
+	}
+	return id, nil
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	parts := strings.Split(v, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
@@ -0,0 +1,131 @@
+package event_handler
+
+import (
+	"bufio"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	id    string
+	event string
+	data  eventDTO
+}
+
+func connect(t *testing.T, srv *httptest.Server, query, lastEventId string) *bufio.Reader {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+query, nil)
+	require.NoError(t, err)
+	if lastEventId != "" {
+		req.Header.Set(LastEventIdHeader, lastEventId)
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+// next reads up to the next message, skipping the retry hint and comments.
+func next(t *testing.T, r *bufio.Reader) message {
+	t.Helper()
+
+	var m message
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "" && m.id != "":
+			return m
+		case strings.HasPrefix(line, "id: "):
+			m.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			m.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &m.data))
+		}
+	}
+}
+
+func TestStream_SendsMatchingEvents(t *testing.T) {
+	t.Parallel()
+
+	hub := events.NewHub(events.DefaultHistorySize)
+	srv := httptest.NewServer(http.HandlerFunc(NewHandler(hub, V1).Stream))
+	t.Cleanup(srv.Close)
+
+	// The handler subscribes before it sends headers, so nothing published
+	// after connect returns is missed.
+	r := connect(t, srv, "/?courier_id=1&type=delivery.assigned", "")
+
+	deadline := time.Date(2026, 10, 1, 12, 30, 0, 0, time.UTC)
+	hub.Publish(events.Event{Type: events.TypeDeliveryAssigned, CourierId: 2, OrderId: "o-2"})
+	hub.Publish(events.Event{Type: events.TypeCourierUpdated, CourierId: 1})
+	hub.Publish(events.Event{Type: events.TypeDeliveryAssigned, CourierId: 1, OrderId: "o-1", Deadline: deadline, Actor: "anna"})
+
+	m := next(t, r)
+	require.Equal(t, "3", m.id)
+	require.Equal(t, events.TypeDeliveryAssigned, m.event)
+	require.Equal(t, int64(3), m.data.Id)
+	require.Equal(t, "o-1", m.data.OrderId)
+	require.Equal(t, "anna", m.data.Actor)
+	require.Equal(t, deadline, *m.data.Deadline)
+}
+
+func TestStream_ResumesAfterLastEventId(t *testing.T) {
+	t.Parallel()
+
+	hub := events.NewHub(events.DefaultHistorySize)
+	for _, orderId := range []string{"o-1", "o-2", "o-3"} {
+		hub.Publish(events.Event{Type: events.TypeDeliveryAssigned, CourierId: 1, OrderId: orderId})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(NewHandler(hub, V1).Stream))
+	t.Cleanup(srv.Close)
+
+	r := connect(t, srv, "/", "1")
+	require.Equal(t, "o-2", next(t, r).data.OrderId)
+	require.Equal(t, "o-3", next(t, r).data.OrderId)
+
+	r = connect(t, srv, "/?last_event_id=2", "")
+	require.Equal(t, "o-3", next(t, r).data.OrderId)
+}
+
+func TestStream_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(events.NewHub(0), V1)
+
+	for _, tc := range []struct {
+		query       string
+		lastEventId string
+	}{
+		{"/?courier_id=0", ""},
+		{"/?status=sleeping", ""},
+		{"/?type=courier.deleted", ""},
+		{"/", "abc"},
+		{"/?last_event_id=-1", ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.query, nil)
+		if tc.lastEventId != "" {
+			req.Header.Set(LastEventIdHeader, tc.lastEventId)
+		}
+		rec := httptest.NewRecorder()
+		h.Stream(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, tc.query)
+	}
+}
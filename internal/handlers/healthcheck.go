@@ -1,9 +1,18 @@
 package handlers
 
 import (
+	"course-go-avito-SitnikovArtem06/internal/health"
 	"net/http"
 )
 
-func HealthCheck(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNoContent)
+// HealthCheck is the bodyless form of /readyz: 204 when every check is up
+// and 503 otherwise.
+func HealthCheck(ready *health.Readiness) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if ready.Check(r.Context()).Status != health.StatusUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
 }
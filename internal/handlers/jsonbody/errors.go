@@ -0,0 +1,15 @@
+package jsonbody
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"net/http"
+)
+
+var (
+	ErrUnsupportedMediaType = problem.New(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType, "content type must be application/json")
+	ErrEmptyBody            = problem.New(http.StatusBadRequest, problem.CodeMalformedJSON, "request body is empty")
+	ErrMalformedJSON        = problem.New(http.StatusBadRequest, problem.CodeMalformedJSON, "malformed JSON")
+	ErrBodyTooLarge         = problem.New(http.StatusBadRequest, problem.CodeBodyTooLarge, "request body is too large")
+	ErrUnknownField         = problem.New(http.StatusBadRequest, problem.CodeUnknownField, "unknown field")
+	ErrInvalidFieldType     = problem.New(http.StatusBadRequest, problem.CodeInvalidRequest, "invalid field type")
+)
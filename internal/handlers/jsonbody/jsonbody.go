@@ -0,0 +1,75 @@
+package jsonbody
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MaxBytes caps every JSON request body. Courier and assignment payloads
+// are a few hundred bytes; batches stay well below this.
+const MaxBytes = 64 << 10
+
+// CheckContentType accepts application/json with any parameters.
+func CheckContentType(r *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return ErrUnsupportedMediaType
+	}
+	return nil
+}
+
+// Decode strictly decodes a single JSON value from the request body into
+// dst: the content type must be JSON, the body must fit into MaxBytes,
+// unknown fields and trailing data are rejected.
+func Decode(w http.ResponseWriter, r *http.Request, dst any) error {
+	if err := CheckContentType(r); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return decodeError(err)
+		}
+		return fmt.Errorf("%w: unexpected data after the JSON value", ErrMalformedJSON)
+	}
+	return nil
+}
+
+func decodeError(err error) error {
+	var (
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+		maxBytesErr *http.MaxBytesError
+	)
+	switch {
+	case errors.Is(err, io.EOF):
+		return ErrEmptyBody
+	case errors.As(err, &maxBytesErr):
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxBytesErr.Limit)
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("%w at offset %d", ErrMalformedJSON, syntaxErr.Offset)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return fmt.Errorf("%w: unexpected end of input", ErrMalformedJSON)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return fmt.Errorf("%w: unexpected JSON %s for the body", ErrInvalidFieldType, typeErr.Value)
+		}
+		return fmt.Errorf("%w: %s must be %s", ErrInvalidFieldType, typeErr.Field, typeErr.Type)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return fmt.Errorf("%w %s", ErrUnknownField, strings.TrimPrefix(err.Error(), "json: unknown field "))
+	default:
+		return fmt.Errorf("%w: %v", ErrMalformedJSON, err)
+	}
+}
@@ -0,0 +1,64 @@
+package jsonbody
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func decode(contentType, body string) (payload, error) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	var p payload
+	err := Decode(httptest.NewRecorder(), req, &p)
+	return p, err
+}
+
+func TestDecode_Valid(t *testing.T) {
+	t.Parallel()
+
+	p, err := decode("application/json; charset=utf-8", `{"name":"a","count":2}`+"\n")
+	require.NoError(t, err)
+	require.Equal(t, payload{Name: "a", Count: 2}, p)
+}
+
+func TestDecode_Rejects(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		want        error
+		detail      string
+	}{
+		{"no content type", "", `{}`, ErrUnsupportedMediaType, "content type must be application/json"},
+		{"form content type", "application/x-www-form-urlencoded", `{}`, ErrUnsupportedMediaType, "content type must be application/json"},
+		{"empty", "application/json", ``, ErrEmptyBody, "request body is empty"},
+		{"syntax", "application/json", `{"name":}`, ErrMalformedJSON, "malformed JSON at offset 9"},
+		{"truncated", "application/json", `{"name":"a"`, ErrMalformedJSON, "malformed JSON: unexpected end of input"},
+		{"trailing", "application/json", `{"name":"a"} {}`, ErrMalformedJSON, "malformed JSON: unexpected data after the JSON value"},
+		{"unknown field", "application/json", `{"name":"a","extra":1}`, ErrUnknownField, `unknown field "extra"`},
+		{"wrong type", "application/json", `{"count":"2"}`, ErrInvalidFieldType, "invalid field type: count must be int"},
+		{"not an object", "application/json", `[1]`, ErrInvalidFieldType, "invalid field type: unexpected JSON array for the body"},
+		{"too large", "application/json", `{"name":"` + strings.Repeat("a", MaxBytes) + `"}`, ErrBodyTooLarge, "request body is too large: limit is 65536 bytes"},
+	}
+
+	for _, tc := range cases {
+		_, err := decode(tc.contentType, tc.body)
+		require.True(t, errors.Is(err, tc.want), "%s: got %v", tc.name, err)
+		require.Equal(t, tc.detail, err.Error(), tc.name)
+	}
+}
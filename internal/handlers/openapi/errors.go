@@ -0,0 +1,21 @@
+package openapi
+
+import "course-go-avito-SitnikovArtem06/internal/handlers/problem"
+
+// ValidationError describes the first part of a request that does not match
+// the specification.
+type ValidationError struct {
+	In     string
+	Field  string
+	Reason string
+	// Code overrides problem.CodeInvalidRequest for violations clients
+	// commonly need to tell apart.
+	Code problem.Code
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.In + " " + e.Reason
+	}
+	return e.In + " " + e.Field + " " + e.Reason
+}
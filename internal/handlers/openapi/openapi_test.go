@@ -0,0 +1,131 @@
+package openapi
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/jsonbody"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestValidator(t *testing.T) http.Handler {
+	t.Helper()
+
+	doc, err := Load()
+	require.NoError(t, err)
+
+	return NewValidator(doc).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestValidator_PassesValidRequests(t *testing.T) {
+	t.Parallel()
+
+	h := newTestValidator(t)
+
+	cases := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/api/v1/courier/7", ""},
+		{http.MethodGet, "/api/v1/couriers", ""},
+		{http.MethodPost, "/api/v1/courier", `{"name":"Artem","phone":"+79119568101","status":"available","transport_type":"car"}`},
+		{http.MethodPut, "/api/v1/courier", `{"id":7,"status":"paused"}`},
+		{http.MethodPost, "/api/v1/delivery/assign", `{"order_id":"o-1"}`},
+		{http.MethodGet, "/unknown", ""},
+		{http.MethodDelete, "/api/v1/courier/7", ""},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusTeapot, rec.Code, "%s %s: %s", tc.method, tc.path, rec.Body.String())
+		require.Equal(t, tc.body, rec.Body.String(), "body must reach the handler intact")
+	}
+}
+
+func TestValidator_RejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	h := newTestValidator(t)
+
+	cases := []struct {
+		method string
+		path   string
+		body   string
+		want   string
+		code   problem.Code
+	}{
+		{http.MethodGet, "/api/v1/courier/abc", "", "path id must be an integer", problem.CodeInvalidRequest},
+		{http.MethodGet, "/api/v1/courier/0", "", "path id must be at least 1", problem.CodeInvalidRequest},
+		{http.MethodPost, "/api/v1/courier", "", "body is required", problem.CodeInvalidRequest},
+		{http.MethodPost, "/api/v1/courier", `{"name":`, "body is not valid JSON", problem.CodeMalformedJSON},
+		{http.MethodPost, "/api/v1/courier", `{"phone":"+79119568101","status":"available","transport_type":"car"}`, "body name is required", problem.CodeInvalidRequest},
+		{http.MethodPost, "/api/v1/courier", `{"name":"","phone":"+79119568101","status":"available","transport_type":"car"}`, "body name length must be at least 1", problem.CodeInvalidRequest},
+		{http.MethodPost, "/api/v1/courier", `{"name":"A","phone":"+79119568101","status":"sleeping","transport_type":"car"}`, "body status must be one of available, busy, paused", problem.CodeInvalidRequest},
+		{http.MethodPut, "/api/v1/courier", `{"id":"7"}`, "body id must be an integer", problem.CodeInvalidRequest},
+		{http.MethodPut, "/api/v1/courier", `{"id":1.5}`, "body id must be an integer", problem.CodeInvalidRequest},
+		{http.MethodPost, "/api/v1/delivery/unassign", `[]`, "body must be an object", problem.CodeInvalidRequest},
+		{http.MethodPost, "/api/v1/delivery/assign", `{"order_id":"o-1","courier_id":3}`, "body courier_id is not allowed", problem.CodeUnknownField},
+		{http.MethodPost, "/api/v1/delivery/assign", `{"order_id":"` + strings.Repeat("x", 65) + `"}`, "body order_id length must be at most 64", problem.CodeFieldTooLong},
+		{http.MethodPost, "/api/v1/delivery/assign", `{"order_id":"` + strings.Repeat("x", jsonbody.MaxBytes) + `"}`, "request body is too large", problem.CodeBodyTooLarge},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code, "%s %s", tc.method, tc.path)
+		require.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+
+		var p problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+		require.Equal(t, tc.want, p.Detail, "%s %s", tc.method, tc.path)
+		require.Equal(t, tc.code, p.Code, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestValidator_RequiresJSONContentType(t *testing.T) {
+	t.Parallel()
+
+	h := newTestValidator(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/delivery/assign", strings.NewReader(`{"order_id":"o-1"}`))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	var p problem.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	require.Equal(t, problem.CodeUnsupportedMediaType, p.Code)
+}
+
+func TestServeSpec(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	ServeSpec(rec, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Body.String(), "openapi: 3.0.3")
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var raw []byte
+
+// Document is the part of an OpenAPI 3 document the validator understands.
+type Document struct {
+	OpenAPI    string                `yaml:"openapi"`
+	Security   []map[string][]string `yaml:"security"`
+	Paths      map[string]PathItem   `yaml:"paths"`
+	Components Components            `yaml:"components"`
+}
+
+// PathItem maps lower-case HTTP methods to operations.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationId string       `yaml:"operationId"`
+	Parameters  []*Parameter `yaml:"parameters"`
+	RequestBody *RequestBody `yaml:"requestBody"`
+	// Security is nil when the operation inherits the document's
+	// requirements and empty when the operation is public.
+	Security  *[]map[string][]string `yaml:"security"`
+	Roles     []string               `yaml:"x-roles"`
+	Responses map[string]any         `yaml:"responses"`
+}
+
+// Public reports whether the operation can be called without credentials.
+func (o *Operation) Public(doc *Document) bool {
+	if o.Security != nil {
+		return len(*o.Security) == 0
+	}
+	return len(doc.Security) == 0
+}
+
+type Parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+type Schema struct {
+	Ref        string             `yaml:"$ref"`
+	Type       string             `yaml:"type"`
+	Format     string             `yaml:"format"`
+	Nullable   bool               `yaml:"nullable"`
+	Enum       []string           `yaml:"enum"`
+	Required   []string           `yaml:"required"`
+	Properties map[string]*Schema `yaml:"properties"`
+	// AdditionalProperties only supports the boolean form.
+	AdditionalProperties *bool    `yaml:"additionalProperties"`
+	Items                *Schema  `yaml:"items"`
+	MinItems             *int     `yaml:"minItems"`
+	MaxItems             *int     `yaml:"maxItems"`
+	MinLength            *int     `yaml:"minLength"`
+	MaxLength            *int     `yaml:"maxLength"`
+	Minimum              *float64 `yaml:"minimum"`
+	Maximum              *float64 `yaml:"maximum"`
+}
+
+type Components struct {
+	Schemas    map[string]*Schema    `yaml:"schemas"`
+	Parameters map[string]*Parameter `yaml:"parameters"`
+}
+
+// Load parses the embedded specification and resolves parameter references.
+func Load() (*Document, error) {
+	var doc Document
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			for i, p := range op.Parameters {
+				if p.Ref == "" {
+					continue
+				}
+				resolved, ok := doc.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+				if !ok {
+					return nil, fmt.Errorf("openapi: %s %s: unknown parameter %s", method, path, p.Ref)
+				}
+				op.Parameters[i] = resolved
+			}
+			for _, p := range op.Parameters {
+				if err := doc.checkRefs(p.Schema); err != nil {
+					return nil, fmt.Errorf("openapi: %s %s: %w", method, path, err)
+				}
+			}
+			if op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					if err := doc.checkRefs(media.Schema); err != nil {
+						return nil, fmt.Errorf("openapi: %s %s: %w", method, path, err)
+					}
+				}
+			}
+		}
+	}
+
+	return &doc, nil
+}
+
+// Operation returns the operation documented for method on the path template.
+func (d *Document) Operation(method, path string) *Operation {
+	return d.Paths[path][strings.ToLower(method)]
+}
+
+func (d *Document) resolve(s *Schema) (*Schema, error) {
+	for s != nil && s.Ref != "" {
+		next, ok := d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+		if !ok {
+			return nil, fmt.Errorf("unknown schema %s", s.Ref)
+		}
+		s = next
+	}
+	return s, nil
+}
+
+// checkRefs makes sure every schema reachable from s resolves, so that a
+// broken reference fails at startup rather than on the first request.
+func (d *Document) checkRefs(s *Schema) error {
+	s, err := d.resolve(s)
+	if err != nil || s == nil {
+		return err
+	}
+	for _, prop := range s.Properties {
+		if err := d.checkRefs(prop); err != nil {
+			return err
+		}
+	}
+	return d.checkRefs(s.Items)
+}
+
+// ServeSpec serves the embedded specification as is.
+func ServeSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(raw)
+}
@@ -0,0 +1,328 @@
+package openapi
+
+import (
+	"bytes"
+	"course-go-avito-SitnikovArtem06/internal/handlers/jsonbody"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type route struct {
+	segments []string
+	params   int
+	item     PathItem
+}
+
+// Validator checks requests against the operation their path and method
+// resolve to. Requests the document does not describe are passed through,
+// so the router keeps answering 404 and 405 for them.
+type Validator struct {
+	doc    *Document
+	routes []route
+}
+
+func NewValidator(doc *Document) *Validator {
+	v := &Validator{doc: doc}
+	for path, item := range doc.Paths {
+		rt := route{segments: strings.Split(strings.Trim(path, "/"), "/"), item: item}
+		for _, s := range rt.segments {
+			if isParam(s) {
+				rt.params++
+			}
+		}
+		v.routes = append(v.routes, rt)
+	}
+	// Literal segments win over templated ones, as they do in chi.
+	sort.Slice(v.routes, func(i, j int) bool { return v.routes[i].params < v.routes[j].params })
+	return v
+}
+
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, params := v.match(r.Method, r.URL.Path)
+		if op == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := v.validate(w, r, op, params); err != nil {
+			var ve *ValidationError
+			if errors.As(err, &ve) {
+				code := ve.Code
+				if code == "" {
+					code = problem.CodeInvalidRequest
+				}
+				err = problem.Wrap(http.StatusBadRequest, code, err)
+			}
+			problem.Write(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (v *Validator) match(method, path string) (*Operation, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, rt := range v.routes {
+		if len(rt.segments) != len(segments) {
+			continue
+		}
+		params := map[string]string{}
+		matched := true
+		for i, s := range rt.segments {
+			if isParam(s) {
+				params[s[1:len(s)-1]] = segments[i]
+			} else if s != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rt.item[strings.ToLower(method)], params
+		}
+	}
+	return nil, nil
+}
+
+func (v *Validator) validate(w http.ResponseWriter, r *http.Request, op *Operation, pathParams map[string]string) error {
+	query := r.URL.Query()
+	for _, p := range op.Parameters {
+		var (
+			value   string
+			present bool
+		)
+		switch p.In {
+		case "path":
+			value, present = pathParams[p.Name]
+		case "query":
+			present = query.Has(p.Name)
+			value = query.Get(p.Name)
+		case "header":
+			value = r.Header.Get(p.Name)
+			present = value != ""
+		default:
+			continue
+		}
+
+		if !present {
+			if p.Required {
+				return &ValidationError{In: p.In, Field: p.Name, Reason: "is required"}
+			}
+			continue
+		}
+		if err := v.validateParam(p, value); err != nil {
+			return err
+		}
+	}
+
+	if op.RequestBody == nil {
+		return nil
+	}
+	return v.validateBody(w, r, op.RequestBody)
+}
+
+func (v *Validator) validateParam(p *Parameter, value string) error {
+	schema, err := v.doc.resolve(p.Schema)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	var decoded any = value
+	switch schema.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return &ValidationError{In: p.In, Field: p.Name, Reason: "must be an integer"}
+		}
+		decoded = json.Number(value)
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return &ValidationError{In: p.In, Field: p.Name, Reason: "must be a number"}
+		}
+		decoded = json.Number(value)
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return &ValidationError{In: p.In, Field: p.Name, Reason: "must be a boolean"}
+		}
+		decoded = b
+	}
+
+	if reason := v.check(schema, decoded, ""); reason != nil {
+		reason.In = p.In
+		reason.Field = p.Name + reason.Field
+		return reason
+	}
+	return nil
+}
+
+func (v *Validator) validateBody(w http.ResponseWriter, r *http.Request, body *RequestBody) error {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, jsonbody.MaxBytes))
+	_ = r.Body.Close()
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return jsonbody.ErrBodyTooLarge
+		}
+		return &ValidationError{In: "body", Reason: "could not be read"}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if body.Required {
+			return &ValidationError{In: "body", Reason: "is required"}
+		}
+		return nil
+	}
+
+	media, ok := body.Content["application/json"]
+	if !ok {
+		return nil
+	}
+	if err := jsonbody.CheckContentType(r); err != nil {
+		return err
+	}
+	if media.Schema == nil {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return &ValidationError{In: "body", Reason: "is not valid JSON", Code: problem.CodeMalformedJSON}
+	}
+
+	if reason := v.check(media.Schema, value, ""); reason != nil {
+		reason.In = "body"
+		reason.Field = strings.TrimPrefix(reason.Field, ".")
+		return reason
+	}
+	return nil
+}
+
+// check returns the first violation of schema by value, with Field holding
+// the path below the value being checked.
+func (v *Validator) check(s *Schema, value any, at string) *ValidationError {
+	s, err := v.doc.resolve(s)
+	if err != nil {
+		return &ValidationError{Field: at, Reason: err.Error()}
+	}
+	if s == nil {
+		return nil
+	}
+
+	if value == nil {
+		if s.Nullable || s.Type == "" {
+			return nil
+		}
+		return &ValidationError{Field: at, Reason: "must not be null"}
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return &ValidationError{Field: at, Reason: "must be an object"}
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return &ValidationError{Field: at + "." + name, Reason: "is required"}
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return &ValidationError{Field: at + "." + name, Reason: "is not allowed", Code: problem.CodeUnknownField}
+				}
+				continue
+			}
+			if reason := v.check(prop, obj[name], at+"."+name); reason != nil {
+				return reason
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return &ValidationError{Field: at, Reason: "must be an array"}
+		}
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			return &ValidationError{Field: at, Reason: "must have at least " + strconv.Itoa(*s.MinItems) + " items"}
+		}
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			return &ValidationError{Field: at, Reason: "must have at most " + strconv.Itoa(*s.MaxItems) + " items"}
+		}
+		for i, item := range items {
+			if reason := v.check(s.Items, item, at+"["+strconv.Itoa(i)+"]"); reason != nil {
+				return reason
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return &ValidationError{Field: at, Reason: "must be a string"}
+		}
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			return &ValidationError{Field: at, Reason: "length must be at least " + strconv.Itoa(*s.MinLength)}
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			return &ValidationError{Field: at, Reason: "length must be at most " + strconv.Itoa(*s.MaxLength), Code: problem.CodeFieldTooLong}
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				return &ValidationError{Field: at, Reason: "must be an RFC 3339 date-time"}
+			}
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			return &ValidationError{Field: at, Reason: "must be one of " + strings.Join(s.Enum, ", ")}
+		}
+	case "integer", "number":
+		notNumber := &ValidationError{Field: at, Reason: "must be a number"}
+		if s.Type == "integer" {
+			notNumber.Reason = "must be an integer"
+		}
+		num, ok := value.(json.Number)
+		if !ok {
+			return notNumber
+		}
+		f, err := num.Float64()
+		if err != nil {
+			return notNumber
+		}
+		if _, err := num.Int64(); s.Type == "integer" && err != nil {
+			return notNumber
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			return &ValidationError{Field: at, Reason: "must be at least " + strconv.FormatFloat(*s.Minimum, 'f', -1, 64)}
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			return &ValidationError{Field: at, Reason: "must be at most " + strconv.FormatFloat(*s.Maximum, 'f', -1, 64)}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return &ValidationError{Field: at, Reason: "must be a boolean"}
+		}
+	}
+
+	return nil
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
@@ -0,0 +1,107 @@
+package problem
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"course-go-avito-SitnikovArtem06/internal/service/courier_service"
+	"course-go-avito-SitnikovArtem06/internal/service/delivery_service"
+	"errors"
+	"net/http"
+)
+
+// Code identifies an error independently of its message. Codes are part of
+// the API contract: add new ones, never rename existing ones.
+type Code string
+
+const (
+	CodeInvalidRequest       Code = "invalid_request"
+	CodeUnsupportedMediaType Code = "unsupported_media_type"
+	CodeMalformedJSON        Code = "malformed_json"
+	CodeBodyTooLarge         Code = "body_too_large"
+	CodeUnknownField         Code = "unknown_field"
+	CodeFieldTooLong         Code = "field_too_long"
+	CodeInvalidId            Code = "invalid_id"
+	CodeInvalidName          Code = "invalid_name"
+	CodeInvalidOrderId       Code = "invalid_order_id"
+	CodeInvalidStatus        Code = "invalid_status"
+	CodeInvalidPhone         Code = "invalid_phone"
+	CodeInvalidTransport     Code = "invalid_transport"
+	CodeCourierNotFound      Code = "courier_not_found"
+	CodeOrderNotFound        Code = "order_not_found"
+	CodeCourierNotAssigned   Code = "courier_not_assigned"
+	CodeDuplicatePhone       Code = "duplicate_phone"
+	CodeOrderAlreadyAssigned Code = "order_already_assigned"
+	CodeNoAvailableCourier   Code = "no_available_courier"
+	CodeInvalidTransition    Code = "invalid_transition"
+	CodeDeliveryNotFound     Code = "delivery_not_found"
+	CodeInvalidPageToken     Code = "invalid_page_token"
+	CodeBatchAborted         Code = "batch_aborted"
+	CodeDuplicateOrderId     Code = "duplicate_order_id"
+	CodeInvalidPolicy        Code = "invalid_policy"
+	CodeUnauthenticated      Code = "unauthenticated"
+	CodeForbidden            Code = "forbidden"
+	CodeRateLimited          Code = "rate_limited"
+
+	CodeInvalidIdempotencyKey Code = "invalid_idempotency_key"
+	CodeIdempotencyKeyReused  Code = "idempotency_key_reused"
+	CodeIdempotencyInProgress Code = "idempotency_request_in_progress"
+
+	CodeNotFound         Code = "not_found"
+	CodeMethodNotAllowed Code = "method_not_allowed"
+	CodeCanceled         Code = "canceled"
+	CodeTimeout          Code = "timeout"
+	CodeInternal         Code = "internal"
+)
+
+// statusClientClosedRequest is nginx's code for a client that went away
+// before the response was ready.
+const statusClientClosedRequest = 499
+
+// classify is the single place where errors become HTTP statuses and codes.
+// Anything unknown is reported as a 500 without leaking its message.
+func classify(err error) (int, Code, string) {
+	var pe *Error
+	switch {
+	case errors.As(err, &pe):
+		// err may wrap pe with more context, such as the offending field.
+		return pe.Status, pe.Code, err.Error()
+	case errors.Is(err, courier_service.ErrNotFound):
+		return http.StatusNotFound, CodeCourierNotFound, "courier not found"
+	case errors.Is(err, courier_service.ErrInvalidStatus):
+		return http.StatusBadRequest, CodeInvalidStatus, err.Error()
+	case errors.Is(err, courier_service.ErrInvalidPhoneNumber):
+		return http.StatusBadRequest, CodeInvalidPhone, err.Error()
+	case errors.Is(err, courier_service.ErrInvalidTransport):
+		return http.StatusBadRequest, CodeInvalidTransport, err.Error()
+	case errors.Is(err, courier_service.ErrDuplicatePhone):
+		return http.StatusConflict, CodeDuplicatePhone, err.Error()
+	case errors.Is(err, assign_service.ErrNotAvailableCourier):
+		return http.StatusConflict, CodeNoAvailableCourier, err.Error()
+	case errors.Is(err, assign_service.ErrOrderAlreadyAssign):
+		return http.StatusConflict, CodeOrderAlreadyAssigned, err.Error()
+	case errors.Is(err, assign_service.ErrNotAssignedCourier):
+		return http.StatusNotFound, CodeCourierNotAssigned, err.Error()
+	case errors.Is(err, assign_service.ErrNotFoundOrder):
+		return http.StatusNotFound, CodeOrderNotFound, err.Error()
+	case errors.Is(err, assign_service.ErrInvalidTransition):
+		return http.StatusConflict, CodeInvalidTransition, err.Error()
+	case errors.Is(err, assign_service.ErrBatchAborted):
+		return http.StatusConflict, CodeBatchAborted, err.Error()
+	case errors.Is(err, delivery_service.ErrNotFound):
+		return http.StatusNotFound, CodeDeliveryNotFound, err.Error()
+	case errors.Is(err, delivery_service.ErrCourierNotFound):
+		return http.StatusNotFound, CodeCourierNotFound, err.Error()
+	case errors.Is(err, delivery_service.ErrInvalidStatus):
+		return http.StatusBadRequest, CodeInvalidStatus, err.Error()
+	case errors.Is(err, delivery_service.ErrInvalidRange):
+		return http.StatusBadRequest, CodeInvalidRequest, err.Error()
+	case errors.Is(err, delivery_service.ErrInvalidPageToken):
+		return http.StatusBadRequest, CodeInvalidPageToken, err.Error()
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest, CodeCanceled, "request canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, CodeTimeout, "request timed out"
+	default:
+		return http.StatusInternalServerError, CodeInternal, "internal error"
+	}
+}
@@ -0,0 +1,79 @@
+package problem
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details body extended with a stable
+// machine-readable code and the id of the failed request.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance,omitempty"`
+	Code      Code   `json:"code"`
+	RequestId string `json:"request_id,omitempty"`
+}
+
+// Error is an error that already knows how it is reported over HTTP.
+// Handlers declare their own sentinels with New, the same way the gRPC
+// handlers declare status errors.
+type Error struct {
+	Status int
+	Code   Code
+	Err    error
+}
+
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Err: errors.New(message)}
+}
+
+// Wrap attaches a status and code to err, keeping err's message.
+func Wrap(status int, code Code, err error) *Error {
+	return &Error{Status: status, Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Describe returns the code and detail err is reported with, for responses
+// that carry several errors, such as per-order batch results.
+func Describe(err error) (Code, string) {
+	_, code, detail := classify(err)
+	return code, detail
+}
+
+// Write maps err and writes it as application/problem+json.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	status, code, detail := classify(err)
+
+	title := http.StatusText(status)
+	if status == statusClientClosedRequest {
+		title = "Client Closed Request"
+	}
+
+	p := Problem{
+		Type:      "about:blank",
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestId: middleware.RequestIdFromContext(r.Context()),
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,91 @@
+package problem
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/middleware"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"course-go-avito-SitnikovArtem06/internal/service/courier_service"
+	"course-go-avito-SitnikovArtem06/internal/service/delivery_service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeThroughRequestId(t *testing.T, err error, requestId string) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+
+	h := middleware.RequestId(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, err)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/courier/1", nil)
+	if requestId != "" {
+		req.Header.Set(middleware.RequestIdHeader, requestId)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var p Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	return rec, p
+}
+
+func TestWrite_MapsErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		err    error
+		status int
+		code   Code
+	}{
+		{New(http.StatusBadRequest, CodeInvalidId, "invalid ID"), http.StatusBadRequest, CodeInvalidId},
+		{fmt.Errorf("get: %w", courier_service.ErrNotFound), http.StatusNotFound, CodeCourierNotFound},
+		{courier_service.ErrDuplicatePhone, http.StatusConflict, CodeDuplicatePhone},
+		{courier_service.ErrInvalidTransport, http.StatusBadRequest, CodeInvalidTransport},
+		{assign_service.ErrNotAvailableCourier, http.StatusConflict, CodeNoAvailableCourier},
+		{assign_service.ErrNotAssignedCourier, http.StatusNotFound, CodeCourierNotAssigned},
+		{assign_service.ErrBatchAborted, http.StatusConflict, CodeBatchAborted},
+		{delivery_service.ErrNotFound, http.StatusNotFound, CodeDeliveryNotFound},
+		{delivery_service.ErrInvalidPageToken, http.StatusBadRequest, CodeInvalidPageToken},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout, CodeTimeout},
+	}
+
+	for _, tc := range cases {
+		rec, p := writeThroughRequestId(t, tc.err, "")
+
+		require.Equal(t, tc.status, rec.Code, tc.err.Error())
+		require.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+		require.Equal(t, tc.status, p.Status)
+		require.Equal(t, tc.code, p.Code)
+		require.NotEmpty(t, p.Title)
+		require.NotEmpty(t, p.Detail)
+		require.Equal(t, "/courier/1", p.Instance)
+		require.NotEmpty(t, p.RequestId)
+		require.Equal(t, rec.Header().Get(middleware.RequestIdHeader), p.RequestId)
+	}
+}
+
+func TestWrite_HidesUnknownErrors(t *testing.T) {
+	t.Parallel()
+
+	rec, p := writeThroughRequestId(t, errors.New("pq: password authentication failed"), "req-42")
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Equal(t, CodeInternal, p.Code)
+	require.Equal(t, "internal error", p.Detail)
+	require.Equal(t, "req-42", p.RequestId)
+}
+
+func TestWrite_ReplacesMalformedRequestId(t *testing.T) {
+	t.Parallel()
+
+	_, p := writeThroughRequestId(t, courier_service.ErrNotFound, "bad id\n")
+
+	require.NotEqual(t, "bad id\n", p.RequestId)
+	require.Len(t, p.RequestId, 32)
+}
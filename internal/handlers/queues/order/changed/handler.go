@@ -24,8 +24,9 @@ func (h *ChangedHandler) HandleMessage(ctx context.Context, value []byte) error
 	}
 
 	chg := model.ChangedStatus{
-		OrderID: req.OrderID,
-		Status:  req.Status,
+		OrderID:   req.OrderID,
+		Status:    req.Status,
+		CreatedAt: req.CreatedAt,
 	}
 
 	if err := h.changedS.HandleStatusChanged(ctx, chg); err != nil {
@@ -36,3 +37,11 @@ func (h *ChangedHandler) HandleMessage(ctx context.Context, value []byte) error
 	}
 	return nil
 }
+
+func (h *ChangedHandler) MessageKey(value []byte) string {
+	var req OrderStatusChanged
+	if err := json.Unmarshal(value, &req); err != nil {
+		return ""
+	}
+	return req.OrderID
+}
@@ -0,0 +1,90 @@
+package changed
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"course-go-avito-SitnikovArtem06/internal/service/order_changed_service"
+	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// deliveries applies order statuses to deliveries with the same rules as
+// the assign service.
+type deliveries map[string]model.DeliveryStatus
+
+func (d deliveries) change(orderId string, status model.DeliveryStatus) error {
+	current, ok := d[orderId]
+	if !ok {
+		return assign_service.ErrNotFoundOrder
+	}
+	if current == status {
+		return nil
+	}
+	if !current.CanTransitionTo(status) {
+		return assign_service.ErrInvalidTransition
+	}
+	d[orderId] = status
+	return nil
+}
+
+func (d deliveries) AssignOrder(_ context.Context, order *model.Order) (*model.AssignCourier, error) {
+	d[order.Id] = model.DeliveryAssigned
+	return &model.AssignCourier{CourierId: 1, OrderId: order.Id}, nil
+}
+
+func (d deliveries) UnassignCourier(_ context.Context, orderId string) (*model.UnassignCourier, error) {
+	if _, ok := d[orderId]; !ok {
+		return nil, assign_service.ErrNotAssignedCourier
+	}
+	delete(d, orderId)
+	return &model.UnassignCourier{CourierId: 1, OrderId: orderId}, nil
+}
+
+func (d deliveries) CompleteCourier(_ context.Context, orderId string) (*model.CompleteCourier, error) {
+	if err := d.change(orderId, model.DeliveryCompleted); err != nil {
+		return nil, err
+	}
+	return &model.CompleteCourier{CourierId: 1, OrderId: orderId}, nil
+}
+
+func (d deliveries) ChangeDeliveryStatus(_ context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryStatusChange, error) {
+	from := d[orderId]
+	if err := d.change(orderId, status); err != nil {
+		return nil, err
+	}
+	return &model.DeliveryStatusChange{CourierId: 1, OrderId: orderId, From: from, To: status}, nil
+}
+
+// orders answers with the status of the message being handled, as the order
+// service would right after the change.
+type orders map[string]string
+
+func (o orders) GetOrder(_ context.Context, orderId string) (*model.Order, error) {
+	return &model.Order{Id: orderId, Status: o[orderId]}, nil
+}
+
+func TestHandleMessage_CompletedAfterFailedDeliveryIsSkipped(t *testing.T) {
+	t.Parallel()
+
+	d := deliveries{}
+	o := orders{}
+	h := NewChangedHandler(order_changed_service.NewOrderChangedService(order_status_factory.NewOrderStatusFactory(d), o, nil))
+
+	for _, status := range []string{
+		order_status_factory.StatusCreated,
+		order_status_factory.StatusPickedUp,
+		order_status_factory.StatusFailedDelivery,
+		order_status_factory.StatusCompleted,
+	} {
+		o["o1"] = status
+		require.NoError(t, h.HandleMessage(context.Background(), []byte(`{"order_id":"o1","status":"`+status+`"}`)), status)
+	}
+
+	require.Equal(t, model.DeliveryFailed, d["o1"])
+
+	o["o2"] = order_status_factory.StatusCompleted
+	require.NoError(t, h.HandleMessage(context.Background(), []byte(`{"order_id":"o2","status":"completed"}`)), "no delivery")
+}
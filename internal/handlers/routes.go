@@ -1,26 +1,52 @@
 package handlers
 
 import (
-	"course-go-avito-SitnikovArtem06/internal/handlers/assign_handler"
-	"course-go-avito-SitnikovArtem06/internal/handlers/courier_handler"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/handlers/openapi"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"course-go-avito-SitnikovArtem06/internal/health"
+	"course-go-avito-SitnikovArtem06/internal/middleware/authorize"
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func Routes(h *courier_handler.Handler, ha *assign_handler.AssignHandler) chi.Router {
+// Routes mounts every API version under /api and the legacy aliases of v1.
+// Authentication runs before validate, so callers without credentials learn
+// nothing about the request contract. ready backs /readyz and /healthcheck.
+func Routes(authn *auth.Authenticator, validate func(http.Handler) http.Handler, ready *health.Readiness, versions ...Version) chi.Router {
 	r := chi.NewRouter()
-	r.Get("/courier/{id}", h.GetById)
-	r.Get("/couriers", h.GetAll)
-	r.Post("/courier", h.CreateCourier)
-	r.Put("/courier", h.UpdateCourier)
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, ErrRouteNotFound)
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		problem.Write(w, r, ErrMethodNotAllowed)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(authorize.Authenticate(authn), validate)
+
+		for _, v := range versions {
+			r.Route(v.Prefix(), v.Mount)
+		}
+	})
 
-	r.Post("/delivery/assign", ha.AssignCourier)
-	r.Post("/delivery/unassign", ha.UnassignCourier)
+	// Aliases re-enter r under /api/v1, which authenticates and validates
+	// them, so they must stay outside the group above.
+	for _, v := range versions {
+		if v.Name == legacyVersion {
+			mountLegacy(r, v)
+		}
+	}
 
 	r.Get("/ping", Ping)
-	r.Head("/healthcheck", HealthCheck)
+	r.Head("/healthcheck", HealthCheck(ready))
+	r.Get(health.LivePath, health.Live)
+	r.Get(health.ReadyPath, ready.ServeHTTP)
 
 	r.Get("/metrics", promhttp.Handler().ServeHTTP)
+	r.Get("/openapi.yaml", openapi.ServeSpec)
 
 	return r
 }
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/handlers/assign_handler"
+	assign_mocks "course-go-avito-SitnikovArtem06/internal/handlers/assign_handler/mocks"
+	"course-go-avito-SitnikovArtem06/internal/handlers/courier_handler"
+	courier_mocks "course-go-avito-SitnikovArtem06/internal/handlers/courier_handler/mocks"
+	"course-go-avito-SitnikovArtem06/internal/handlers/delivery_handler"
+	delivery_mocks "course-go-avito-SitnikovArtem06/internal/handlers/delivery_handler/mocks"
+	"course-go-avito-SitnikovArtem06/internal/handlers/event_handler"
+	event_mocks "course-go-avito-SitnikovArtem06/internal/handlers/event_handler/mocks"
+	"course-go-avito-SitnikovArtem06/internal/handlers/openapi"
+	"course-go-avito-SitnikovArtem06/internal/health"
+	"course-go-avito-SitnikovArtem06/internal/middleware"
+	"course-go-avito-SitnikovArtem06/internal/middleware/authorize"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"course-go-avito-SitnikovArtem06/internal/service/courier_service"
+	"course-go-avito-SitnikovArtem06/internal/service/delivery_service"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var allRoles = []auth.Role{auth.RoleAdmin, auth.RoleDispatcher, auth.RoleService, auth.RoleCourier}
+
+// sampleRequests hold a valid request per operation; path and body must
+// pass the spec so that only authorization decides the outcome.
+var sampleRequests = map[string]struct {
+	path string
+	body string
+}{
+	"getCourier":      {"/api/v1/courier/1", ""},
+	"listCouriers":    {"/api/v1/couriers", ""},
+	"createCourier":   {"/api/v1/courier", `{"name":"A","phone":"+79119568101","status":"available","transport_type":"car"}`},
+	"updateCourier":   {"/api/v1/courier", `{"id":1,"status":"paused"}`},
+	"assignCourier":   {"/api/v1/delivery/assign", `{"order_id":"o-1"}`},
+	"unassignCourier": {"/api/v1/delivery/unassign", `{"order_id":"o-1"}`},
+	"ping":            {"/ping", ""},
+	"healthcheck":     {"/healthcheck", ""},
+	"livez":           {"/livez", ""},
+	"readyz":          {"/readyz", ""},
+	"metrics":         {"/metrics", ""},
+	"openapi":         {"/openapi.yaml", ""},
+
+	"getDelivery":           {"/api/v1/delivery/o-1", ""},
+	"listCourierDeliveries": {"/api/v1/courier/1/deliveries?status=assigned,completed&limit=10", ""},
+	"listDeliveries":        {"/api/v1/deliveries?courier_id=1&from=2026-10-01T00:00:00Z", ""},
+	"streamEvents":          {"/api/v1/events?status=busy&type=delivery.assigned", ""},
+	"assignCouriersBatch":   {"/api/v1/delivery/assign/batch", `{"order_ids":["o-1","o-2"],"policy":"all_or_nothing"}`},
+}
+
+func newTestRouter(t *testing.T, versions ...Version) (chi.Router, *openapi.Document) {
+	t.Helper()
+
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	cs := courier_mocks.NewMockcourierService(ctrl)
+	cs.EXPECT().GetCourierById(gomock.Any(), gomock.Any()).Return(nil, courier_service.ErrNotFound).AnyTimes()
+	cs.EXPECT().GetAllCouriers(gomock.Any()).Return(nil, nil).AnyTimes()
+	cs.EXPECT().CreateCourier(gomock.Any(), gomock.Any()).Return(nil, courier_service.ErrDuplicatePhone).AnyTimes()
+	cs.EXPECT().UpdateCourier(gomock.Any(), gomock.Any()).Return(courier_service.ErrNotFound).AnyTimes()
+	as := assign_mocks.NewMockassignService(ctrl)
+	as.EXPECT().AssignCourier(gomock.Any(), gomock.Any()).Return(nil, assign_service.ErrNotAvailableCourier).AnyTimes()
+	as.EXPECT().AssignCouriers(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assign_service.ErrBatchAborted).AnyTimes()
+	as.EXPECT().UnassignCourier(gomock.Any(), gomock.Any()).Return(nil, assign_service.ErrNotAssignedCourier).AnyTimes()
+
+	keys := map[[sha256.Size]byte]*auth.Principal{}
+	for _, role := range allRoles {
+		keys[sha256.Sum256([]byte("key-"+role))] = &auth.Principal{Subject: role.String(), Role: role, CourierId: 1}
+	}
+	authn := auth.NewAuthenticator(func() map[[sha256.Size]byte]*auth.Principal { return keys }, nil, "", "")
+
+	ds := delivery_mocks.NewMockdeliveryService(ctrl)
+	ds.EXPECT().GetDelivery(gomock.Any(), gomock.Any()).Return(nil, delivery_service.ErrNotFound).AnyTimes()
+	ds.EXPECT().ListDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.DeliveriesPage{}, nil).AnyTimes()
+	ds.EXPECT().ListCourierDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.DeliveriesPage{}, nil).AnyTimes()
+
+	// A closed channel ends the stream at once, as for a client that fell
+	// behind.
+	es := event_mocks.NewMockeventSource(ctrl)
+	es.EXPECT().Subscribe(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(events.Filter, int64, int) (<-chan events.Event, func()) {
+		ch := make(chan events.Event)
+		close(ch)
+		return ch, func() {}
+	}).AnyTimes()
+
+	passThrough := func(next http.Handler) http.Handler { return next }
+	v1 := V1(courier_handler.NewHandler(cs, courier_handler.V1), assign_handler.NewAssignHandler(as, assign_handler.V1), delivery_handler.NewHandler(ds, delivery_handler.V1), event_handler.NewHandler(es, event_handler.V1), passThrough)
+
+	ready := health.NewReadiness(0, health.Check{Name: "postgres", Run: func(context.Context) error { return nil }})
+
+	r := Routes(authn, openapi.NewValidator(spec).Middleware, ready, append([]Version{v1}, versions...)...)
+	return r, spec
+}
+
+func serve(r http.Handler, method, path, body, apiKey string) int {
+	return serveRecorded(r, method, path, body, apiKey).Code
+}
+
+func serveRecorded(r http.Handler, method, path, body, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if apiKey != "" {
+		req.Header.Set(authorize.APIKeyHeader, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRoutes_MatchOpenAPISpec(t *testing.T) {
+	t.Parallel()
+
+	r, spec := newTestRouter(t)
+
+	routed := map[string]bool{}
+	err := chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if isLegacy(method, route) {
+			route = "/api/" + legacyVersion + route
+		}
+		routed[method+" "+route] = true
+		if spec.Operation(method, route) == nil {
+			t.Errorf("%s %s is routed but missing from the spec", method, route)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			key := strings.ToUpper(method) + " " + path
+			if !routed[key] {
+				t.Errorf("%s is in the spec but not routed", key)
+			}
+			require.NotEmpty(t, op.OperationId, key)
+			require.NotEmpty(t, op.Responses, key)
+		}
+	}
+}
+
+func TestRoutes_EnforceSpecRoles(t *testing.T) {
+	t.Parallel()
+
+	r, spec := newTestRouter(t)
+
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			sample, ok := sampleRequests[op.OperationId]
+			require.True(t, ok, "no sample request for %s", op.OperationId)
+			method = strings.ToUpper(method)
+			key := method + " " + path
+
+			anonymous := serve(r, method, sample.path, sample.body, "")
+			if op.Public(spec) {
+				require.NotEqual(t, http.StatusUnauthorized, anonymous, key)
+				continue
+			}
+			require.Equal(t, http.StatusUnauthorized, anonymous, key)
+			require.NotEmpty(t, op.Roles, "%s needs x-roles", key)
+
+			for _, role := range allRoles {
+				code := serve(r, method, sample.path, sample.body, "key-"+role.String())
+				if slices.Contains(op.Roles, role.String()) {
+					require.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, code, "%s as %s", key, role)
+				} else {
+					require.Equal(t, http.StatusForbidden, code, "%s as %s", key, role)
+				}
+			}
+		}
+	}
+}
+
+func TestRoutes_CouriersOnlyAccessThemselves(t *testing.T) {
+	t.Parallel()
+
+	r, _ := newTestRouter(t)
+
+	require.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, "/api/v1/courier/1", "", "key-courier"))
+	require.Equal(t, http.StatusForbidden, serve(r, http.MethodGet, "/api/v1/courier/2", "", "key-courier"))
+	require.Equal(t, http.StatusForbidden, serve(r, http.MethodPut, "/api/v1/courier", `{"id":2,"status":"paused"}`, "key-courier"))
+	require.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, "/api/v1/courier/2", "", "key-dispatcher"))
+	require.Equal(t, http.StatusUnauthorized, serve(r, http.MethodGet, "/api/v1/courier/1", "", "wrong-key"))
+	require.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/api/v1/courier/1/deliveries", "", "key-courier"))
+	require.Equal(t, http.StatusForbidden, serve(r, http.MethodGet, "/api/v1/courier/2/deliveries", "", "key-courier"))
+}
+
+func isLegacy(method, pattern string) bool {
+	return slices.ContainsFunc(legacyRoutes, func(l struct{ method, pattern string }) bool {
+		return l.method == method && l.pattern == pattern
+	})
+}
+
+func TestRoutes_LegacyAliases(t *testing.T) {
+	t.Parallel()
+
+	r, spec := newTestRouter(t)
+
+	for _, op := range []string{"getCourier", "listCouriers", "createCourier", "updateCourier", "assignCourier", "unassignCourier"} {
+		sample := sampleRequests[op]
+		legacyPath := strings.TrimPrefix(sample.path, "/api/v1")
+		method := ""
+		for path, item := range spec.Paths {
+			for m, o := range item {
+				if o.OperationId == op {
+					method = strings.ToUpper(m)
+					require.True(t, isLegacy(method, strings.TrimPrefix(path, "/api/v1")), op)
+				}
+			}
+		}
+
+		for _, key := range []string{"", "key-admin"} {
+			want := serveRecorded(r, method, sample.path, sample.body, key)
+			got := serveRecorded(r, method, legacyPath, sample.body, key)
+
+			require.Equal(t, want.Code, got.Code, "%s %s", method, legacyPath)
+			require.Equal(t, want.Body.String(), got.Body.String(), "%s %s", method, legacyPath)
+			require.Empty(t, want.Header().Get(middleware.DeprecationHeader))
+			require.Equal(t, "@1792368000", got.Header().Get(middleware.DeprecationHeader))
+			require.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", got.Header().Get(middleware.SunsetHeader))
+			require.Equal(t, "<"+sample.path+`>; rel="successor-version"`, got.Header().Get("Link"))
+		}
+	}
+
+	require.Equal(t, http.StatusNotFound, serve(r, http.MethodPost, "/delivery/complete", `{"order_id":"o-1"}`, "key-admin"))
+	require.Equal(t, http.StatusMethodNotAllowed, serve(r, http.MethodDelete, "/courier/1", "", "key-admin"))
+}
+
+func TestRoutes_RegisterNextVersion(t *testing.T) {
+	t.Parallel()
+
+	v2 := Version{
+		Name: "v2",
+		Mount: func(r chi.Router) {
+			r.With(authorize.Require(auth.AnyRole...)).Get("/couriers", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			})
+		},
+	}
+	r, _ := newTestRouter(t, v2)
+
+	require.Equal(t, http.StatusTeapot, serve(r, http.MethodGet, "/api/v2/couriers", "", "key-courier"))
+	require.Equal(t, http.StatusUnauthorized, serve(r, http.MethodGet, "/api/v2/couriers", "", ""))
+	require.Equal(t, http.StatusForbidden, serve(r, http.MethodGet, "/api/v1/couriers", "", "key-courier"))
+	require.Equal(t, http.StatusForbidden, serve(r, http.MethodGet, "/couriers", "", "key-courier"))
+}
+
+func TestRoutes_Probes(t *testing.T) {
+	t.Parallel()
+
+	r, _ := newTestRouter(t)
+
+	rec := serveRecorded(r, http.MethodGet, "/readyz", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"name":"postgres"`)
+	require.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/livez", "", ""))
+	require.Equal(t, http.StatusNoContent, serve(r, http.MethodHead, "/healthcheck", "", ""))
+
+	down := health.NewReadiness(0, health.Check{Name: "postgres", Run: func(context.Context) error { return context.DeadlineExceeded }})
+	require.Equal(t, http.StatusServiceUnavailable, serve(HealthCheck(down), http.MethodHead, "/healthcheck", "", ""))
+}
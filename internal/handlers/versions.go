@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/handlers/assign_handler"
+	"course-go-avito-SitnikovArtem06/internal/handlers/courier_handler"
+	"course-go-avito-SitnikovArtem06/internal/handlers/delivery_handler"
+	"course-go-avito-SitnikovArtem06/internal/handlers/event_handler"
+	"course-go-avito-SitnikovArtem06/internal/middleware"
+	"course-go-avito-SitnikovArtem06/internal/middleware/authorize"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const APIPrefix = "/api"
+
+// Version is one major version of the HTTP API, mounted under
+// /api/<Name>. The wire format of a version lives in the Mapper its handlers
+// are built with (courier_handler.V1 and so on for /api/v1). A breaking change
+// ships as a new Version whose handlers get new mappers, registered next to the
+// old ones rather than as an edit to them.
+type Version struct {
+	Name  string
+	Mount func(r chi.Router)
+}
+
+func (v Version) Prefix() string {
+	return APIPrefix + "/" + v.Name
+}
+
+// V1 is the first versioned API. It serves the routes that used to live at
+// the root, with the same DTOs; its handlers are built with the V1 mappers.
+func V1(h *courier_handler.Handler, ha *assign_handler.AssignHandler, hd *delivery_handler.Handler, he *event_handler.Handler, idempotent func(http.Handler) http.Handler) Version {
+	return Version{
+		Name: "v1",
+		Mount: func(r chi.Router) {
+			r.With(authorize.Require(auth.AnyRole...)).Get("/courier/{id}", h.GetById)
+			r.With(authorize.Require(auth.StaffService...)).Get("/couriers", h.GetAll)
+			r.With(authorize.Require(auth.Staff...), idempotent).Post("/courier", h.CreateCourier)
+			r.With(authorize.Require(auth.StaffCourier...)).Put("/courier", h.UpdateCourier)
+
+			r.With(authorize.Require(auth.StaffService...), idempotent).Post("/delivery/assign", ha.AssignCourier)
+			r.With(authorize.Require(auth.StaffService...), idempotent).Post("/delivery/assign/batch", ha.AssignCouriersBatch)
+			r.With(authorize.Require(auth.StaffService...), idempotent).Post("/delivery/unassign", ha.UnassignCourier)
+
+			r.With(authorize.Require(auth.AnyRole...)).Get("/delivery/{order_id}", hd.GetDelivery)
+			r.With(authorize.Require(auth.AnyRole...)).Get("/courier/{id}/deliveries", hd.ListCourierDeliveries)
+			r.With(authorize.Require(auth.StaffService...)).Get("/deliveries", hd.ListDeliveries)
+
+			r.With(authorize.Require(auth.StaffService...)).Get("/events", he.Stream)
+		},
+	}
+}
+
+// legacyRoutes are the unversioned routes clients called before /api/v1.
+// They are frozen: new endpoints are only added to a Version.
+var legacyRoutes = []struct{ method, pattern string }{
+	{http.MethodGet, "/courier/{id}"},
+	{http.MethodGet, "/couriers"},
+	{http.MethodPost, "/courier"},
+	{http.MethodPut, "/courier"},
+	{http.MethodPost, "/delivery/assign"},
+	{http.MethodPost, "/delivery/unassign"},
+}
+
+const legacyVersion = "v1"
+
+var (
+	LegacyDeprecated = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	LegacySunset     = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+)
+
+// mountLegacy serves every legacy route as the same path under v1, routed
+// through root again, so aliases get exactly the auth, validation and handlers
+// of v1.
+func mountLegacy(root chi.Router, v1 Version) {
+	root.Group(func(r chi.Router) {
+		r.Use(middleware.Deprecated(LegacyDeprecated, LegacySunset))
+
+		for _, route := range legacyRoutes {
+			r.Method(route.method, route.pattern, alias(root, v1.Prefix()))
+		}
+	})
+}
+
+func alias(root http.Handler, prefix string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := prefix + r.URL.Path
+		w.Header().Set("Link", "<"+path+`>; rel="successor-version"`)
+
+		r2 := r.Clone(context.WithValue(r.Context(), chi.RouteCtxKey, nil))
+		r2.URL.Path = path
+		r2.URL.RawPath = ""
+		root.ServeHTTP(w, r2)
+	}
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MaxMissedBeats is how many intervals a background loop may stay silent
+// before Heartbeat reports it down.
+const MaxMissedBeats = 3
+
+// Ping checks a connection pool, such as *pgxpool.Pool.
+func Ping(name string, p pinger) Check {
+	return Check{Name: name, Run: p.Ping}
+}
+
+// Migrations checks that the schema is at least at version want. A newer
+// schema is fine, since it is what a rolling deploy leaves behind.
+func Migrations(version func(ctx context.Context) (int64, error), want int64) Check {
+	return Check{Name: "migrations", Run: func(ctx context.Context) error {
+		got, err := version(ctx)
+		if err != nil {
+			return err
+		}
+		if got < want {
+			return fmt.Errorf("schema version %d, want %d", got, want)
+		}
+		return nil
+	}}
+}
+
+// Heartbeat checks that a loop ticking every interval made progress within
+// MaxMissedBeats intervals. A zero last tick means the loop has not started.
+func Heartbeat(name string, lastTick func() time.Time, interval time.Duration) Check {
+	return Check{Name: name, Run: func(context.Context) error {
+		last := lastTick()
+		if last.IsZero() {
+			return fmt.Errorf("not started")
+		}
+		if age := time.Since(last); age > MaxMissedBeats*interval {
+			return fmt.Errorf("last tick %s ago", age.Round(time.Second))
+		}
+		return nil
+	}}
+}
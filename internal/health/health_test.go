@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	health "course-go-avito-SitnikovArtem06/internal/health/mocks"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func up(name string) Check {
+	return Check{Name: name, Run: func(context.Context) error { return nil }}
+}
+
+func TestReadiness_AllUp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	db := health.NewMockpinger(ctrl)
+	db.EXPECT().Ping(gomock.Any()).Return(nil)
+
+	rec := httptest.NewRecorder()
+	NewReadiness(0, Ping("postgres", db), up("kafka")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Equal(t, StatusUp, report.Status)
+	require.Len(t, report.Checks, 2)
+	require.Equal(t, "postgres", report.Checks[0].Name)
+	require.Equal(t, StatusUp, report.Checks[0].Status)
+}
+
+func TestReadiness_ReportsEveryFailure(t *testing.T) {
+	t.Parallel()
+
+	hang := Check{Name: "kafka", Run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}}
+	broken := Check{Name: "postgres", Run: func(context.Context) error { return errors.New("connection refused") }}
+
+	rec := httptest.NewRecorder()
+	NewReadiness(10*time.Millisecond, broken, up("migrations"), hang).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var report Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Equal(t, StatusDown, report.Status)
+	require.Equal(t, Result{Name: "postgres", Status: StatusDown, Error: "connection refused", Duration: report.Checks[0].Duration}, report.Checks[0])
+	require.Equal(t, StatusUp, report.Checks[1].Status)
+	require.Equal(t, StatusDown, report.Checks[2].Status)
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks[2].Error)
+}
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+
+	version := func(v int64) func(context.Context) (int64, error) {
+		return func(context.Context) (int64, error) { return v, nil }
+	}
+
+	require.NoError(t, Migrations(version(20260405100000), 20260405100000).Run(context.Background()))
+	require.NoError(t, Migrations(version(20260501000000), 20260405100000).Run(context.Background()))
+	require.EqualError(t, Migrations(version(20260401100000), 20260405100000).Run(context.Background()), "schema version 20260401100000, want 20260405100000")
+}
+
+func TestHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	at := func(tick time.Time) func() time.Time { return func() time.Time { return tick } }
+
+	require.NoError(t, Heartbeat("monitor", at(time.Now().Add(-time.Second)), time.Second).Run(context.Background()))
+	require.EqualError(t, Heartbeat("monitor", at(time.Time{}), time.Second).Run(context.Background()), "not started")
+	require.Error(t, Heartbeat("monitor", at(time.Now().Add(-time.Minute)), time.Second).Run(context.Background()))
+}
+
+func TestLive(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	Live(rec, httptest.NewRequest(http.MethodGet, LivePath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"status": "up", "checks": []}`, rec.Body.String())
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(string) {}
+
+func TestStartServer_FailsOnTakenAddress(t *testing.T) {
+	t.Parallel()
+
+	srv, err := StartServer("127.0.0.1:0", NewReadiness(0), nopLogger{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = srv.Close() })
+
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = taken.Close() })
+
+	_, err = StartServer(taken.Addr().String(), NewReadiness(0), nopLogger{})
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/health/pinger_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/health/pinger_contract.go -destination=internal/health/mocks/pinger_mock.go -package=health
+//
+
+// Package health is a generated GoMock package.
+package health
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockpinger is a mock of pinger interface.
+type Mockpinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockpingerMockRecorder
+	isgomock struct{}
+}
+
+// MockpingerMockRecorder is the mock recorder for Mockpinger.
+type MockpingerMockRecorder struct {
+	mock *Mockpinger
+}
+
+// NewMockpinger creates a new mock instance.
+func NewMockpinger(ctrl *gomock.Controller) *Mockpinger {
+	mock := &Mockpinger{ctrl: ctrl}
+	mock.recorder = &MockpingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpinger) EXPECT() *MockpingerMockRecorder {
+	return m.recorder
+}
+
+// Ping mocks base method.
+func (m *Mockpinger) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockpingerMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*Mockpinger)(nil).Ping), ctx)
+}
@@ -0,0 +1,7 @@
+package health
+
+import "context"
+
+type pinger interface {
+	Ping(ctx context.Context) error
+}
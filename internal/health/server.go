@@ -0,0 +1,39 @@
+package health
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/logger"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// StartServer serves the probes on their own address, for processes without
+// an HTTP API of their own. It binds addr before returning, so a taken port
+// fails the caller instead of leaving the process without probes.
+func StartServer(addr string, ready *Readiness, logger logger.Logger) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("probes listen: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+LivePath, Live)
+	mux.Handle("GET "+ReadyPath, ready)
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		logger.Log(fmt.Sprintf("probes on http://%s%s", ln.Addr(), ReadyPath))
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Log(fmt.Sprintf("probes serve: %v", err))
+		}
+	}()
+
+	return srv, nil
+}
@@ -0,0 +1,61 @@
+package authorize
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+const APIKeyHeader = "X-Api-Key"
+
+// Authenticate resolves the X-Api-Key header or a bearer JWT to a principal
+// and stores it in the request context. Requests without valid credentials
+// are answered with 401.
+func Authenticate(a *auth.Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := principal(a, r)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="service-courier"`)
+				problem.Write(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), p)))
+		})
+	}
+}
+
+func principal(a *auth.Authenticator, r *http.Request) (*auth.Principal, error) {
+	p, err := a.Resolve(r.Header.Get(APIKeyHeader), r.Header.Get("Authorization"))
+	switch {
+	case errors.Is(err, auth.ErrMissingCredentials):
+		return nil, ErrUnauthenticated
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	return p, nil
+}
+
+// Require lets through principals with one of roles and answers 403 to the
+// rest. It must run after Authenticate.
+func Require(roles ...auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, ok := auth.FromContext(r.Context())
+			if !ok {
+				problem.Write(w, r, ErrUnauthenticated)
+				return
+			}
+			if !slices.Contains(roles, p.Role) {
+				problem.Write(w, r, fmt.Errorf("%w: %s", ErrForbidden, p.Role))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
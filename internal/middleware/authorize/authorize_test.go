@@ -0,0 +1,62 @@
+package authorize
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticate_BearerToken(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("s3cret")
+	a := auth.NewAuthenticator(nil, func() []byte { return secret }, "", "")
+
+	var got *auth.Principal
+	h := Authenticate(a)(Require(auth.RoleDispatcher)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.FromContext(r.Context())
+	})))
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		Role: auth.RoleDispatcher,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "anna",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}).SignedString(secret)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "anna", got.Subject)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token+"x")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+}
+
+func TestAuthenticate_Disabled(t *testing.T) {
+	t.Parallel()
+
+	h := Authenticate(auth.Disabled())(Require(auth.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
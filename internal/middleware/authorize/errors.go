@@ -0,0 +1,11 @@
+package authorize
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"net/http"
+)
+
+var (
+	ErrUnauthenticated = problem.New(http.StatusUnauthorized, problem.CodeUnauthenticated, "authentication required")
+	ErrForbidden       = problem.New(http.StatusForbidden, problem.CodeForbidden, "role is not allowed to perform this request")
+)
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// Deprecated marks every response as coming from a deprecated endpoint:
+// Deprecation holds the date it was deprecated (RFC 9745) and Sunset the date
+// it stops working (RFC 8594).
+func Deprecated(since, sunset time.Time) func(http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(DeprecationHeader, deprecation)
+			w.Header().Set(SunsetHeader, sunsetDate)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
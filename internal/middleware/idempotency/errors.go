@@ -0,0 +1,13 @@
+package idempotency
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrInvalidKey        = problem.New(http.StatusBadRequest, problem.CodeInvalidIdempotencyKey, fmt.Sprintf("Idempotency-Key must be 1 to %d printable ASCII characters", MaxKeyLen))
+	ErrKeyReused         = problem.New(http.StatusConflict, problem.CodeIdempotencyKeyReused, "Idempotency-Key was already used with a different request")
+	ErrRequestInProgress = problem.New(http.StatusConflict, problem.CodeIdempotencyInProgress, "a request with this Idempotency-Key is still in progress")
+)
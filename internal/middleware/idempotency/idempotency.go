@@ -0,0 +1,182 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/auth"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"course-go-avito-SitnikovArtem06/internal/logger"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	MaxKeyLen = 255
+
+	DefaultTTL           = 24 * time.Hour
+	DefaultLease         = 30 * time.Second
+	DefaultPurgeInterval = time.Hour
+)
+
+// Middleware makes a mutating endpoint safe to retry. The first response to
+// a key is stored for ttl and replayed for duplicates; reusing a key with
+// another body is a conflict. Keys are scoped to the principal and the
+// endpoint. A request holds its key for lease; if it neither completes nor
+// releases the key by then, as when the process dies, a retry takes it over.
+// A lease shorter than the handler's runtime therefore lets a retry run the
+// handler a second time while the first is still running; only the request
+// that holds the key records its outcome. 5xx responses and panics release
+// the key so that a retry runs again.
+func Middleware(s store, ttl, lease time.Duration, loger logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !validKey(key) {
+				problem.Write(w, r, ErrInvalidKey)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			_ = r.Body.Close()
+			if err != nil {
+				problem.Write(w, r, err)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := scopeOf(r)
+			hash := sha256.Sum256(body)
+			requestHash := hex.EncodeToString(hash[:])
+
+			owner := rand.Text()
+			stored, err := s.Reserve(r.Context(), scope, key, requestHash, owner, lease)
+			if err != nil {
+				problem.Write(w, r, fmt.Errorf("idempotency: %w", err))
+				return
+			}
+			if stored != nil {
+				replay(w, r, stored, requestHash)
+				return
+			}
+
+			// The outcome has to be recorded even if the client is gone.
+			ctx := context.WithoutCancel(r.Context())
+			rec := &recorder{ResponseWriter: w}
+
+			defer func() {
+				if p := recover(); p != nil {
+					if err := s.Release(ctx, scope, key, owner); err != nil {
+						loger.Log(fmt.Sprintf("idempotency: release key=%s scope=%q: %v", key, scope, err))
+					}
+					panic(p)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+
+			if rec.status == 0 || rec.status >= http.StatusInternalServerError {
+				err = s.Release(ctx, scope, key, owner)
+			} else {
+				err = s.Complete(ctx, scope, key, owner, model.IdempotentResponse{
+					RequestHash: requestHash,
+					StatusCode:  rec.status,
+					ContentType: rec.Header().Get("Content-Type"),
+					Body:        rec.body.Bytes(),
+				}, ttl)
+			}
+			if err != nil {
+				loger.Log(fmt.Sprintf("idempotency: store key=%s scope=%q: %v", key, scope, err))
+			}
+		})
+	}
+}
+
+func replay(w http.ResponseWriter, r *http.Request, stored *model.IdempotentResponse, requestHash string) {
+	switch {
+	case stored.RequestHash != requestHash:
+		problem.Write(w, r, ErrKeyReused)
+	case !stored.Done():
+		w.Header().Set("Retry-After", "1")
+		problem.Write(w, r, ErrRequestInProgress)
+	default:
+		if stored.ContentType != "" {
+			w.Header().Set("Content-Type", stored.ContentType)
+		}
+		w.Header().Set(ReplayedHeader, "true")
+		w.WriteHeader(stored.StatusCode)
+		_, _ = w.Write(stored.Body)
+	}
+}
+
+// scopeOf keeps keys of different callers and endpoints apart, so a key
+// reused by another principal is a new request rather than a conflict.
+func scopeOf(r *http.Request) string {
+	subject := ""
+	if p, ok := auth.FromContext(r.Context()); ok {
+		subject = p.Subject
+	}
+	return strconv.Quote(subject) + " " + r.Method + " " + r.URL.Path
+}
+
+func validKey(key string) bool {
+	if len(key) > MaxKeyLen {
+		return false
+	}
+	for _, c := range key {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Purge deletes expired keys every interval until ctx is done.
+func Purge(ctx context.Context, p purger, interval time.Duration, loger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+				loger.Log(fmt.Sprintf("idempotency: purge: %v", err))
+			}
+		}
+	}
+}
+
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,194 @@
+package idempotency
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	mocks "course-go-avito-SitnikovArtem06/internal/middleware/idempotency/mocks"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Log(string) {}
+
+const body = `{"order_id":"o-1"}`
+
+func hashOf(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func do(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/delivery/assign", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(Header, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func handlerWith(status int, calls *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		got, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(got)
+	})
+}
+
+func requireCode(t *testing.T, rec *httptest.ResponseRecorder, status int, code problem.Code) {
+	t.Helper()
+
+	require.Equal(t, status, rec.Code)
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, string(code), resp["code"])
+}
+
+func TestMiddleware_StoresFirstResponse(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	s := mocks.NewMockstore(ctrl)
+	var owner string
+	s.EXPECT().Reserve(gomock.Any(), gomock.Any(), "k-1", hashOf(body), gomock.Any(), DefaultLease).
+		DoAndReturn(func(_ context.Context, _, _, _, o string, _ time.Duration) (*model.IdempotentResponse, error) {
+			owner = o
+			return nil, nil
+		})
+	s.EXPECT().Complete(gomock.Any(), gomock.Any(), "k-1", gomock.Cond(func(o string) bool { return o != "" && o == owner }), model.IdempotentResponse{
+		RequestHash: hashOf(body),
+		StatusCode:  http.StatusOK,
+		ContentType: "application/json",
+		Body:        []byte(body),
+	}, DefaultTTL).Return(nil)
+
+	calls := 0
+	rec := do(Middleware(s, DefaultTTL, DefaultLease, nopLogger{})(handlerWith(http.StatusOK, &calls)), "k-1", body)
+
+	require.Equal(t, 1, calls)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, body, rec.Body.String())
+	require.Empty(t, rec.Header().Get(ReplayedHeader))
+}
+
+func TestMiddleware_ReplaysStoredResponse(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	s := mocks.NewMockstore(ctrl)
+	s.EXPECT().Reserve(gomock.Any(), gomock.Any(), "k-1", hashOf(body), gomock.Any(), DefaultLease).Return(&model.IdempotentResponse{
+		RequestHash: hashOf(body),
+		StatusCode:  http.StatusCreated,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":7}`),
+	}, nil)
+
+	calls := 0
+	rec := do(Middleware(s, DefaultTTL, DefaultLease, nopLogger{})(handlerWith(http.StatusOK, &calls)), "k-1", body)
+
+	require.Zero(t, calls)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, `{"id":7}`, rec.Body.String())
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.Equal(t, "true", rec.Header().Get(ReplayedHeader))
+}
+
+func TestMiddleware_Conflicts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		stored model.IdempotentResponse
+		code   problem.Code
+	}{
+		{
+			name:   "different body",
+			stored: model.IdempotentResponse{RequestHash: hashOf(`{"order_id":"o-2"}`), StatusCode: http.StatusOK},
+			code:   problem.CodeIdempotencyKeyReused,
+		},
+		{
+			name:   "still running",
+			stored: model.IdempotentResponse{RequestHash: hashOf(body)},
+			code:   problem.CodeIdempotencyInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			s := mocks.NewMockstore(ctrl)
+			s.EXPECT().Reserve(gomock.Any(), gomock.Any(), "k-1", hashOf(body), gomock.Any(), DefaultLease).Return(&tt.stored, nil)
+
+			calls := 0
+			rec := do(Middleware(s, DefaultTTL, DefaultLease, nopLogger{})(handlerWith(http.StatusOK, &calls)), "k-1", body)
+
+			require.Zero(t, calls)
+			requireCode(t, rec, http.StatusConflict, tt.code)
+		})
+	}
+}
+
+func TestMiddleware_ReleasesServerErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	s := mocks.NewMockstore(ctrl)
+	s.EXPECT().Reserve(gomock.Any(), gomock.Any(), "k-1", hashOf(body), gomock.Any(), DefaultLease).Return(nil, nil)
+	s.EXPECT().Release(gomock.Any(), gomock.Any(), "k-1", gomock.Any()).Return(nil)
+
+	calls := 0
+	rec := do(Middleware(s, DefaultTTL, DefaultLease, nopLogger{})(handlerWith(http.StatusServiceUnavailable, &calls)), "k-1", body)
+
+	require.Equal(t, 1, calls)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestMiddleware_ReleasesOnPanic(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	s := mocks.NewMockstore(ctrl)
+	s.EXPECT().Reserve(gomock.Any(), gomock.Any(), "k-1", hashOf(body), gomock.Any(), DefaultLease).Return(nil, nil)
+	s.EXPECT().Release(gomock.Any(), gomock.Any(), "k-1", gomock.Any()).Return(nil)
+
+	h := Middleware(s, DefaultTTL, DefaultLease, nopLogger{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	require.PanicsWithValue(t, "boom", func() { do(h, "k-1", body) })
+}
+
+func TestMiddleware_WithoutKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	s := mocks.NewMockstore(ctrl)
+
+	calls := 0
+	h := Middleware(s, DefaultTTL, DefaultLease, nopLogger{})(handlerWith(http.StatusOK, &calls))
+
+	rec := do(h, "", body)
+	require.Equal(t, 1, calls)
+	require.Equal(t, body, rec.Body.String())
+
+	requireCode(t, do(h, "bad\x01key", body), http.StatusBadRequest, problem.CodeInvalidIdempotencyKey)
+	requireCode(t, do(h, strings.Repeat("k", MaxKeyLen+1), body), http.StatusBadRequest, problem.CodeInvalidIdempotencyKey)
+	require.Equal(t, 1, calls)
+}
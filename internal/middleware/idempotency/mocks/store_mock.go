@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/middleware/idempotency/store_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/middleware/idempotency/store_contract.go -destination=internal/middleware/idempotency/mocks/store_mock.go -package=idempotency
+//
+
+// Package idempotency is a generated GoMock package.
+package idempotency
+
+import (
+	context "context"
+	model "course-go-avito-SitnikovArtem06/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockstore is a mock of store interface.
+type Mockstore struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMockRecorder
+	isgomock struct{}
+}
+
+// MockstoreMockRecorder is the mock recorder for Mockstore.
+type MockstoreMockRecorder struct {
+	mock *Mockstore
+}
+
+// NewMockstore creates a new mock instance.
+func NewMockstore(ctrl *gomock.Controller) *Mockstore {
+	mock := &Mockstore{ctrl: ctrl}
+	mock.recorder = &MockstoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstore) EXPECT() *MockstoreMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *Mockstore) Complete(ctx context.Context, scope, key, owner string, resp model.IdempotentResponse, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, scope, key, owner, resp, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockstoreMockRecorder) Complete(ctx, scope, key, owner, resp, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*Mockstore)(nil).Complete), ctx, scope, key, owner, resp, ttl)
+}
+
+// Release mocks base method.
+func (m *Mockstore) Release(ctx context.Context, scope, key, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, scope, key, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockstoreMockRecorder) Release(ctx, scope, key, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*Mockstore)(nil).Release), ctx, scope, key, owner)
+}
+
+// Reserve mocks base method.
+func (m *Mockstore) Reserve(ctx context.Context, scope, key, requestHash, owner string, lease time.Duration) (*model.IdempotentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, scope, key, requestHash, owner, lease)
+	ret0, _ := ret[0].(*model.IdempotentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockstoreMockRecorder) Reserve(ctx, scope, key, requestHash, owner, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*Mockstore)(nil).Reserve), ctx, scope, key, requestHash, owner, lease)
+}
+
+// Mockpurger is a mock of purger interface.
+type Mockpurger struct {
+	ctrl     *gomock.Controller
+	recorder *MockpurgerMockRecorder
+	isgomock struct{}
+}
+
+// MockpurgerMockRecorder is the mock recorder for Mockpurger.
+type MockpurgerMockRecorder struct {
+	mock *Mockpurger
+}
+
+// NewMockpurger creates a new mock instance.
+func NewMockpurger(ctrl *gomock.Controller) *Mockpurger {
+	mock := &Mockpurger{ctrl: ctrl}
+	mock.recorder = &MockpurgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpurger) EXPECT() *MockpurgerMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpired mocks base method.
+func (m *Mockpurger) DeleteExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockpurgerMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*Mockpurger)(nil).DeleteExpired), ctx)
+}
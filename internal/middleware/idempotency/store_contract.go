@@ -0,0 +1,17 @@
+package idempotency
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"time"
+)
+
+type store interface {
+	Reserve(ctx context.Context, scope, key, requestHash, owner string, lease time.Duration) (*model.IdempotentResponse, error)
+	Complete(ctx context.Context, scope, key, owner string, resp model.IdempotentResponse, ttl time.Duration) error
+	Release(ctx context.Context, scope, key, owner string) error
+}
+
+type purger interface {
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -19,6 +19,12 @@ func (w *statusWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, so that
+// streaming handlers can flush through this middleware.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func ObservabilityMiddleware(next http.Handler, logger logger.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -37,11 +43,12 @@ func ObservabilityMiddleware(next http.Handler, logger logger.Logger) http.Handl
 
 		duration := time.Since(start).Seconds()
 
-		logger.Log(fmt.Sprintf(" method=%s path=%s status=%d duration=%fs",
+		logger.Log(fmt.Sprintf(" method=%s path=%s status=%d duration=%fs request_id=%s",
 			r.Method,
 			r.URL.Path,
 			sw.status,
-			duration))
+			duration,
+			RequestIdFromContext(r.Context())))
 
 		observability.HttpRequestsTotal.WithLabelValues(
 			r.Method,
@@ -0,0 +1,10 @@
+package ratelimiter
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
+	"net/http"
+)
+
+var (
+	ErrRateLimited = problem.New(http.StatusTooManyRequests, problem.CodeRateLimited, "rate limit exceeded")
+)
@@ -1,6 +1,7 @@
 package ratelimiter
 
 import (
+	"course-go-avito-SitnikovArtem06/internal/handlers/problem"
 	"course-go-avito-SitnikovArtem06/internal/logger"
 	"course-go-avito-SitnikovArtem06/internal/observability"
 	"fmt"
@@ -14,17 +15,13 @@ func RateLimiterMiddleware(bucket *TokenBucket, logger logger.Logger) func(next
 			if bucket.Allow() {
 				next.ServeHTTP(w, r)
 			} else {
-				w.WriteHeader(http.StatusTooManyRequests)
 				observability.RateLimitExceededTotal.WithLabelValues(
 					r.URL.Path,
 					r.Method,
 				).Inc()
 				logger.Log(fmt.Sprintf("rate limit exceeded method=%s path=%s", r.Method, r.URL.Path))
 
-				_, err := w.Write([]byte("rate limit exceeded"))
-				if err != nil {
-					return
-				}
+				problem.Write(w, r, ErrRateLimited)
 			}
 		})
 	}
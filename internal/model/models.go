@@ -36,20 +36,139 @@ type AssignCourier struct {
 	Deadline  time.Time
 }
 
+// BatchPolicy decides what happens to a batch assignment when some of its
+// orders cannot be assigned.
+type BatchPolicy string
+
+const (
+	// BatchPartial keeps every assignment that succeeded.
+	BatchPartial BatchPolicy = "partial"
+	// BatchAllOrNothing rolls the whole batch back on the first failure.
+	BatchAllOrNothing BatchPolicy = "all_or_nothing"
+)
+
+func (p BatchPolicy) IsValid() bool {
+	return p == BatchPartial || p == BatchAllOrNothing
+}
+
+func (p BatchPolicy) String() string {
+	return string(p)
+}
+
+// AssignResult is the outcome of one order of a batch: Assign on success,
+// Err otherwise.
+type AssignResult struct {
+	OrderId string
+	Assign  *AssignCourier
+	Err     error
+}
+
 type UnassignCourier struct {
 	CourierId int64
 	OrderId   string
 	Status    AssignStatus
 }
 
-type OrdersResponse struct {
-	OrdersId  []string
-	CreatedAt []time.Time
+type CompleteCourier struct {
+	CourierId int64
+	OrderId   string
+}
+
+// DeliveryStatusChange is a delivery moving from one status to another.
+// From equals To when the delivery already had the status.
+type DeliveryStatusChange struct {
+	CourierId int64
+	OrderId   string
+	From      DeliveryStatus
+	To        DeliveryStatus
+}
+
+func (c DeliveryStatusChange) Changed() bool {
+	return c.From != c.To
+}
+
+// ReleasesCourier reports whether the change frees the courier. Only the
+// first status that releases them does: a failed delivery that is later
+// returned freed its courier already.
+func (c DeliveryStatusChange) ReleasesCourier() bool {
+	return !c.From.ReleasesCourier() && c.To.ReleasesCourier()
+}
+
+type Order struct {
+	Id                string
+	UserId            string
+	Number            string
+	CustomerName      string
+	RestaurantId      string
+	Items             []OrderItem
+	TotalPrice        int64
+	Address           OrderAddress
+	Status            string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	EstimatedDelivery time.Time
+}
+
+// OrderCursor is a position in the stable (created_at, id) ordering of orders.
+type OrderCursor struct {
+	CreatedAt time.Time
+	OrderId   string
+}
+
+// Precedes reports whether o comes after the cursor and so is not processed yet.
+func (c OrderCursor) Precedes(o *Order) bool {
+	if !o.CreatedAt.Equal(c.CreatedAt) {
+		return o.CreatedAt.After(c.CreatedAt)
+	}
+	return o.Id > c.OrderId
+}
+
+type OrdersPage struct {
+	Orders        []Order
+	NextPageToken string
+}
+
+type OrderItem struct {
+	Name     string
+	Price    int64
+	Quantity int64
+}
+
+type OrderAddress struct {
+	Street    string `json:"street"`
+	House     string `json:"house"`
+	Apartment string `json:"apartment,omitempty"`
+	Floor     string `json:"floor,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// OrderSnapshot is the part of an order stored with its delivery so it can be
+// shown without calling the order service.
+type OrderSnapshot struct {
+	RestaurantId string
+	Address      OrderAddress
+	TotalPrice   int64
+	ItemsCount   int64
+}
+
+func (o *Order) Snapshot() OrderSnapshot {
+	var count int64
+	for _, item := range o.Items {
+		count += item.Quantity
+	}
+
+	return OrderSnapshot{
+		RestaurantId: o.RestaurantId,
+		Address:      o.Address,
+		TotalPrice:   o.TotalPrice,
+		ItemsCount:   count,
+	}
 }
 
 type ChangedStatus struct {
-	OrderID string
-	Status  string
+	OrderID   string
+	Status    string
+	CreatedAt time.Time
 }
 type CourierStatus string
 
@@ -95,3 +214,106 @@ const (
 func (a AssignStatus) String() string {
 	return string(a)
 }
+
+type DeliveryStatus string
+
+const (
+	DeliveryAssigned   DeliveryStatus = "assigned"
+	DeliveryPickedUp   DeliveryStatus = "picked_up"
+	DeliveryDelivering DeliveryStatus = "delivering"
+	DeliveryCompleted  DeliveryStatus = "completed"
+	DeliveryReturned   DeliveryStatus = "returned"
+	DeliveryFailed     DeliveryStatus = "failed_delivery"
+)
+
+var deliveryTransitions = map[DeliveryStatus][]DeliveryStatus{
+	DeliveryAssigned:   {DeliveryPickedUp, DeliveryDelivering, DeliveryCompleted, DeliveryFailed},
+	DeliveryPickedUp:   {DeliveryDelivering, DeliveryCompleted, DeliveryReturned, DeliveryFailed},
+	DeliveryDelivering: {DeliveryCompleted, DeliveryReturned, DeliveryFailed},
+	DeliveryFailed:     {DeliveryReturned},
+}
+
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryAssigned, DeliveryPickedUp, DeliveryDelivering, DeliveryCompleted, DeliveryReturned, DeliveryFailed:
+		return true
+	}
+	return false
+}
+
+func (s DeliveryStatus) CanTransitionTo(next DeliveryStatus) bool {
+	for _, allowed := range deliveryTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleasesCourier reports whether the courier is free again once the
+// delivery reaches this status.
+func (s DeliveryStatus) ReleasesCourier() bool {
+	return s == DeliveryCompleted || s == DeliveryReturned || s == DeliveryFailed
+}
+
+func (s DeliveryStatus) String() string {
+	return string(s)
+}
+
+// Delivery is a delivery as dispatch sees it, with its courier.
+type Delivery struct {
+	Id          int64
+	OrderId     string
+	CourierId   int64
+	CourierName string
+	Transport   TransportType
+	Status      DeliveryStatus
+	AssignedAt  time.Time
+	Deadline    time.Time
+}
+
+// Active reports whether the delivery still occupies its courier.
+func (d *Delivery) Active() bool {
+	return !d.Status.ReleasesCourier()
+}
+
+// Remaining is the time left until the deadline, or zero once it has passed.
+func (d *Delivery) Remaining(now time.Time) time.Duration {
+	return max(d.Deadline.Sub(now), 0)
+}
+
+// DeliveryCursor is a position in the (assigned_at, id) ordering of
+// deliveries, newest first.
+type DeliveryCursor struct {
+	AssignedAt time.Time
+	Id         int64
+}
+
+// DeliveryFilter selects deliveries. Zero fields do not filter; the
+// assigned_at range is [From, To).
+type DeliveryFilter struct {
+	CourierId int64
+	Statuses  []DeliveryStatus
+	From      time.Time
+	To        time.Time
+	After     *DeliveryCursor
+	Limit     int
+}
+
+type DeliveriesPage struct {
+	Deliveries    []Delivery
+	NextPageToken string
+}
+
+// IdempotentResponse is the stored outcome of a request made with an
+// Idempotency-Key. StatusCode is zero while the first request is running.
+type IdempotentResponse struct {
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+func (r *IdempotentResponse) Done() bool {
+	return r.StatusCode != 0
+}
@@ -15,9 +15,15 @@ type CourierDB struct {
 }
 
 type DeliveryDB struct {
-	Id         int64     `db:"id"`
-	CourierId  int64     `db:"courier_id"`
-	OrderId    string    `db:"order_id"`
-	AssignedAt time.Time `db:"assigned_at"`
-	Deadline   time.Time `db:"deadline"`
+	Id         int64          `db:"id"`
+	CourierId  int64          `db:"courier_id"`
+	OrderId    string         `db:"order_id"`
+	AssignedAt time.Time      `db:"assigned_at"`
+	Deadline   time.Time      `db:"deadline"`
+	Status     DeliveryStatus `db:"status"`
+
+	RestaurantId string        `db:"restaurant_id"`
+	Address      *OrderAddress `db:"address"`
+	TotalPrice   int64         `db:"total_price"`
+	ItemsCount   int64         `db:"items_count"`
 }
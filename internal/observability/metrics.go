@@ -34,6 +34,37 @@ var (
 		},
 		[]string{},
 	)
+
+	GatewayCircuitState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gateway_circuit_state",
+			Help: "Circuit breaker state of a gateway: 0 closed, 1 half-open, 2 open",
+		},
+		[]string{"gateway"},
+	)
+
+	GatewayCircuitTransitionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_circuit_transitions_total",
+			Help: "Total number of circuit breaker state changes",
+		},
+		[]string{"gateway", "state"},
+	)
+
+	OrderCacheRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "order_cache_requests_total",
+			Help: "Total number of order cache lookups by result",
+		},
+		[]string{"result"},
+	)
+
+	OrderCacheInvalidationsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "order_cache_invalidations_total",
+			Help: "Total number of cached orders dropped because a newer event arrived",
+		},
+	)
 )
 
 func Register() {
@@ -41,4 +72,8 @@ func Register() {
 	prometheus.MustRegister(HttpRequestDuration)
 	prometheus.MustRegister(RateLimitExceededTotal)
 	prometheus.MustRegister(GatewayRetriesTotal)
+	prometheus.MustRegister(GatewayCircuitState)
+	prometheus.MustRegister(GatewayCircuitTransitionsTotal)
+	prometheus.MustRegister(OrderCacheRequestsTotal)
+	prometheus.MustRegister(OrderCacheInvalidationsTotal)
 }
@@ -0,0 +1,31 @@
+package observability
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/logger"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func StartMetrics(addr string, logger logger.Logger) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		logger.Log(fmt.Sprintf("metrics on http://%s/metrics", addr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Log(fmt.Sprintf("metrics serve: %v", err))
+		}
+	}()
+
+	return srv
+}
@@ -0,0 +1,1031 @@
+// couriers.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.0
+// source: internal/pb/couriers.proto
+
+// Пакет для работы с курьерами и доставками
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Курьер
+type Courier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                    // available, busy или paused
+	TransportType string                 `protobuf:"bytes,5,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"` // on_foot, scooter или car
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Courier) Reset() {
+	*x = Courier{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Courier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Courier) ProtoMessage() {}
+
+func (x *Courier) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Courier.ProtoReflect.Descriptor instead.
+func (*Courier) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Courier) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Courier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Courier) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Courier) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Courier) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+func (x *Courier) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Courier) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос на создание курьера
+type CreateCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TransportType string                 `protobuf:"bytes,4,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourierRequest) Reset() {
+	*x = CreateCourierRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourierRequest) ProtoMessage() {}
+
+func (x *CreateCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourierRequest.ProtoReflect.Descriptor instead.
+func (*CreateCourierRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateCourierRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCourierRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateCourierRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateCourierRequest) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+// Запрос на получение курьера по id
+type GetCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourierRequest) Reset() {
+	*x = GetCourierRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierRequest) ProtoMessage() {}
+
+func (x *GetCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierRequest.ProtoReflect.Descriptor instead.
+func (*GetCourierRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCourierRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Запрос на получение всех курьеров
+type ListCouriersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouriersRequest) Reset() {
+	*x = ListCouriersRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouriersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersRequest) ProtoMessage() {}
+
+func (x *ListCouriersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersRequest.ProtoReflect.Descriptor instead.
+func (*ListCouriersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{3}
+}
+
+// Ответ со списком курьеров
+type ListCouriersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Couriers      []*Courier             `protobuf:"bytes,1,rep,name=couriers,proto3" json:"couriers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouriersResponse) Reset() {
+	*x = ListCouriersResponse{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouriersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersResponse) ProtoMessage() {}
+
+func (x *ListCouriersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersResponse.ProtoReflect.Descriptor instead.
+func (*ListCouriersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCouriersResponse) GetCouriers() []*Courier {
+	if x != nil {
+		return x.Couriers
+	}
+	return nil
+}
+
+// Запрос на обновление курьера, меняются только переданные поля
+type UpdateCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Phone         *string                `protobuf:"bytes,3,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	Status        *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	TransportType *string                `protobuf:"bytes,5,opt,name=transport_type,json=transportType,proto3,oneof" json:"transport_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCourierRequest) Reset() {
+	*x = UpdateCourierRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCourierRequest) ProtoMessage() {}
+
+func (x *UpdateCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCourierRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCourierRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCourierRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCourierRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCourierRequest) GetPhone() string {
+	if x != nil && x.Phone != nil {
+		return *x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateCourierRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateCourierRequest) GetTransportType() string {
+	if x != nil && x.TransportType != nil {
+		return *x.TransportType
+	}
+	return ""
+}
+
+// Запрос на назначение курьера на заказ
+type AssignCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignCourierRequest) Reset() {
+	*x = AssignCourierRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignCourierRequest) ProtoMessage() {}
+
+func (x *AssignCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignCourierRequest.ProtoReflect.Descriptor instead.
+func (*AssignCourierRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AssignCourierRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Назначение курьера на заказ
+type Assignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourierId     int64                  `protobuf:"varint,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TransportType string                 `protobuf:"bytes,3,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Assignment) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *Assignment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Assignment) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+func (x *Assignment) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+// Запрос на снятие курьера с заказа
+type UnassignCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignCourierRequest) Reset() {
+	*x = UnassignCourierRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignCourierRequest) ProtoMessage() {}
+
+func (x *UnassignCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignCourierRequest.ProtoReflect.Descriptor instead.
+func (*UnassignCourierRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UnassignCourierRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Ответ на снятие курьера с заказа
+type UnassignCourierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourierId     int64                  `protobuf:"varint,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignCourierResponse) Reset() {
+	*x = UnassignCourierResponse{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignCourierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignCourierResponse) ProtoMessage() {}
+
+func (x *UnassignCourierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignCourierResponse.ProtoReflect.Descriptor instead.
+func (*UnassignCourierResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UnassignCourierResponse) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *UnassignCourierResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UnassignCourierResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Запрос на завершение доставки
+type CompleteDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteDeliveryRequest) Reset() {
+	*x = CompleteDeliveryRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteDeliveryRequest) ProtoMessage() {}
+
+func (x *CompleteDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*CompleteDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CompleteDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Ответ на завершение доставки
+type CompleteDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteDeliveryResponse) Reset() {
+	*x = CompleteDeliveryResponse{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteDeliveryResponse) ProtoMessage() {}
+
+func (x *CompleteDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*CompleteDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{11}
+}
+
+// Запрос на подписку на события, пустые поля не фильтруют
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourierId     int64                  `protobuf:"varint,1,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`                     // Типы событий, например delivery.assigned
+	Statuses      []string               `protobuf:"bytes,3,rep,name=statuses,proto3" json:"statuses,omitempty"`               // Статусы курьера после события
+	AfterId       int64                  `protobuf:"varint,4,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"` // Продолжить после события с этим id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchRequest) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+// Изменение курьера или доставки
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	CourierId     int64                  `protobuf:"varint,3,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	CourierStatus string                 `protobuf:"bytes,4,opt,name=courier_status,json=courierStatus,proto3" json:"courier_status,omitempty"`
+	TransportType string                 `protobuf:"bytes,5,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"`
+	OrderId       string                 `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_internal_pb_couriers_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_couriers_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_internal_pb_couriers_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *Event) GetCourierStatus() string {
+	if x != nil {
+		return x.CourierStatus
+	}
+	return ""
+}
+
+func (x *Event) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+func (x *Event) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Event) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *Event) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+var File_internal_pb_couriers_proto protoreflect.FileDescriptor
+
+const file_internal_pb_couriers_proto_rawDesc = "" +
+	"\n" +
+	"\x1ainternal/pb/couriers.proto\x12\vcouriers.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf8\x01\n" +
+	"\aCourier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\x0etransport_type\x18\x05 \x01(\tR\rtransportType\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x7f\n" +
+	"\x14CreateCourierRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12%\n" +
+	"\x0etransport_type\x18\x04 \x01(\tR\rtransportType\"#\n" +
+	"\x11GetCourierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x15\n" +
+	"\x13ListCouriersRequest\"H\n" +
+	"\x14ListCouriersResponse\x120\n" +
+	"\bcouriers\x18\x01 \x03(\v2\x14.couriers.v1.CourierR\bcouriers\"\xd4\x01\n" +
+	"\x14UpdateCourierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
+	"\x05phone\x18\x03 \x01(\tH\x01R\x05phone\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x04 \x01(\tH\x02R\x06status\x88\x01\x01\x12*\n" +
+	"\x0etransport_type\x18\x05 \x01(\tH\x03R\rtransportType\x88\x01\x01B\a\n" +
+	"\x05_nameB\b\n" +
+	"\x06_phoneB\t\n" +
+	"\a_statusB\x11\n" +
+	"\x0f_transport_type\"1\n" +
+	"\x14AssignCourierRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xa5\x01\n" +
+	"\n" +
+	"Assignment\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x01 \x01(\x03R\tcourierId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12%\n" +
+	"\x0etransport_type\x18\x03 \x01(\tR\rtransportType\x126\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\"3\n" +
+	"\x16UnassignCourierRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"k\n" +
+	"\x17UnassignCourierResponse\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x01 \x01(\x03R\tcourierId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"4\n" +
+	"\x17CompleteDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x1a\n" +
+	"\x18CompleteDeliveryResponse\"z\n" +
+	"\fWatchRequest\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x01 \x01(\x03R\tcourierId\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x1a\n" +
+	"\bstatuses\x18\x03 \x03(\tR\bstatuses\x12\x19\n" +
+	"\bafter_id\x18\x04 \x01(\x03R\aafterId\"\x97\x02\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x03 \x01(\x03R\tcourierId\x12%\n" +
+	"\x0ecourier_status\x18\x04 \x01(\tR\rcourierStatus\x12%\n" +
+	"\x0etransport_type\x18\x05 \x01(\tR\rtransportType\x12\x19\n" +
+	"\border_id\x18\x06 \x01(\tR\aorderId\x126\n" +
+	"\bdeadline\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12*\n" +
+	"\x02at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x02at2\x84\x05\n" +
+	"\x0fCouriersService\x12H\n" +
+	"\rCreateCourier\x12!.couriers.v1.CreateCourierRequest\x1a\x14.couriers.v1.Courier\x12B\n" +
+	"\n" +
+	"GetCourier\x12\x1e.couriers.v1.GetCourierRequest\x1a\x14.couriers.v1.Courier\x12S\n" +
+	"\fListCouriers\x12 .couriers.v1.ListCouriersRequest\x1a!.couriers.v1.ListCouriersResponse\x12H\n" +
+	"\rUpdateCourier\x12!.couriers.v1.UpdateCourierRequest\x1a\x14.couriers.v1.Courier\x12K\n" +
+	"\rAssignCourier\x12!.couriers.v1.AssignCourierRequest\x1a\x17.couriers.v1.Assignment\x12\\\n" +
+	"\x0fUnassignCourier\x12#.couriers.v1.UnassignCourierRequest\x1a$.couriers.v1.UnassignCourierResponse\x12_\n" +
+	"\x10CompleteDelivery\x12$.couriers.v1.CompleteDeliveryRequest\x1a%.couriers.v1.CompleteDeliveryResponse\x128\n" +
+	"\x05Watch\x12\x19.couriers.v1.WatchRequest\x1a\x12.couriers.v1.Event0\x01B\rZ\vinternal/pbb\x06proto3"
+
+var (
+	file_internal_pb_couriers_proto_rawDescOnce sync.Once
+	file_internal_pb_couriers_proto_rawDescData []byte
+)
+
+func file_internal_pb_couriers_proto_rawDescGZIP() []byte {
+	file_internal_pb_couriers_proto_rawDescOnce.Do(func() {
+		file_internal_pb_couriers_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_pb_couriers_proto_rawDesc), len(file_internal_pb_couriers_proto_rawDesc)))
+	})
+	return file_internal_pb_couriers_proto_rawDescData
+}
+
+var file_internal_pb_couriers_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_internal_pb_couriers_proto_goTypes = []any{
+	(*Courier)(nil),                  // 0: couriers.v1.Courier
+	(*CreateCourierRequest)(nil),     // 1: couriers.v1.CreateCourierRequest
+	(*GetCourierRequest)(nil),        // 2: couriers.v1.GetCourierRequest
+	(*ListCouriersRequest)(nil),      // 3: couriers.v1.ListCouriersRequest
+	(*ListCouriersResponse)(nil),     // 4: couriers.v1.ListCouriersResponse
+	(*UpdateCourierRequest)(nil),     // 5: couriers.v1.UpdateCourierRequest
+	(*AssignCourierRequest)(nil),     // 6: couriers.v1.AssignCourierRequest
+	(*Assignment)(nil),               // 7: couriers.v1.Assignment
+	(*UnassignCourierRequest)(nil),   // 8: couriers.v1.UnassignCourierRequest
+	(*UnassignCourierResponse)(nil),  // 9: couriers.v1.UnassignCourierResponse
+	(*CompleteDeliveryRequest)(nil),  // 10: couriers.v1.CompleteDeliveryRequest
+	(*CompleteDeliveryResponse)(nil), // 11: couriers.v1.CompleteDeliveryResponse
+	(*WatchRequest)(nil),             // 12: couriers.v1.WatchRequest
+	(*Event)(nil),                    // 13: couriers.v1.Event
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+}
+var file_internal_pb_couriers_proto_depIdxs = []int32{
+	14, // 0: couriers.v1.Courier.created_at:type_name -> google.protobuf.Timestamp
+	14, // 1: couriers.v1.Courier.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: couriers.v1.ListCouriersResponse.couriers:type_name -> couriers.v1.Courier
+	14, // 3: couriers.v1.Assignment.deadline:type_name -> google.protobuf.Timestamp
+	14, // 4: couriers.v1.Event.deadline:type_name -> google.protobuf.Timestamp
+	14, // 5: couriers.v1.Event.at:type_name -> google.protobuf.Timestamp
+	1,  // 6: couriers.v1.CouriersService.CreateCourier:input_type -> couriers.v1.CreateCourierRequest
+	2,  // 7: couriers.v1.CouriersService.GetCourier:input_type -> couriers.v1.GetCourierRequest
+	3,  // 8: couriers.v1.CouriersService.ListCouriers:input_type -> couriers.v1.ListCouriersRequest
+	5,  // 9: couriers.v1.CouriersService.UpdateCourier:input_type -> couriers.v1.UpdateCourierRequest
+	6,  // 10: couriers.v1.CouriersService.AssignCourier:input_type -> couriers.v1.AssignCourierRequest
+	8,  // 11: couriers.v1.CouriersService.UnassignCourier:input_type -> couriers.v1.UnassignCourierRequest
+	10, // 12: couriers.v1.CouriersService.CompleteDelivery:input_type -> couriers.v1.CompleteDeliveryRequest
+	12, // 13: couriers.v1.CouriersService.Watch:input_type -> couriers.v1.WatchRequest
+	0,  // 14: couriers.v1.CouriersService.CreateCourier:output_type -> couriers.v1.Courier
+	0,  // 15: couriers.v1.CouriersService.GetCourier:output_type -> couriers.v1.Courier
+	4,  // 16: couriers.v1.CouriersService.ListCouriers:output_type -> couriers.v1.ListCouriersResponse
+	0,  // 17: couriers.v1.CouriersService.UpdateCourier:output_type -> couriers.v1.Courier
+	7,  // 18: couriers.v1.CouriersService.AssignCourier:output_type -> couriers.v1.Assignment
+	9,  // 19: couriers.v1.CouriersService.UnassignCourier:output_type -> couriers.v1.UnassignCourierResponse
+	11, // 20: couriers.v1.CouriersService.CompleteDelivery:output_type -> couriers.v1.CompleteDeliveryResponse
+	13, // 21: couriers.v1.CouriersService.Watch:output_type -> couriers.v1.Event
+	14, // [14:22] is the sub-list for method output_type
+	6,  // [6:14] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_internal_pb_couriers_proto_init() }
+func file_internal_pb_couriers_proto_init() {
+	if File_internal_pb_couriers_proto != nil {
+		return
+	}
+	file_internal_pb_couriers_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_pb_couriers_proto_rawDesc), len(file_internal_pb_couriers_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_pb_couriers_proto_goTypes,
+		DependencyIndexes: file_internal_pb_couriers_proto_depIdxs,
+		MessageInfos:      file_internal_pb_couriers_proto_msgTypes,
+	}.Build()
+	File_internal_pb_couriers_proto = out.File
+	file_internal_pb_couriers_proto_goTypes = nil
+	file_internal_pb_couriers_proto_depIdxs = nil
+}
@@ -0,0 +1,69 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+type FileSource struct {
+	path string
+	rng  Range
+}
+
+func NewFileSource(path string, rng Range) *FileSource {
+	return &FileSource{path: path, rng: rng}
+}
+
+func (s *FileSource) Read(ctx context.Context, fn func(rec Record) error) error {
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("open dump: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var line int64
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line++
+		value := scanner.Bytes()
+		if len(value) == 0 {
+			continue
+		}
+
+		var meta struct {
+			CreatedAt time.Time `json:"created_at"`
+		}
+		_ = json.Unmarshal(value, &meta)
+
+		rec := Record{
+			Offset:    line,
+			Timestamp: meta.CreatedAt,
+			Value:     append([]byte(nil), value...),
+		}
+
+		if !s.rng.contains(rec) {
+			continue
+		}
+
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read dump: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package replay
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+)
+
+type orderGateway interface {
+	GetOrder(ctx context.Context, orderID string) (*model.Order, error)
+}
+
+type orderSnapshots interface {
+	SaveOrderSnapshot(ctx context.Context, order *model.Order) error
+}
@@ -0,0 +1,168 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// DefaultIdleTimeout ends a partition that delivers nothing for this long
+// before the end offset. The offsets left over usually hold no data, such as
+// transaction markers and aborted records, which are never delivered; they
+// are reported as gaps in case the broker stalled instead.
+const DefaultIdleTimeout = 10 * time.Second
+
+type KafkaSource struct {
+	brokers []string
+	topic   string
+	cfg     *sarama.Config
+	rng     Range
+	idle    time.Duration
+	gaps    []Gap
+}
+
+func NewKafkaSource(brokers []string, topic string, cfg *sarama.Config, rng Range) *KafkaSource {
+	return &KafkaSource{brokers: brokers, topic: topic, cfg: cfg, rng: rng, idle: DefaultIdleTimeout}
+}
+
+// Gaps returns the offsets of partitions that went idle before their end
+// offset during the last Read.
+func (s *KafkaSource) Gaps() []Gap {
+	return s.gaps
+}
+
+func (s *KafkaSource) Read(ctx context.Context, fn func(rec Record) error) error {
+
+	s.gaps = nil
+
+	client, err := sarama.NewClient(s.brokers, s.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	defer client.Close()
+
+	partitions, err := client.Partitions(s.topic)
+	if err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	for _, p := range partitions {
+		if s.rng.Partition != AllPartitions && s.rng.Partition != p {
+			continue
+		}
+		if err := s.readPartition(ctx, client, consumer, p, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *KafkaSource) readPartition(ctx context.Context, client sarama.Client, consumer sarama.Consumer, partition int32, fn func(rec Record) error) error {
+
+	oldest, err := client.GetOffset(s.topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return fmt.Errorf("partition %d: oldest offset: %w", partition, err)
+	}
+	newest, err := client.GetOffset(s.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return fmt.Errorf("partition %d: newest offset: %w", partition, err)
+	}
+
+	start := oldest
+	if s.rng.FromOffset > start {
+		start = s.rng.FromOffset
+	}
+	if !s.rng.FromTime.IsZero() {
+		byTime, err := client.GetOffset(s.topic, partition, s.rng.FromTime.UnixMilli())
+		if err != nil {
+			return fmt.Errorf("partition %d: offset for time: %w", partition, err)
+		}
+		if byTime == sarama.OffsetNewest || byTime < 0 {
+			return nil
+		}
+		if byTime > start {
+			start = byTime
+		}
+	}
+
+	end := newest
+	if s.rng.ToOffset >= 0 && s.rng.ToOffset+1 < end {
+		end = s.rng.ToOffset + 1
+	}
+
+	if start >= end {
+		return nil
+	}
+
+	pc, err := consumer.ConsumePartition(s.topic, partition, start)
+	if err != nil {
+		return fmt.Errorf("partition %d: consume: %w", partition, err)
+	}
+	defer pc.Close()
+
+	return s.consume(ctx, pc, partition, start, end, fn)
+}
+
+func (s *KafkaSource) consume(ctx context.Context, pc sarama.PartitionConsumer, partition int32, start, end int64, fn func(rec Record) error) error {
+
+	idle := time.NewTimer(s.idle)
+	defer idle.Stop()
+
+	next := start
+
+	for {
+		select {
+		case msg := <-pc.Messages():
+			if msg == nil {
+				continue
+			}
+			// Only waiting for the broker counts as idle, not the time
+			// spent handling a record.
+			idle.Stop()
+			next = msg.Offset + 1
+
+			rec := Record{
+				Partition: msg.Partition,
+				Offset:    msg.Offset,
+				Timestamp: msg.Timestamp,
+				Value:     msg.Value,
+			}
+
+			if !s.rng.ToTime.IsZero() && rec.Timestamp.After(s.rng.ToTime) {
+				return nil
+			}
+
+			if s.rng.contains(rec) {
+				if err := fn(rec); err != nil {
+					return err
+				}
+			}
+
+			if msg.Offset+1 >= end {
+				return nil
+			}
+			idle.Reset(s.idle)
+
+		case err := <-pc.Errors():
+			if err != nil {
+				return err
+			}
+
+		case <-idle.C:
+			s.gaps = append(s.gaps, Gap{Partition: partition, From: next, To: end - 1})
+			return nil
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package replay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaSource_Consume_StopsAfterControlRecords(t *testing.T) {
+	t.Parallel()
+
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.ExpectConsumePartition("orders", 0, 0).
+		YieldMessage(&sarama.ConsumerMessage{Partition: 0, Offset: 0, Value: []byte("a")}).
+		YieldMessage(&sarama.ConsumerMessage{Partition: 0, Offset: 1, Value: []byte("b")})
+
+	pc, err := consumer.ConsumePartition("orders", 0, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pc.Close() })
+
+	s := &KafkaSource{rng: allRange(), idle: 50 * time.Millisecond}
+
+	// Offsets 2 and 3 are a commit marker and an aborted record, which the
+	// broker never hands to the consumer.
+	var got []int64
+	err = s.consume(context.Background(), pc, 0, 0, 4, func(rec Record) error {
+		got = append(got, rec.Offset)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 1}, got)
+	require.Equal(t, []Gap{{Partition: 0, From: 2, To: 3}}, s.Gaps())
+}
+
+func TestKafkaSource_Consume_SlowHandlerIsNotIdle(t *testing.T) {
+	t.Parallel()
+
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.ExpectConsumePartition("orders", 0, 0).
+		YieldMessage(&sarama.ConsumerMessage{Partition: 0, Offset: 0, Value: []byte("a")}).
+		YieldMessage(&sarama.ConsumerMessage{Partition: 0, Offset: 1, Value: []byte("b")}).
+		YieldMessage(&sarama.ConsumerMessage{Partition: 0, Offset: 2, Value: []byte("c")})
+
+	pc, err := consumer.ConsumePartition("orders", 0, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pc.Close() })
+
+	s := &KafkaSource{rng: allRange(), idle: 20 * time.Millisecond}
+
+	var got []int64
+	err = s.consume(context.Background(), pc, 0, 0, 3, func(rec Record) error {
+		got = append(got, rec.Offset)
+		time.Sleep(3 * s.idle)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 1, 2}, got)
+	require.Empty(t, s.Gaps())
+}
@@ -0,0 +1,169 @@
+package replay
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/handlers/queues/order/changed"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/order_changed_service"
+	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+type Outcome string
+
+const (
+	OutcomeApplied  Outcome = "applied"
+	OutcomeDryRun   Outcome = "would_apply"
+	OutcomeMismatch Outcome = "skipped_mismatch"
+	OutcomeUnknown  Outcome = "unknown_status"
+	OutcomeFailed   Outcome = "failed"
+)
+
+const MaxReportedFailures = 50
+
+type Failure struct {
+	Partition int32
+	Offset    int64
+	OrderID   string
+	Err       string
+}
+
+type Summary struct {
+	Total    int
+	Outcomes map[Outcome]int
+	Failures []Failure
+	Gaps     []Gap
+}
+
+func (s *Summary) Write(w io.Writer) {
+	fmt.Fprintf(w, "replayed %d events\n", s.Total)
+
+	keys := make([]string, 0, len(s.Outcomes))
+	for k := range s.Outcomes {
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %-18s %d\n", k, s.Outcomes[Outcome(k)])
+	}
+
+	for _, f := range s.Failures {
+		fmt.Fprintf(w, "  failed partition=%d offset=%d order_id=%s: %s\n", f.Partition, f.Offset, f.OrderID, f.Err)
+	}
+	if n := s.Outcomes[OutcomeFailed]; n > len(s.Failures) {
+		fmt.Fprintf(w, "  ... %d more failures\n", n-len(s.Failures))
+	}
+
+	for _, g := range s.Gaps {
+		fmt.Fprintf(w, "  not delivered partition=%d offsets=%d-%d\n", g.Partition, g.From, g.To)
+	}
+}
+
+// Replayer feeds recorded events through the same handler and service the
+// worker uses and classifies what happened to each of them.
+type Replayer struct {
+	handler *changed.ChangedHandler
+	last    Outcome
+}
+
+// NewReplayer builds a replayer. snapshots may be nil and is never used in
+// dry-run mode.
+func NewReplayer(factory order_status_factory.OrderStatusFactory, gateway orderGateway, snapshots orderSnapshots, dryRun bool) *Replayer {
+	r := &Replayer{}
+
+	f := &trackingFactory{next: factory, r: r, dryRun: dryRun}
+
+	if dryRun {
+		snapshots = nil
+	}
+
+	svc := &trackingService{next: order_changed_service.NewOrderChangedService(f, gateway, snapshots), r: r}
+	r.handler = changed.NewChangedHandler(svc)
+
+	return r
+}
+
+func (r *Replayer) Run(ctx context.Context, src Source) (*Summary, error) {
+	summary := &Summary{Outcomes: make(map[Outcome]int)}
+
+	err := src.Read(ctx, func(rec Record) error {
+		summary.Total++
+		r.last = OutcomeApplied
+
+		if err := r.handler.HandleMessage(ctx, rec.Value); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			summary.Outcomes[OutcomeFailed]++
+			if len(summary.Failures) < MaxReportedFailures {
+				summary.Failures = append(summary.Failures, Failure{
+					Partition: rec.Partition,
+					Offset:    rec.Offset,
+					OrderID:   orderID(rec.Value),
+					Err:       err.Error(),
+				})
+			}
+			return nil
+		}
+
+		summary.Outcomes[r.last]++
+		return nil
+	})
+
+	if g, ok := src.(gapSource); ok {
+		summary.Gaps = g.Gaps()
+	}
+
+	return summary, err
+}
+
+func orderID(value []byte) string {
+	var req changed.OrderStatusChanged
+	_ = json.Unmarshal(value, &req)
+	return req.OrderID
+}
+
+type trackingService struct {
+	next *order_changed_service.OrderChangedService
+	r    *Replayer
+}
+
+func (s *trackingService) HandleStatusChanged(ctx context.Context, req model.ChangedStatus) error {
+	err := s.next.HandleStatusChanged(ctx, req)
+	if errors.Is(err, order_changed_service.ErrMismatchStatus) {
+		s.r.last = OutcomeMismatch
+	}
+	return err
+}
+
+type trackingFactory struct {
+	next   order_status_factory.OrderStatusFactory
+	r      *Replayer
+	dryRun bool
+}
+
+func (f *trackingFactory) Get(status string) order_status_factory.OrderStatus {
+	st := f.next.Get(status)
+	if st == nil {
+		f.r.last = OutcomeUnknown
+		return nil
+	}
+	return &trackingStatus{next: st, f: f}
+}
+
+type trackingStatus struct {
+	next order_status_factory.OrderStatus
+	f    *trackingFactory
+}
+
+func (s *trackingStatus) Do(ctx context.Context, order *model.Order) error {
+	if s.f.dryRun {
+		s.f.r.last = OutcomeDryRun
+		return nil
+	}
+	return s.next.Do(ctx, order)
+}
@@ -0,0 +1,156 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory"
+	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory/mocks"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeGateway struct {
+	statuses map[string]string
+}
+
+func (g fakeGateway) GetOrder(ctx context.Context, orderID string) (*model.Order, error) {
+	status, ok := g.statuses[orderID]
+	if !ok {
+		return nil, errors.New("order gateway: status=404")
+	}
+	return &model.Order{Id: orderID, Status: status}, nil
+}
+
+func writeDump(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dump.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
+
+func allRange() Range {
+	return Range{Partition: AllPartitions, FromOffset: -1, ToOffset: -1}
+}
+
+func TestReplayer_Run_ClassifiesOutcomes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := mocks.NewMockassign(ctrl)
+	a.EXPECT().AssignOrder(gomock.Any(), &model.Order{Id: "o1", Status: "created"}).Return(&model.AssignCourier{OrderId: "o1"}, nil)
+
+	gw := fakeGateway{statuses: map[string]string{
+		"o1": "created",
+		"o2": "completed",
+		"o3": "weird",
+	}}
+
+	path := writeDump(t,
+		`{"order_id":"o1","status":"created"}`,
+		`{"order_id":"o2","status":"created"}`,
+		`{"order_id":"o3","status":"weird"}`,
+		`{"order_id":"o4","status":"created"}`,
+		`not json`,
+	)
+
+	r := NewReplayer(order_status_factory.NewOrderStatusFactory(a), gw, nil, false)
+
+	summary, err := r.Run(context.Background(), NewFileSource(path, allRange()))
+	require.NoError(t, err)
+
+	require.Equal(t, 5, summary.Total)
+	require.Equal(t, 1, summary.Outcomes[OutcomeApplied])
+	require.Equal(t, 1, summary.Outcomes[OutcomeMismatch])
+	require.Equal(t, 1, summary.Outcomes[OutcomeUnknown])
+	require.Equal(t, 2, summary.Outcomes[OutcomeFailed])
+	require.Len(t, summary.Failures, 2)
+	require.Equal(t, "o4", summary.Failures[0].OrderID)
+
+	var out bytes.Buffer
+	summary.Write(&out)
+	require.Contains(t, out.String(), "replayed 5 events")
+}
+
+func TestReplayer_Run_DryRunDoesNotApply(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := mocks.NewMockassign(ctrl)
+
+	gw := fakeGateway{statuses: map[string]string{"o1": "created", "o2": "cancelled"}}
+
+	path := writeDump(t,
+		`{"order_id":"o1","status":"created"}`,
+		`{"order_id":"o2","status":"cancelled"}`,
+	)
+
+	r := NewReplayer(order_status_factory.NewOrderStatusFactory(a), gw, nil, true)
+
+	summary, err := r.Run(context.Background(), NewFileSource(path, allRange()))
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.Outcomes[OutcomeDryRun])
+}
+
+// gappedSource is a file source whose partition ended early.
+type gappedSource struct {
+	*FileSource
+	gaps []Gap
+}
+
+func (s gappedSource) Gaps() []Gap {
+	return s.gaps
+}
+
+func TestReplayer_Run_ReportsGaps(t *testing.T) {
+	t.Parallel()
+
+	gw := fakeGateway{statuses: map[string]string{"o1": "cancelled"}}
+	path := writeDump(t, `{"order_id":"o1","status":"cancelled"}`)
+
+	r := NewReplayer(order_status_factory.NewOrderStatusFactory(nil), gw, nil, true)
+
+	src := gappedSource{FileSource: NewFileSource(path, allRange()), gaps: []Gap{{Partition: 2, From: 7, To: 9}}}
+	summary, err := r.Run(context.Background(), src)
+	require.NoError(t, err)
+	require.Equal(t, src.gaps, summary.Gaps)
+
+	var out bytes.Buffer
+	summary.Write(&out)
+	require.Contains(t, out.String(), "not delivered partition=2 offsets=7-9")
+}
+
+func TestFileSource_Read_AppliesRange(t *testing.T) {
+	t.Parallel()
+
+	path := writeDump(t,
+		`{"order_id":"o1","status":"created","created_at":"2025-12-15T12:00:00Z"}`,
+		`{"order_id":"o2","status":"created","created_at":"2025-12-15T12:10:00Z"}`,
+		``,
+		`{"order_id":"o3","status":"created","created_at":"2025-12-15T12:20:00Z"}`,
+		`{"order_id":"o4","status":"created","created_at":"2025-12-15T12:30:00Z"}`,
+	)
+
+	rng := allRange()
+	rng.FromOffset = 2
+	rng.ToTime = time.Date(2025, 12, 15, 12, 25, 0, 0, time.UTC)
+
+	var got []string
+	err := NewFileSource(path, rng).Read(context.Background(), func(rec Record) error {
+		got = append(got, orderID(rec.Value))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"o2", "o3"}, got)
+}
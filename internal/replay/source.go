@@ -0,0 +1,56 @@
+package replay
+
+import (
+	"context"
+	"time"
+)
+
+type Record struct {
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+	Value     []byte
+}
+
+type Source interface {
+	Read(ctx context.Context, fn func(rec Record) error) error
+}
+
+// Gap is a run of offsets a source stopped waiting for before the end of
+// its range.
+type Gap struct {
+	Partition int32
+	From      int64
+	To        int64
+}
+
+// gapSource is implemented by sources that can end a partition early.
+type gapSource interface {
+	Gaps() []Gap
+}
+
+type Range struct {
+	Partition  int32
+	FromOffset int64
+	ToOffset   int64
+	FromTime   time.Time
+	ToTime     time.Time
+}
+
+const AllPartitions int32 = -1
+
+func (r Range) contains(rec Record) bool {
+	if r.FromOffset >= 0 && rec.Offset < r.FromOffset {
+		return false
+	}
+	if r.ToOffset >= 0 && rec.Offset > r.ToOffset {
+		return false
+	}
+	if !r.FromTime.IsZero() && rec.Timestamp.Before(r.FromTime) {
+		return false
+	}
+	if !r.ToTime.IsZero() && rec.Timestamp.After(r.ToTime) {
+		return false
+	}
+	return true
+}
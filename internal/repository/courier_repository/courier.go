@@ -167,21 +167,43 @@ func (r *CourierRepo) GetAvailableCouriers(ctx context.Context) ([]model.Courier
 
 }
 
-func (r *CourierRepo) UpdateAllExpiredCourier(ctx context.Context, ids []int64) error {
+// UpdateAllExpiredCourier frees the busy couriers among ids and returns the
+// ones it freed.
+func (r *CourierRepo) UpdateAllExpiredCourier(ctx context.Context, ids []int64) ([]model.CourierDB, error) {
 
 	conn, err := r.tm.GetConnection(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	sqlUpdate := `UPDATE couriers SET status = 'available',
                     updated_at = now()
-                    WHERE id = ANY($1) and status = 'busy'`
+                    WHERE id = ANY($1) and status = 'busy'
+                    RETURNING id, name, phone, status, created_at, updated_at, transport_type`
 
-	if _, err = conn.Exec(ctx, sqlUpdate, ids); err != nil {
-		return err
+	rows, err := conn.Query(ctx, sqlUpdate, ids)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	defer rows.Close()
+
+	var couriers []model.CourierDB
+
+	for rows.Next() {
+		var c model.CourierDB
+
+		if err := rows.Scan(&c.Id, &c.Name, &c.Phone, &c.Status, &c.CreatedAt, &c.UpdatedAt, &c.Transport); err != nil {
+			return nil, err
+		}
+
+		couriers = append(couriers, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows err: %w", err)
+	}
+
+	return couriers, nil
 
 }
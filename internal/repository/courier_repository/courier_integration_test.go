@@ -287,8 +287,9 @@ func TestUpdateAllExpiredCourier_Success_Integration(t *testing.T) {
 
 	ids := []int64{busy1.Id, busy2.Id, avail.Id}
 
-	err = repo.UpdateAllExpiredCourier(ctx, ids)
+	released, err := repo.UpdateAllExpiredCourier(ctx, ids)
 	require.NoError(t, err)
+	require.Len(t, released, 2, "only busy couriers are released")
 
 	b1, err := repo.Get(ctx, busy1.Id)
 	require.NoError(t, err)
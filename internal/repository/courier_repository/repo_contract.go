@@ -15,5 +15,5 @@ type CourierRepository interface {
 	Update(ctx context.Context, req *model.UpdateCourierRequest) error
 
 	GetAvailableCouriers(ctx context.Context) ([]model.CourierDB, error)
-	UpdateAllExpiredCourier(ctx context.Context, ids []int64) error
+	UpdateAllExpiredCourier(ctx context.Context, ids []int64) ([]model.CourierDB, error)
 }
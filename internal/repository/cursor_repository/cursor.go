@@ -0,0 +1,55 @@
+package cursor_repository
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/tx"
+	"errors"
+	"github.com/jackc/pgx/v5"
+)
+
+type CursorRepo struct {
+	tm tx.TransactionManager
+}
+
+func NewCursorRepository(tm tx.TransactionManager) *CursorRepo {
+	return &CursorRepo{tm: tm}
+}
+
+func (r *CursorRepo) Get(ctx context.Context, name string) (model.OrderCursor, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return model.OrderCursor{}, err
+	}
+
+	sqlSelect := `SELECT position, order_id FROM order_cursor WHERE name = $1;`
+
+	var cursor model.OrderCursor
+	if err := conn.QueryRow(ctx, sqlSelect, name).Scan(&cursor.CreatedAt, &cursor.OrderId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.OrderCursor{}, ErrNotFound
+		}
+		return model.OrderCursor{}, err
+	}
+
+	cursor.CreatedAt = cursor.CreatedAt.UTC()
+	return cursor, nil
+}
+
+// Save never moves the cursor backwards, so a slow poller cannot undo the
+// progress of a newer one.
+func (r *CursorRepo) Save(ctx context.Context, name string, cursor model.OrderCursor) error {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	sqlUpsert := `INSERT INTO order_cursor (name, position, order_id) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET position = EXCLUDED.position, order_id = EXCLUDED.order_id, updated_at = now()
+		WHERE (order_cursor.position, order_cursor.order_id) < (EXCLUDED.position, EXCLUDED.order_id);`
+
+	_, err = conn.Exec(ctx, sqlUpsert, name, cursor.CreatedAt.UTC(), cursor.OrderId)
+	return err
+}
@@ -0,0 +1,7 @@
+package cursor_repository
+
+import "errors"
+
+var (
+	ErrNotFound error = errors.New("cursor not found")
+)
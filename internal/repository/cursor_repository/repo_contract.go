@@ -0,0 +1,11 @@
+package cursor_repository
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+)
+
+type CursorRepository interface {
+	Get(ctx context.Context, name string) (model.OrderCursor, error)
+	Save(ctx context.Context, name string, cursor model.OrderCursor) error
+}
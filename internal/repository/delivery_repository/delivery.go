@@ -6,6 +6,8 @@ import (
 	"course-go-avito-SitnikovArtem06/internal/tx"
 	"errors"
 	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -41,11 +43,12 @@ func (r *DeliveryRepo) GetByOrderId(ctx context.Context, orderID string) (*model
 		return nil, err
 	}
 
-	sqlSelect := `SELECT id, courier_id, order_id, assigned_at, deadline FROM delivery WHERE order_id = $1 FOR UPDATE;`
+	sqlSelect := `SELECT id, courier_id, order_id, assigned_at, deadline, status, restaurant_id, address, total_price, items_count FROM delivery WHERE order_id = $1 FOR UPDATE;`
 
 	var delivery model.DeliveryDB
 
-	if err := conn.QueryRow(ctx, sqlSelect, orderID).Scan(&delivery.Id, &delivery.CourierId, &delivery.OrderId, &delivery.AssignedAt, &delivery.Deadline); err != nil {
+	if err := conn.QueryRow(ctx, sqlSelect, orderID).Scan(&delivery.Id, &delivery.CourierId, &delivery.OrderId, &delivery.AssignedAt, &delivery.Deadline, &delivery.Status,
+		&delivery.RestaurantId, &delivery.Address, &delivery.TotalPrice, &delivery.ItemsCount); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
 		}
@@ -55,6 +58,48 @@ func (r *DeliveryRepo) GetByOrderId(ctx context.Context, orderID string) (*model
 	return &delivery, nil
 }
 
+func (r *DeliveryRepo) UpdateStatus(ctx context.Context, orderId string, status model.DeliveryStatus) error {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	sqlUpdate := `UPDATE delivery SET status = $2, updated_at = now() WHERE order_id = $1;`
+
+	tag, err := conn.Exec(ctx, sqlUpdate, orderId, status)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *DeliveryRepo) SaveSnapshot(ctx context.Context, orderId string, snapshot model.OrderSnapshot) error {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	sqlUpdate := `UPDATE delivery SET restaurant_id = $2, address = $3, total_price = $4, items_count = $5, updated_at = now() WHERE order_id = $1;`
+
+	tag, err := conn.Exec(ctx, sqlUpdate, orderId, snapshot.RestaurantId, snapshot.Address, snapshot.TotalPrice, snapshot.ItemsCount)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *DeliveryRepo) Delete(ctx context.Context, orderId string) (int64, error) {
 
 	conn, err := r.tm.GetConnection(ctx)
@@ -140,3 +185,102 @@ func (r *DeliveryRepo) GetCourierWithMinimumOrder(ctx context.Context) (id int64
 	return courierId, nil
 
 }
+
+const sqlSelectDelivery = `SELECT d.id, d.order_id, d.courier_id, c.name, c.transport_type, d.status, d.assigned_at, d.deadline
+	FROM delivery d JOIN couriers c ON c.id = d.courier_id`
+
+func (r *DeliveryRepo) GetDelivery(ctx context.Context, orderId string) (*model.Delivery, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := scanDelivery(conn.QueryRow(ctx, sqlSelectDelivery+` WHERE d.order_id = $1;`, orderId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// FindDeliveries returns deliveries matching filter, newest first.
+func (r *DeliveryRepo) FindDeliveries(ctx context.Context, filter model.DeliveryFilter) ([]model.Delivery, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.CourierId != 0 {
+		where = append(where, "d.courier_id = "+arg(filter.CourierId))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, s := range filter.Statuses {
+			statuses = append(statuses, s.String())
+		}
+		where = append(where, "d.status = ANY("+arg(statuses)+")")
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "d.assigned_at >= "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "d.assigned_at < "+arg(filter.To))
+	}
+	if filter.After != nil {
+		where = append(where, "(d.assigned_at, d.id) < ("+arg(filter.After.AssignedAt)+", "+arg(filter.After.Id)+")")
+	}
+
+	sqlSelect := sqlSelectDelivery
+	if len(where) > 0 {
+		sqlSelect += " WHERE " + strings.Join(where, " AND ")
+	}
+	sqlSelect += " ORDER BY d.assigned_at DESC, d.id DESC"
+	if filter.Limit > 0 {
+		sqlSelect += " LIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := conn.Query(ctx, sqlSelect, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]model.Delivery, 0)
+
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func scanDelivery(row pgx.Row) (*model.Delivery, error) {
+	var d model.Delivery
+	if err := row.Scan(&d.Id, &d.OrderId, &d.CourierId, &d.CourierName, &d.Transport, &d.Status, &d.AssignedAt, &d.Deadline); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -119,6 +119,83 @@ func TestDelete_NotFound_Integration(t *testing.T) {
 	require.ErrorIs(t, err, ErrNotFound)
 }
 
+func TestUpdateStatus_Success_Integration(t *testing.T) {
+	dRepo, cRepo := newTestRepos(t)
+	ctx := context.Background()
+
+	courier, err := cRepo.Create(ctx, &model.CourierDB{
+		Name:      "Courier",
+		Phone:     "+79990000003",
+		Status:    model.CourierStatusBusy,
+		Transport: model.OnFoot,
+	})
+	require.NoError(t, err)
+
+	orderID := "order-status"
+	require.NoError(t, dRepo.Create(ctx, orderID, courier.Id, time.Now().Add(30*time.Minute).UTC()))
+
+	got, err := dRepo.GetByOrderId(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, model.DeliveryAssigned, got.Status)
+
+	require.NoError(t, dRepo.UpdateStatus(ctx, orderID, model.DeliveryPickedUp))
+
+	got, err = dRepo.GetByOrderId(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, model.DeliveryPickedUp, got.Status)
+}
+
+func TestUpdateStatus_NotFound_Integration(t *testing.T) {
+	dRepo, _ := newTestRepos(t)
+	ctx := context.Background()
+
+	err := dRepo.UpdateStatus(ctx, "unknown-order", model.DeliveryPickedUp)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSaveSnapshot_Success_Integration(t *testing.T) {
+	dRepo, cRepo := newTestRepos(t)
+	ctx := context.Background()
+
+	courier, err := cRepo.Create(ctx, &model.CourierDB{
+		Name:      "Courier",
+		Phone:     "+79990000004",
+		Status:    model.CourierStatusBusy,
+		Transport: model.OnFoot,
+	})
+	require.NoError(t, err)
+
+	orderID := "order-snapshot"
+	require.NoError(t, dRepo.Create(ctx, orderID, courier.Id, time.Now().Add(30*time.Minute).UTC()))
+
+	got, err := dRepo.GetByOrderId(ctx, orderID)
+	require.NoError(t, err)
+	require.Nil(t, got.Address)
+
+	snapshot := model.OrderSnapshot{
+		RestaurantId: "r1",
+		Address:      model.OrderAddress{Street: "Lenina", House: "1", Apartment: "12"},
+		TotalPrice:   1500,
+		ItemsCount:   3,
+	}
+	require.NoError(t, dRepo.SaveSnapshot(ctx, orderID, snapshot))
+
+	got, err = dRepo.GetByOrderId(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, "r1", got.RestaurantId)
+	require.Equal(t, &snapshot.Address, got.Address)
+	require.Equal(t, int64(1500), got.TotalPrice)
+	require.Equal(t, int64(3), got.ItemsCount)
+}
+
+func TestSaveSnapshot_NotFound_Integration(t *testing.T) {
+	dRepo, _ := newTestRepos(t)
+	ctx := context.Background()
+
+	err := dRepo.SaveSnapshot(ctx, "unknown-order", model.OrderSnapshot{})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
 func TestGetExpiredOrders_Success_Integration(t *testing.T) {
 	dRepo, cRepo := newTestRepos(t)
 	ctx := context.Background()
@@ -233,3 +310,75 @@ func TestGetCourierWithMinimumOrder_NoneCourier_Integration(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrNoneCourier)
 }
+
+func TestGetDelivery_Success_Integration(t *testing.T) {
+	dRepo, cRepo := newTestRepos(t)
+	ctx := context.Background()
+
+	courier, err := cRepo.Create(ctx, &model.CourierDB{
+		Name:      "Courier",
+		Phone:     "+79990000001",
+		Status:    model.CourierStatusAvailable,
+		Transport: model.Car,
+	})
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(5 * time.Minute).UTC()
+	require.NoError(t, dRepo.Create(ctx, "order-1", courier.Id, deadline))
+
+	got, err := dRepo.GetDelivery(ctx, "order-1")
+	require.NoError(t, err)
+
+	require.Equal(t, "order-1", got.OrderId)
+	require.Equal(t, courier.Id, got.CourierId)
+	require.Equal(t, "Courier", got.CourierName)
+	require.Equal(t, model.Car, got.Transport)
+	require.Equal(t, model.DeliveryAssigned, got.Status)
+	require.WithinDuration(t, deadline, got.Deadline, time.Second*2)
+
+	_, err = dRepo.GetDelivery(ctx, "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFindDeliveries_FiltersAndPages_Integration(t *testing.T) {
+	dRepo, cRepo := newTestRepos(t)
+	ctx := context.Background()
+
+	first, err := cRepo.Create(ctx, &model.CourierDB{Name: "A", Phone: "+79990000001", Status: model.CourierStatusAvailable, Transport: model.OnFoot})
+	require.NoError(t, err)
+	second, err := cRepo.Create(ctx, &model.CourierDB{Name: "B", Phone: "+79990000002", Status: model.CourierStatusAvailable, Transport: model.Car})
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(time.Hour).UTC()
+	require.NoError(t, dRepo.Create(ctx, "order-1", first.Id, deadline))
+	require.NoError(t, dRepo.Create(ctx, "order-2", second.Id, deadline))
+	require.NoError(t, dRepo.Create(ctx, "order-3", first.Id, deadline))
+	require.NoError(t, dRepo.UpdateStatus(ctx, "order-3", model.DeliveryCompleted))
+
+	all, err := dRepo.FindDeliveries(ctx, model.DeliveryFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	byCourier, err := dRepo.FindDeliveries(ctx, model.DeliveryFilter{CourierId: first.Id})
+	require.NoError(t, err)
+	require.Len(t, byCourier, 2)
+
+	active, err := dRepo.FindDeliveries(ctx, model.DeliveryFilter{CourierId: first.Id, Statuses: []model.DeliveryStatus{model.DeliveryAssigned}})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	require.Equal(t, "order-1", active[0].OrderId)
+
+	future, err := dRepo.FindDeliveries(ctx, model.DeliveryFilter{From: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Empty(t, future)
+
+	page, err := dRepo.FindDeliveries(ctx, model.DeliveryFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+
+	last := page[1]
+	rest, err := dRepo.FindDeliveries(ctx, model.DeliveryFilter{After: &model.DeliveryCursor{AssignedAt: last.AssignedAt, Id: last.Id}})
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	require.NotContains(t, []string{page[0].OrderId, page[1].OrderId}, rest[0].OrderId)
+}
@@ -12,8 +12,13 @@ type DeliveryRepository interface {
 	Delete(ctx context.Context, orderId string) (int64, error)
 
 	GetByOrderId(ctx context.Context, orderID string) (*model.DeliveryDB, error)
+	UpdateStatus(ctx context.Context, orderId string, status model.DeliveryStatus) error
+	SaveSnapshot(ctx context.Context, orderId string, snapshot model.OrderSnapshot) error
 
 	GetExpiredOrders(ctx context.Context) ([]int64, error)
 
 	GetCourierWithMinimumOrder(ctx context.Context) (id int64, err error)
+
+	GetDelivery(ctx context.Context, orderId string) (*model.Delivery, error)
+	FindDeliveries(ctx context.Context, filter model.DeliveryFilter) ([]model.Delivery, error)
 }
@@ -0,0 +1,120 @@
+package event_repository
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/tx"
+	"strconv"
+	"time"
+)
+
+// Channel is notified with the id of every appended event once its
+// transaction commits.
+const Channel = "courier_events"
+
+// appendLock is the advisory lock Append holds until commit.
+const appendLock = 20260415
+
+type EventRepo struct {
+	tm tx.TransactionManager
+}
+
+func NewEventRepository(tm tx.TransactionManager) *EventRepo {
+	return &EventRepo{tm: tm}
+}
+
+// Append stores e and notifies Channel. Call it inside the transaction of
+// the write e describes: the event and the notification then commit or roll
+// back with it. Appends are serialised until commit, so ids become visible
+// in order and a reader that has seen an id never misses a smaller one.
+func (r *EventRepo) Append(ctx context.Context, e events.Event) error {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err = conn.Exec(ctx, `SELECT pg_advisory_xact_lock($1);`, appendLock); err != nil {
+		return err
+	}
+
+	var deadline *time.Time
+	if !e.Deadline.IsZero() {
+		deadline = &e.Deadline
+	}
+
+	sqlInsert := `INSERT INTO courier_events (type, courier_id, courier_status, transport_type, order_id, deadline, actor, actor_role)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;`
+
+	var id int64
+	err = conn.QueryRow(ctx, sqlInsert, e.Type, e.CourierId, e.CourierStatus, e.Transport, e.OrderId, deadline, e.Actor, e.ActorRole).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, `SELECT pg_notify($1, $2);`, Channel, strconv.FormatInt(id, 10))
+	return err
+}
+
+// ListAfter returns up to limit events with an id above afterId, oldest
+// first.
+func (r *EventRepo) ListAfter(ctx context.Context, afterId int64, limit int) ([]events.Event, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlSelect := `SELECT id, type, courier_id, courier_status, transport_type, order_id, deadline, actor, actor_role, created_at
+		FROM courier_events WHERE id > $1 ORDER BY id LIMIT $2;`
+
+	rows, err := conn.Query(ctx, sqlSelect, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]events.Event, 0)
+
+	for rows.Next() {
+		var e events.Event
+		var deadline *time.Time
+		if err := rows.Scan(&e.Id, &e.Type, &e.CourierId, &e.CourierStatus, &e.Transport, &e.OrderId, &deadline, &e.Actor, &e.ActorRole, &e.At); err != nil {
+			return nil, err
+		}
+		if deadline != nil {
+			e.Deadline = deadline.UTC()
+		}
+		e.At = e.At.UTC()
+		list = append(list, e)
+	}
+
+	return list, rows.Err()
+}
+
+// LastId returns the id of the newest event, or 0 when there is none.
+func (r *EventRepo) LastId(ctx context.Context) (int64, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = conn.QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM courier_events;`).Scan(&id)
+	return id, err
+}
+
+func (r *EventRepo) DeleteOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := conn.Exec(ctx, `DELETE FROM courier_events WHERE created_at < now() - $1 * interval '1 millisecond';`, age.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,14 @@
+package event_repository
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"time"
+)
+
+type EventRepository interface {
+	Append(ctx context.Context, e events.Event) error
+	ListAfter(ctx context.Context, afterId int64, limit int) ([]events.Event, error)
+	LastId(ctx context.Context) (int64, error)
+	DeleteOlderThan(ctx context.Context, age time.Duration) (int64, error)
+}
@@ -0,0 +1,7 @@
+package idempotency_repository
+
+import "errors"
+
+var (
+	ErrNotFound error = errors.New("idempotency key not found")
+)
@@ -0,0 +1,114 @@
+package idempotency_repository
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/tx"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type IdempotencyRepo struct {
+	tm tx.TransactionManager
+}
+
+func NewIdempotencyRepository(tm tx.TransactionManager) *IdempotencyRepo {
+	return &IdempotencyRepo{tm: tm}
+}
+
+// Reserve claims key for owner under a lease and returns nil, or returns
+// what is stored for a live key without claiming it. An expired key, or one
+// whose request let its lease run out without completing, is taken over as
+// if it never existed. Only the owner can Complete or Release the key.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, scope, key, requestHash, owner string, lease time.Duration) (*model.IdempotentResponse, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Until it completes, a key expires together with its lease, so that
+	// abandoned reservations are purged too.
+	sqlReserve := `INSERT INTO idempotency_keys (scope, key, request_hash, owner, locked_until, expires_at)
+		VALUES ($1, $2, $3, $5, now() + $4 * interval '1 millisecond', now() + $4 * interval '1 millisecond')
+		ON CONFLICT (scope, key) DO UPDATE SET request_hash = EXCLUDED.request_hash, owner = EXCLUDED.owner, status_code = NULL,
+			content_type = '', body = NULL, created_at = now(), locked_until = EXCLUDED.locked_until, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= now()
+			OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until <= now())
+		RETURNING key;`
+
+	var reserved string
+	err = conn.QueryRow(ctx, sqlReserve, scope, key, requestHash, lease.Milliseconds(), owner).Scan(&reserved)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	sqlSelect := `SELECT request_hash, COALESCE(status_code, 0), content_type, COALESCE(body, '') FROM idempotency_keys
+		WHERE scope = $1 AND key = $2;`
+
+	var resp model.IdempotentResponse
+	if err := conn.QueryRow(ctx, sqlSelect, scope, key).Scan(&resp.RequestHash, &resp.StatusCode, &resp.ContentType, &resp.Body); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Complete stores the response of a key reserved by owner and keeps it for
+// ttl. It returns ErrNotFound when owner no longer holds the key, as when
+// its lease ran out and a retry took the key over.
+func (r *IdempotencyRepo) Complete(ctx context.Context, scope, key, owner string, resp model.IdempotentResponse, ttl time.Duration) error {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	sqlUpdate := `UPDATE idempotency_keys SET status_code = $3, content_type = $4, body = $5,
+			locked_until = NULL, expires_at = now() + $6 * interval '1 millisecond'
+		WHERE scope = $1 AND key = $2 AND owner = $7 AND status_code IS NULL;`
+
+	tag, err := conn.Exec(ctx, sqlUpdate, scope, key, resp.StatusCode, resp.ContentType, resp.Body, ttl.Milliseconds(), owner)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Release forgets a key whose request failed, so that a retry runs again. A
+// completed key, or one that owner no longer holds, is kept.
+func (r *IdempotencyRepo) Release(ctx context.Context, scope, key, owner string) error {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, `DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND owner = $3 AND status_code IS NULL;`, scope, key, owner)
+	return err
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+
+	conn, err := r.tm.GetConnection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := conn.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= now();`)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
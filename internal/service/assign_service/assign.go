@@ -26,62 +26,162 @@ func NewAssignService(txManager tx.TransactionManager, dRepo delivery_repository
 	}
 }
 
+// AssignCourier assigns a courier to orderId without reading the order, so
+// the delivery has no snapshot yet. The worker stores it from the order's
+// next status event, starting with created.
 func (s *AssignService) AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error) {
 
 	var result *model.AssignCourier
 
 	err := s.txManager.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		result, err = s.assign(ctx, orderId, nil)
+		return err
+	})
 
-		if _, err := s.deliveryRepo.GetByOrderId(ctx, orderId); err == nil {
-			return ErrOrderAlreadyAssign
-		} else if !errors.Is(err, delivery_repository.ErrNotFound) {
-			return err
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		courierId, err := s.deliveryRepo.GetCourierWithMinimumOrder(ctx)
-		if err != nil {
-			if errors.Is(err, delivery_repository.ErrNoneCourier) {
-				return ErrNotAvailableCourier
-			}
-			return err
-		}
+	return result, nil
 
-		courier, err := s.courierRepo.Get(ctx, courierId)
-		if err != nil {
-			return err
-		}
+}
 
-		tr := s.TransportFactory.Get(courier.Transport)
-		deadline := tr.Deadline()
+// AssignOrder assigns a courier to an order read from the order service and
+// stores its snapshot in the same transaction, so a delivery never exists
+// without one.
+func (s *AssignService) AssignOrder(ctx context.Context, order *model.Order) (*model.AssignCourier, error) {
 
-		if err = s.deliveryRepo.Create(ctx, orderId, courier.Id, deadline); err != nil {
-			return err
-		}
+	var result *model.AssignCourier
 
-		status := model.CourierStatusBusy
+	snapshot := order.Snapshot()
 
-		req := &model.UpdateCourierRequest{Id: &courier.Id, Status: &status}
+	err := s.txManager.Begin(ctx, true, func(ctx context.Context) error {
+		var err error
+		result, err = s.assign(ctx, order.Id, &snapshot)
+		return err
+	})
 
-		if err = s.courierRepo.Update(ctx, req); err != nil {
-			return err
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		result = &model.AssignCourier{
-			CourierId: courier.Id,
-			OrderId:   orderId,
-			Transport: courier.Transport,
-			Deadline:  deadline,
+	return result, nil
+}
+
+// AssignCouriers assigns orders one after another in a single transaction,
+// so each order gets the least loaded courier left after the ones before it.
+// Like AssignCourier it stores no snapshots.
+// With BatchPartial every order runs under its own savepoint: a failure only
+// undoes that order and is reported with it. With BatchAllOrNothing the first
+// failure rolls the batch back, the other orders report ErrBatchAborted and
+// the call returns ErrBatchAborted as well. Either way the pass stops once
+// ctx is done, and the batch then assigns nothing and returns ctx's error.
+func (s *AssignService) AssignCouriers(ctx context.Context, orderIds []string, policy model.BatchPolicy) ([]model.AssignResult, error) {
+
+	results := make([]model.AssignResult, len(orderIds))
+	for i, orderId := range orderIds {
+		results[i].OrderId = orderId
+	}
+
+	if policy != model.BatchAllOrNothing {
+		err := s.txManager.Begin(ctx, true, func(ctx context.Context) error {
+			for i, orderId := range orderIds {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				results[i].Assign, results[i].Err = s.AssignCourier(ctx, orderId)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
+		return results, nil
+	}
 
+	failed := -1
+	err := s.txManager.Begin(ctx, true, func(ctx context.Context) error {
+		for i, orderId := range orderIds {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			assign, err := s.assign(ctx, orderId, nil)
+			if err != nil {
+				failed = i
+				return err
+			}
+			results[i].Assign = assign
+		}
 		return nil
 	})
+	if err == nil {
+		return results, nil
+	}
+	if failed < 0 {
+		// The transaction itself failed, not one of the orders.
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Assign = nil
+		results[i].Err = ErrBatchAborted
+	}
+	results[failed].Err = err
+	return results, ErrBatchAborted
+}
+
+// assign picks the least loaded available courier for orderId and stores
+// snapshot with the delivery when there is one. It must run inside a
+// transaction.
+func (s *AssignService) assign(ctx context.Context, orderId string, snapshot *model.OrderSnapshot) (*model.AssignCourier, error) {
 
+	if _, err := s.deliveryRepo.GetByOrderId(ctx, orderId); err == nil {
+		return nil, ErrOrderAlreadyAssign
+	} else if !errors.Is(err, delivery_repository.ErrNotFound) {
+		return nil, err
+	}
+
+	courierId, err := s.deliveryRepo.GetCourierWithMinimumOrder(ctx)
 	if err != nil {
+		if errors.Is(err, delivery_repository.ErrNoneCourier) {
+			return nil, ErrNotAvailableCourier
+		}
 		return nil, err
 	}
 
-	return result, nil
+	courier, err := s.courierRepo.Get(ctx, courierId)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := s.TransportFactory.Get(courier.Transport)
+	deadline := tr.Deadline()
+
+	if err = s.deliveryRepo.Create(ctx, orderId, courier.Id, deadline); err != nil {
+		return nil, err
+	}
+
+	status := model.CourierStatusBusy
 
+	req := &model.UpdateCourierRequest{Id: &courier.Id, Status: &status}
+
+	if err = s.courierRepo.Update(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if snapshot != nil {
+		if err = s.deliveryRepo.SaveSnapshot(ctx, orderId, *snapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.AssignCourier{
+		CourierId: courier.Id,
+		OrderId:   orderId,
+		Transport: courier.Transport,
+		Deadline:  deadline,
+	}, nil
 }
 
 func (s *AssignService) UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error) {
@@ -120,9 +220,50 @@ func (s *AssignService) UnassignCourier(ctx context.Context, orderId string) (*m
 
 }
 
-func (s *AssignService) CompleteCourier(ctx context.Context, orderId string) error {
+// CompleteCourier completes the delivery of orderId and frees its courier.
+// It follows the same transition rules as ChangeDeliveryStatus, so a returned
+// or failed delivery cannot be completed.
+func (s *AssignService) CompleteCourier(ctx context.Context, orderId string) (*model.CompleteCourier, error) {
+
+	delivery, err := s.changeDeliveryStatus(ctx, orderId, model.DeliveryCompleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CompleteCourier{
+		CourierId: delivery.CourierId,
+		OrderId:   orderId,
+	}, nil
+}
+
+func (s *AssignService) ChangeDeliveryStatus(ctx context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryStatusChange, error) {
+	delivery, err := s.changeDeliveryStatus(ctx, orderId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DeliveryStatusChange{
+		CourierId: delivery.CourierId,
+		OrderId:   orderId,
+		From:      delivery.Status,
+		To:        status,
+	}, nil
+}
+
+// changeDeliveryStatus moves the delivery of orderId to status and returns it
+// as it was before the change.
+func (s *AssignService) changeDeliveryStatus(ctx context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryDB, error) {
+
+	if !status.IsValid() {
+		return nil, ErrInvalidTransition
+	}
+
+	var delivery *model.DeliveryDB
+
 	err := s.txManager.Begin(ctx, true, func(ctx context.Context) error {
-		delivery, err := s.deliveryRepo.GetByOrderId(ctx, orderId)
+
+		var err error
+		delivery, err = s.deliveryRepo.GetByOrderId(ctx, orderId)
 		if err != nil {
 			if errors.Is(err, delivery_repository.ErrNotFound) {
 				return ErrNotFoundOrder
@@ -130,17 +271,43 @@ func (s *AssignService) CompleteCourier(ctx context.Context, orderId string) err
 			return err
 		}
 
-		status := model.CourierStatusAvailable
-		reqUpdate := &model.UpdateCourierRequest{
-			Id:     &delivery.CourierId,
-			Status: &status,
+		if delivery.Status == status {
+			return nil
 		}
-		err = s.courierRepo.Update(ctx, reqUpdate)
-		if err != nil {
+
+		if !delivery.Status.CanTransitionTo(status) {
+			return ErrInvalidTransition
+		}
+
+		if err = s.deliveryRepo.UpdateStatus(ctx, orderId, status); err != nil {
 			return err
 		}
-		return nil
+
+		// A delivery that already freed its courier, such as a failed one
+		// that is now returned, must not free them again: they may have
+		// taken another order since.
+		change := model.DeliveryStatusChange{From: delivery.Status, To: status}
+		if !change.ReleasesCourier() {
+			return nil
+		}
+
+		courierStatus := model.CourierStatusAvailable
+		return s.courierRepo.Update(ctx, &model.UpdateCourierRequest{Id: &delivery.CourierId, Status: &courierStatus})
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (s *AssignService) SaveOrderSnapshot(ctx context.Context, order *model.Order) error {
+
+	err := s.deliveryRepo.SaveSnapshot(ctx, order.Id, order.Snapshot())
+	if errors.Is(err, delivery_repository.ErrNotFound) {
+		return ErrNotFoundOrder
+	}
+
 	return err
 }
@@ -177,3 +177,59 @@ func TestUnassignCourier_NotAssigned_Integration(t *testing.T) {
 	require.Nil(t, res)
 	require.ErrorIs(t, err, ErrNotAssignedCourier)
 }
+
+func TestAssignCouriers_PartialKeepsOtherOrders_Integration(t *testing.T) {
+	svc, cRepo, dRepo := newTestAssignService(t)
+	ctx := context.Background()
+
+	for _, phone := range []string{"+79990000011", "+79990000012"} {
+		_, err := cRepo.Create(ctx, &model.CourierDB{
+			Name:      "Courier",
+			Phone:     phone,
+			Status:    model.CourierStatusAvailable,
+			Transport: model.Car,
+		})
+		require.NoError(t, err)
+	}
+
+	results, err := svc.AssignCouriers(ctx, []string{"order-1", "order-1", "order-2"}, model.BatchPartial)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.NoError(t, results[0].Err)
+	require.ErrorIs(t, results[1].Err, ErrOrderAlreadyAssign)
+	require.NoError(t, results[2].Err)
+	require.NotEqual(t, results[0].Assign.CourierId, results[2].Assign.CourierId)
+
+	for _, orderId := range []string{"order-1", "order-2"} {
+		_, err := dRepo.GetByOrderId(ctx, orderId)
+		require.NoError(t, err)
+	}
+}
+
+func TestChangeDeliveryStatus_ReturnAfterFailureKeepsCourierBusy_Integration(t *testing.T) {
+	svc, cRepo, _ := newTestAssignService(t)
+	ctx := context.Background()
+
+	courier, err := cRepo.Create(ctx, &model.CourierDB{
+		Name:      "Courier 1",
+		Phone:     "+79990000021",
+		Status:    model.CourierStatusAvailable,
+		Transport: model.Car,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.AssignCourier(ctx, "order-1")
+	require.NoError(t, err)
+	_, err = svc.ChangeDeliveryStatus(ctx, "order-1", model.DeliveryFailed)
+	require.NoError(t, err)
+
+	_, err = svc.AssignCourier(ctx, "order-2")
+	require.NoError(t, err)
+	_, err = svc.ChangeDeliveryStatus(ctx, "order-1", model.DeliveryReturned)
+	require.NoError(t, err)
+
+	got, err := cRepo.Get(ctx, courier.Id)
+	require.NoError(t, err)
+	require.Equal(t, model.CourierStatusBusy, got.Status)
+}
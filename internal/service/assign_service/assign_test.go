@@ -414,6 +414,56 @@ func TestAssign_DBError_Begin(t *testing.T) {
 	require.Equal(t, dbErr, err)
 }
 
+func TestAssignOrder_SavesSnapshotInTransaction(t *testing.T) {
+	t.Parallel()
+
+	service, m := newBatchService(t)
+
+	var inTx bool
+	m.tx.EXPECT().
+		Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			inTx = true
+			defer func() { inTx = false }()
+			return fn(parent)
+		})
+
+	order := &model.Order{Id: "o-1", RestaurantId: "r-1", TotalPrice: 1000}
+	deadline := time.Now().Add(15 * time.Minute).UTC()
+	m.expectAssigned("o-1", 1, deadline)
+	m.dRepo.EXPECT().
+		SaveSnapshot(gomock.Any(), "o-1", order.Snapshot()).
+		DoAndReturn(func(context.Context, string, model.OrderSnapshot) error {
+			require.True(t, inTx)
+			return nil
+		})
+
+	got, err := service.AssignOrder(context.Background(), order)
+	require.NoError(t, err)
+	require.Equal(t, &model.AssignCourier{CourierId: 1, OrderId: "o-1", Transport: model.Car, Deadline: deadline}, got)
+}
+
+func TestAssignOrder_SnapshotErrorFailsAssignment(t *testing.T) {
+	t.Parallel()
+
+	service, m := newBatchService(t)
+
+	m.tx.EXPECT().
+		Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			return fn(parent)
+		})
+
+	m.expectAssigned("o-1", 1, time.Now().Add(15*time.Minute).UTC())
+
+	dbErr := errors.New("db error")
+	m.dRepo.EXPECT().SaveSnapshot(gomock.Any(), "o-1", gomock.Any()).Return(dbErr)
+
+	got, err := service.AssignOrder(context.Background(), &model.Order{Id: "o-1"})
+	require.ErrorIs(t, err, dbErr)
+	require.Nil(t, got)
+}
+
 func TestUnassign_Success(t *testing.T) {
 
 	t.Parallel()
@@ -666,12 +716,17 @@ func TestCompleteCourier_Success(t *testing.T) {
 		Id:        1,
 		OrderId:   orderId,
 		CourierId: 1,
+		Status:    model.DeliveryAssigned,
 	}
 
 	dRepo.EXPECT().
 		GetByOrderId(gomock.Any(), orderId).
 		Return(delivery, nil)
 
+	dRepo.EXPECT().
+		UpdateStatus(gomock.Any(), orderId, model.DeliveryCompleted).
+		Return(nil)
+
 	cRepo.EXPECT().
 		Update(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(ctx context.Context, r *model.UpdateCourierRequest) error {
@@ -682,8 +737,9 @@ func TestCompleteCourier_Success(t *testing.T) {
 			return nil
 		})
 
-	err := service.CompleteCourier(context.Background(), orderId)
+	complete, err := service.CompleteCourier(context.Background(), orderId)
 	require.NoError(t, err)
+	require.Equal(t, &model.CompleteCourier{CourierId: 1, OrderId: orderId}, complete)
 }
 
 func TestCompleteCourier_NotFound(t *testing.T) {
@@ -711,7 +767,7 @@ func TestCompleteCourier_NotFound(t *testing.T) {
 		GetByOrderId(gomock.Any(), orderId).
 		Return(nil, delivery_repository.ErrNotFound)
 
-	err := service.CompleteCourier(context.Background(), orderId)
+	_, err := service.CompleteCourier(context.Background(), orderId)
 	require.ErrorIs(t, err, ErrNotFoundOrder)
 }
 
@@ -741,7 +797,7 @@ func TestCompleteCourier_DBError_GetByOrderId(t *testing.T) {
 		GetByOrderId(gomock.Any(), orderId).
 		Return(nil, dbErr)
 
-	err := service.CompleteCourier(context.Background(), orderId)
+	_, err := service.CompleteCourier(context.Background(), orderId)
 	require.ErrorIs(t, err, dbErr)
 }
 
@@ -769,19 +825,24 @@ func TestCompleteCourier_DBError_UpdateCourier(t *testing.T) {
 		Id:        1,
 		OrderId:   orderId,
 		CourierId: 1,
+		Status:    model.DeliveryAssigned,
 	}
 
 	dRepo.EXPECT().
 		GetByOrderId(gomock.Any(), orderId).
 		Return(delivery, nil)
 
+	dRepo.EXPECT().
+		UpdateStatus(gomock.Any(), orderId, model.DeliveryCompleted).
+		Return(nil)
+
 	dbErr := errors.New("db error")
 
 	cRepo.EXPECT().
 		Update(gomock.Any(), gomock.Any()).
 		Return(dbErr)
 
-	err := service.CompleteCourier(context.Background(), orderId)
+	_, err := service.CompleteCourier(context.Background(), orderId)
 	require.ErrorIs(t, err, dbErr)
 }
 
@@ -805,6 +866,362 @@ func TestCompleteCourier_DBError_Begin(t *testing.T) {
 		Begin(gomock.Any(), true, gomock.Any()).
 		Return(dbErr)
 
-	err := service.CompleteCourier(context.Background(), orderId)
+	_, err := service.CompleteCourier(context.Background(), orderId)
 	require.ErrorIs(t, err, dbErr)
 }
+
+func TestCompleteCourier_InvalidTransition(t *testing.T) {
+	t.Parallel()
+
+	for _, status := range []model.DeliveryStatus{model.DeliveryReturned, model.DeliveryFailed} {
+		service, dRepo, _ := newChangeStatusService(t)
+
+		dRepo.EXPECT().
+			GetByOrderId(gomock.Any(), "1").
+			Return(&model.DeliveryDB{OrderId: "1", CourierId: 1, Status: status}, nil)
+
+		_, err := service.CompleteCourier(context.Background(), "1")
+		require.ErrorIs(t, err, ErrInvalidTransition, status)
+	}
+}
+
+func newChangeStatusService(t *testing.T) (*AssignService, *mocks.MockDeliveryRepository, *mocks.MockCourierRepository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	cRepo := mocks.NewMockCourierRepository(ctrl)
+	tx := mocks.NewMockTransactionManager(ctrl)
+	dRepo := mocks.NewMockDeliveryRepository(ctrl)
+	transportFactory := mocks.NewMockTransportFactory(ctrl)
+
+	tx.EXPECT().
+		Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			return fn(parent)
+		}).
+		AnyTimes()
+
+	return NewAssignService(tx, dRepo, cRepo, transportFactory), dRepo, cRepo
+}
+
+func TestChangeDeliveryStatus_PickedUp_KeepsCourierBusy(t *testing.T) {
+	t.Parallel()
+
+	service, dRepo, _ := newChangeStatusService(t)
+
+	orderId := "1"
+	dRepo.EXPECT().
+		GetByOrderId(gomock.Any(), orderId).
+		Return(&model.DeliveryDB{OrderId: orderId, CourierId: 1, Status: model.DeliveryAssigned}, nil)
+	dRepo.EXPECT().
+		UpdateStatus(gomock.Any(), orderId, model.DeliveryPickedUp).
+		Return(nil)
+
+	change, err := service.ChangeDeliveryStatus(context.Background(), orderId, model.DeliveryPickedUp)
+	require.NoError(t, err)
+	require.Equal(t, &model.DeliveryStatusChange{CourierId: 1, OrderId: orderId, From: model.DeliveryAssigned, To: model.DeliveryPickedUp}, change)
+	require.False(t, change.ReleasesCourier())
+}
+
+func TestChangeDeliveryStatus_Returned_ReleasesCourier(t *testing.T) {
+	t.Parallel()
+
+	service, dRepo, cRepo := newChangeStatusService(t)
+
+	orderId := "1"
+	dRepo.EXPECT().
+		GetByOrderId(gomock.Any(), orderId).
+		Return(&model.DeliveryDB{OrderId: orderId, CourierId: 7, Status: model.DeliveryDelivering}, nil)
+	dRepo.EXPECT().
+		UpdateStatus(gomock.Any(), orderId, model.DeliveryReturned).
+		Return(nil)
+	cRepo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, r *model.UpdateCourierRequest) error {
+			require.Equal(t, int64(7), *r.Id)
+			require.Equal(t, model.CourierStatusAvailable, *r.Status)
+			return nil
+		})
+
+	change, err := service.ChangeDeliveryStatus(context.Background(), orderId, model.DeliveryReturned)
+	require.NoError(t, err)
+	require.True(t, change.ReleasesCourier())
+}
+
+func TestChangeDeliveryStatus_FailedToReturned_KeepsReassignedCourierBusy(t *testing.T) {
+	t.Parallel()
+
+	service, dRepo, _ := newChangeStatusService(t)
+
+	// The failed delivery freed courier 7, who has taken another order
+	// since; returning the first order must not touch them.
+	orderId := "1"
+	dRepo.EXPECT().
+		GetByOrderId(gomock.Any(), orderId).
+		Return(&model.DeliveryDB{OrderId: orderId, CourierId: 7, Status: model.DeliveryFailed}, nil)
+	dRepo.EXPECT().
+		UpdateStatus(gomock.Any(), orderId, model.DeliveryReturned).
+		Return(nil)
+
+	change, err := service.ChangeDeliveryStatus(context.Background(), orderId, model.DeliveryReturned)
+	require.NoError(t, err)
+	require.False(t, change.ReleasesCourier())
+}
+
+func TestChangeDeliveryStatus_SameStatus_NoOp(t *testing.T) {
+	t.Parallel()
+
+	service, dRepo, _ := newChangeStatusService(t)
+
+	orderId := "1"
+	dRepo.EXPECT().
+		GetByOrderId(gomock.Any(), orderId).
+		Return(&model.DeliveryDB{OrderId: orderId, CourierId: 1, Status: model.DeliveryDelivering}, nil)
+
+	change, err := service.ChangeDeliveryStatus(context.Background(), orderId, model.DeliveryDelivering)
+	require.NoError(t, err)
+	require.False(t, change.Changed())
+}
+
+func TestChangeDeliveryStatus_InvalidTransition(t *testing.T) {
+	t.Parallel()
+
+	service, dRepo, _ := newChangeStatusService(t)
+
+	orderId := "1"
+	dRepo.EXPECT().
+		GetByOrderId(gomock.Any(), orderId).
+		Return(&model.DeliveryDB{OrderId: orderId, CourierId: 1, Status: model.DeliveryCompleted}, nil)
+
+	_, err := service.ChangeDeliveryStatus(context.Background(), orderId, model.DeliveryPickedUp)
+	require.ErrorIs(t, err, ErrInvalidTransition)
+}
+
+func TestChangeDeliveryStatus_NotFound(t *testing.T) {
+	t.Parallel()
+
+	service, dRepo, _ := newChangeStatusService(t)
+
+	orderId := "1"
+	dRepo.EXPECT().
+		GetByOrderId(gomock.Any(), orderId).
+		Return(nil, delivery_repository.ErrNotFound)
+
+	_, err := service.ChangeDeliveryStatus(context.Background(), orderId, model.DeliveryPickedUp)
+	require.ErrorIs(t, err, ErrNotFoundOrder)
+}
+
+func TestChangeDeliveryStatus_UnknownStatus(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newChangeStatusService(t)
+
+	_, err := service.ChangeDeliveryStatus(context.Background(), "1", model.DeliveryStatus("lost"))
+	require.ErrorIs(t, err, ErrInvalidTransition)
+}
+
+
+func TestSaveOrderSnapshot_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dRepo := mocks.NewMockDeliveryRepository(ctrl)
+	service := NewAssignService(mocks.NewMockTransactionManager(ctrl), dRepo, mocks.NewMockCourierRepository(ctrl), mocks.NewMockTransportFactory(ctrl))
+
+	order := &model.Order{
+		Id:           "o1",
+		RestaurantId: "r1",
+		Items:        []model.OrderItem{{Name: "pizza", Price: 500, Quantity: 2}, {Name: "cola", Price: 100, Quantity: 1}},
+		TotalPrice:   1100,
+		Address:      model.OrderAddress{Street: "Lenina", House: "1"},
+	}
+
+	dRepo.EXPECT().SaveSnapshot(gomock.Any(), "o1", model.OrderSnapshot{
+		RestaurantId: "r1",
+		Address:      model.OrderAddress{Street: "Lenina", House: "1"},
+		TotalPrice:   1100,
+		ItemsCount:   3,
+	}).Return(nil)
+
+	require.NoError(t, service.SaveOrderSnapshot(context.Background(), order))
+}
+
+func TestSaveOrderSnapshot_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dRepo := mocks.NewMockDeliveryRepository(ctrl)
+	service := NewAssignService(mocks.NewMockTransactionManager(ctrl), dRepo, mocks.NewMockCourierRepository(ctrl), mocks.NewMockTransportFactory(ctrl))
+
+	dRepo.EXPECT().SaveSnapshot(gomock.Any(), "o1", gomock.Any()).Return(delivery_repository.ErrNotFound)
+
+	err := service.SaveOrderSnapshot(context.Background(), &model.Order{Id: "o1"})
+	require.ErrorIs(t, err, ErrNotFoundOrder)
+}
+
+type batchMocks struct {
+	tx        *mocks.MockTransactionManager
+	dRepo     *mocks.MockDeliveryRepository
+	cRepo     *mocks.MockCourierRepository
+	transport *mocks.MockTransportFactory
+	ctrl      *gomock.Controller
+}
+
+func newBatchService(t *testing.T) (*AssignService, batchMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	m := batchMocks{
+		tx:        mocks.NewMockTransactionManager(ctrl),
+		dRepo:     mocks.NewMockDeliveryRepository(ctrl),
+		cRepo:     mocks.NewMockCourierRepository(ctrl),
+		transport: mocks.NewMockTransportFactory(ctrl),
+		ctrl:      ctrl,
+	}
+
+	return NewAssignService(m.tx, m.dRepo, m.cRepo, m.transport), m
+}
+
+func (m batchMocks) expectAssigned(orderId string, courierId int64, deadline time.Time) {
+	m.dRepo.EXPECT().GetByOrderId(gomock.Any(), orderId).Return(nil, delivery_repository.ErrNotFound)
+	m.dRepo.EXPECT().GetCourierWithMinimumOrder(gomock.Any()).Return(courierId, nil)
+	m.cRepo.EXPECT().Get(gomock.Any(), courierId).Return(&model.CourierDB{Id: courierId, Transport: model.Car}, nil)
+
+	tr := mocks.NewMockTransport(m.ctrl)
+	tr.EXPECT().Deadline().Return(deadline)
+	m.transport.EXPECT().Get(model.Car).Return(tr)
+
+	m.dRepo.EXPECT().Create(gomock.Any(), orderId, courierId, deadline).Return(nil)
+	m.cRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+}
+
+func (m batchMocks) expectNobodyAvailable(orderId string) {
+	m.dRepo.EXPECT().GetByOrderId(gomock.Any(), orderId).Return(nil, delivery_repository.ErrNotFound)
+	m.dRepo.EXPECT().GetCourierWithMinimumOrder(gomock.Any()).Return(int64(0), delivery_repository.ErrNoneCourier)
+}
+
+func TestAssignCouriers_Partial(t *testing.T) {
+	t.Parallel()
+
+	service, m := newBatchService(t)
+
+	m.tx.EXPECT().
+		Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			return fn(parent)
+		}).
+		Times(3)
+
+	deadline := time.Now().Add(15 * time.Minute).UTC()
+	m.expectAssigned("o-1", 1, deadline)
+	m.expectNobodyAvailable("o-2")
+
+	results, err := service.AssignCouriers(context.Background(), []string{"o-1", "o-2"}, model.BatchPartial)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, "o-1", results[0].OrderId)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, &model.AssignCourier{CourierId: 1, OrderId: "o-1", Transport: model.Car, Deadline: deadline}, results[0].Assign)
+
+	require.Equal(t, "o-2", results[1].OrderId)
+	require.Nil(t, results[1].Assign)
+	require.ErrorIs(t, results[1].Err, ErrNotAvailableCourier)
+}
+
+func TestAssignCouriers_StopsWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	for _, policy := range []model.BatchPolicy{model.BatchPartial, model.BatchAllOrNothing} {
+		t.Run(string(policy), func(t *testing.T) {
+			t.Parallel()
+
+			service, m := newBatchService(t)
+
+			var rolledBack error
+			m.tx.EXPECT().
+				Begin(gomock.Any(), true, gomock.Any()).
+				DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+					rolledBack = fn(parent)
+					return rolledBack
+				}).
+				AnyTimes()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// o-1 is assigned and the caller goes away before o-2 starts.
+			m.dRepo.EXPECT().GetByOrderId(gomock.Any(), "o-1").Return(nil, delivery_repository.ErrNotFound)
+			m.dRepo.EXPECT().GetCourierWithMinimumOrder(gomock.Any()).Return(int64(1), nil)
+			m.cRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.CourierDB{Id: 1, Transport: model.Car}, nil)
+
+			tr := mocks.NewMockTransport(m.ctrl)
+			tr.EXPECT().Deadline().Return(time.Now().Add(15 * time.Minute))
+			m.transport.EXPECT().Get(model.Car).Return(tr)
+
+			m.dRepo.EXPECT().Create(gomock.Any(), "o-1", int64(1), gomock.Any()).Return(nil)
+			m.cRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *model.UpdateCourierRequest) error {
+				cancel()
+				return nil
+			})
+
+			results, err := service.AssignCouriers(ctx, []string{"o-1", "o-2"}, policy)
+			require.ErrorIs(t, err, context.Canceled)
+			require.Nil(t, results)
+			require.ErrorIs(t, rolledBack, context.Canceled)
+		})
+	}
+}
+
+func TestAssignCouriers_AllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	service, m := newBatchService(t)
+
+	var rolledBack error
+	m.tx.EXPECT().
+		Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			rolledBack = fn(parent)
+			return rolledBack
+		})
+
+	m.expectAssigned("o-1", 1, time.Now().Add(15*time.Minute).UTC())
+	m.expectNobodyAvailable("o-2")
+
+	results, err := service.AssignCouriers(context.Background(), []string{"o-1", "o-2", "o-3"}, model.BatchAllOrNothing)
+	require.ErrorIs(t, err, ErrBatchAborted)
+	require.ErrorIs(t, rolledBack, ErrNotAvailableCourier)
+	require.Len(t, results, 3)
+
+	require.Nil(t, results[0].Assign)
+	require.ErrorIs(t, results[0].Err, ErrBatchAborted)
+	require.ErrorIs(t, results[1].Err, ErrNotAvailableCourier)
+	require.ErrorIs(t, results[2].Err, ErrBatchAborted)
+}
+
+func TestAssignCouriers_AllOrNothing_Success(t *testing.T) {
+	t.Parallel()
+
+	service, m := newBatchService(t)
+
+	m.tx.EXPECT().
+		Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			return fn(parent)
+		})
+
+	deadline := time.Now().Add(15 * time.Minute).UTC()
+	m.expectAssigned("o-1", 1, deadline)
+	m.expectAssigned("o-2", 2, deadline)
+
+	results, err := service.AssignCouriers(context.Background(), []string{"o-1", "o-2"}, model.BatchAllOrNothing)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), results[0].Assign.CourierId)
+	require.Equal(t, int64(2), results[1].Assign.CourierId)
+}
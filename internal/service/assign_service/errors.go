@@ -10,4 +10,8 @@ var (
 	ErrNotAssignedCourier error = errors.New("no one courier associated with this order")
 
 	ErrNotFoundOrder = errors.New("not found order")
+
+	ErrInvalidTransition = errors.New("delivery status transition is not allowed")
+
+	ErrBatchAborted = errors.New("batch rolled back because an order could not be assigned")
 )
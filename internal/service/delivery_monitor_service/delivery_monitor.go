@@ -2,38 +2,75 @@ package delivery_monitor_service
 
 import (
 	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
 	"course-go-avito-SitnikovArtem06/internal/repository/courier_repository"
 	"course-go-avito-SitnikovArtem06/internal/repository/delivery_repository"
+	"course-go-avito-SitnikovArtem06/internal/tx"
+	"sync/atomic"
 	"time"
 )
 
 type DeliveryMonitorService struct {
-	dRepo    delivery_repository.DeliveryRepository
-	cRepo    courier_repository.CourierRepository
-	interval time.Duration
+	txManager tx.TransactionManager
+	dRepo     delivery_repository.DeliveryRepository
+	cRepo     courier_repository.CourierRepository
+	events    eventStore
+	interval  time.Duration
+	lastTick  atomic.Int64
 }
 
-func NewDeliveryMonitorService(dRepo delivery_repository.DeliveryRepository, cRepo courier_repository.CourierRepository, interval time.Duration) *DeliveryMonitorService {
-	return &DeliveryMonitorService{dRepo: dRepo, cRepo: cRepo, interval: interval}
+func NewDeliveryMonitorService(txManager tx.TransactionManager, dRepo delivery_repository.DeliveryRepository, cRepo courier_repository.CourierRepository, events eventStore, interval time.Duration) *DeliveryMonitorService {
+	return &DeliveryMonitorService{txManager: txManager, dRepo: dRepo, cRepo: cRepo, events: events, interval: interval}
 }
 
+// handleTick frees the couriers whose deliveries are all past their deadline
+// and appends an event for each of them in the same transaction.
 func (s *DeliveryMonitorService) handleTick(ctx context.Context) error {
-	ids, err := s.dRepo.GetExpiredOrders(ctx)
-	if err != nil {
-		return err
-	}
+	return s.txManager.Begin(ctx, true, func(ctx context.Context) error {
 
-	if len(ids) > 0 {
-		if err := s.cRepo.UpdateAllExpiredCourier(ctx, ids); err != nil {
+		ids, err := s.dRepo.GetExpiredOrders(ctx)
+		if err != nil {
 			return err
 		}
-	}
 
-	return nil
+		if len(ids) == 0 {
+			return nil
+		}
+
+		released, err := s.cRepo.UpdateAllExpiredCourier(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range released {
+			err := s.events.Append(ctx, events.Event{
+				Type:          events.TypeCourierUpdated,
+				CourierId:     c.Id,
+				CourierStatus: c.Status.String(),
+				Transport:     c.Transport.String(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// LastTick returns when the monitor last completed a tick, or the zero time
+// before it has started.
+func (s *DeliveryMonitorService) LastTick() time.Time {
+	if nanos := s.lastTick.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
 }
 
 func (s *DeliveryMonitorService) MonitorDeadline(ctx context.Context) error {
 
+	s.lastTick.Store(time.Now().UnixNano())
+
 	ticker := time.NewTicker(s.interval)
 
 	defer ticker.Stop()
@@ -48,6 +85,7 @@ func (s *DeliveryMonitorService) MonitorDeadline(ctx context.Context) error {
 			if err := s.handleTick(ctx); err != nil {
 				return err
 			}
+			s.lastTick.Store(time.Now().UnixNano())
 
 		}
 	}
@@ -2,6 +2,9 @@
 
 import (
 	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	eventmocks "course-go-avito-SitnikovArtem06/internal/service/delivery_monitor_service/mocks"
 	"course-go-avito-SitnikovArtem06/internal/service/mocks"
 	"errors"
 	"github.com/stretchr/testify/require"
@@ -10,144 +13,157 @@ import (
 	"time"
 )
 
-func TestHandleTick_NoExpiredOrders(t *testing.T) {
-	t.Parallel()
+type testMonitor struct {
+	service *DeliveryMonitorService
+	dRepo   *mocks.MockDeliveryRepository
+	cRepo   *mocks.MockCourierRepository
+	events  *eventmocks.MockeventStore
+}
+
+func newTestMonitor(t *testing.T, interval time.Duration) testMonitor {
+	t.Helper()
 
 	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
 
-	dRepo := mocks.NewMockDeliveryRepository(ctrl)
-	cRepo := mocks.NewMockCourierRepository(ctrl)
+	tx := mocks.NewMockTransactionManager(ctrl)
+	tx.EXPECT().Begin(gomock.Any(), true, gomock.Any()).
+		DoAndReturn(func(parent context.Context, withTx bool, fn func(ctx context.Context) error) error {
+			return fn(parent)
+		}).
+		AnyTimes()
 
-	service := &DeliveryMonitorService{
-		dRepo:    dRepo,
-		cRepo:    cRepo,
-		interval: 0,
+	m := testMonitor{
+		dRepo:  mocks.NewMockDeliveryRepository(ctrl),
+		cRepo:  mocks.NewMockCourierRepository(ctrl),
+		events: eventmocks.NewMockeventStore(ctrl),
 	}
+	m.service = NewDeliveryMonitorService(tx, m.dRepo, m.cRepo, m.events, interval)
+	return m
+}
 
-	dRepo.EXPECT().
+func TestHandleTick_NoExpiredOrders(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMonitor(t, 0)
+
+	m.dRepo.EXPECT().
 		GetExpiredOrders(gomock.Any()).
 		Return([]int64{}, nil).
 		Times(1)
 
-	cRepo.EXPECT().
+	m.cRepo.EXPECT().
 		UpdateAllExpiredCourier(gomock.Any(), gomock.Any()).
 		Times(0)
 
-	err := service.handleTick(context.Background())
+	err := m.service.handleTick(context.Background())
+
 	require.NoError(t, err)
 }
 
 func TestHandleTick_WithExpiredOrders(t *testing.T) {
 	t.Parallel()
 
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	dRepo := mocks.NewMockDeliveryRepository(ctrl)
-	cRepo := mocks.NewMockCourierRepository(ctrl)
-
-	service := &DeliveryMonitorService{
-		dRepo:    dRepo,
-		cRepo:    cRepo,
-		interval: 0,
-	}
+	m := newTestMonitor(t, 0)
 
 	ids := []int64{1, 2, 3}
 
-	dRepo.EXPECT().
+	m.dRepo.EXPECT().
 		GetExpiredOrders(gomock.Any()).
 		Return(ids, nil).
 		Times(1)
 
-	cRepo.EXPECT().
+	m.cRepo.EXPECT().
 		UpdateAllExpiredCourier(gomock.Any(), ids).
+		Return([]model.CourierDB{{Id: 2, Status: model.CourierStatusAvailable, Transport: model.Car}}, nil).
+		Times(1)
+
+	m.events.EXPECT().
+		Append(gomock.Any(), events.Event{Type: events.TypeCourierUpdated, CourierId: 2, CourierStatus: "available", Transport: "car"}).
 		Return(nil).
 		Times(1)
 
-	err := service.handleTick(context.Background())
+	err := m.service.handleTick(context.Background())
+
 	require.NoError(t, err)
 }
 
 func TestHandleTick_DBError_GetExpiredOrders(t *testing.T) {
 	t.Parallel()
 
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	dRepo := mocks.NewMockDeliveryRepository(ctrl)
-	cRepo := mocks.NewMockCourierRepository(ctrl)
+	m := newTestMonitor(t, 0)
 
-	service := &DeliveryMonitorService{
-		dRepo:    dRepo,
-		cRepo:    cRepo,
-		interval: 0,
-	}
-	context.Background()
 	dbErr := errors.New("db error")
 
-	dRepo.EXPECT().
+	m.dRepo.EXPECT().
 		GetExpiredOrders(gomock.Any()).
 		Return(nil, dbErr).
 		Times(1)
 
-	cRepo.EXPECT().
+	m.cRepo.EXPECT().
 		UpdateAllExpiredCourier(gomock.Any(), gomock.Any()).
 		Times(0)
 
-	err := service.handleTick(context.Background())
+	err := m.service.handleTick(context.Background())
+
 	require.ErrorIs(t, err, dbErr)
 }
 
 func TestHandleTick_DBError_UpdateAllExpiredCourier(t *testing.T) {
 	t.Parallel()
 
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	dRepo := mocks.NewMockDeliveryRepository(ctrl)
-	cRepo := mocks.NewMockCourierRepository(ctrl)
-
-	service := &DeliveryMonitorService{
-		dRepo:    dRepo,
-		cRepo:    cRepo,
-		interval: 0,
-	}
+	m := newTestMonitor(t, 0)
 
 	ids := []int64{1, 2, 3}
 	wantErr := errors.New("db error")
 
-	dRepo.EXPECT().
+	m.dRepo.EXPECT().
 		GetExpiredOrders(gomock.Any()).
 		Return(ids, nil).
 		Times(1)
 
-	cRepo.EXPECT().
+	m.cRepo.EXPECT().
 		UpdateAllExpiredCourier(gomock.Any(), ids).
-		Return(wantErr).
+		Return(nil, wantErr).
 		Times(1)
 
-	err := service.handleTick(context.Background())
+	err := m.service.handleTick(context.Background())
+
 	require.ErrorIs(t, err, wantErr)
 }
 
-func TestMonitorDeadline_ContextTimeout(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestHandleTick_EventErrorFailsTheTick(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMonitor(t, 0)
+
+	wantErr := errors.New("append error")
 
-	dRepo := mocks.NewMockDeliveryRepository(ctrl)
-	cRepo := mocks.NewMockCourierRepository(ctrl)
+	m.dRepo.EXPECT().
+		GetExpiredOrders(gomock.Any()).
+		Return([]int64{1}, nil)
+
+	m.cRepo.EXPECT().
+		UpdateAllExpiredCourier(gomock.Any(), []int64{1}).
+		Return([]model.CourierDB{{Id: 1, Status: model.CourierStatusAvailable}}, nil)
 
-	interval := time.Millisecond
+	m.events.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		Return(wantErr)
 
-	service := NewDeliveryMonitorService(dRepo, cRepo, interval)
+	err := m.service.handleTick(context.Background())
 
-	dRepo.EXPECT().
+	require.ErrorIs(t, err, wantErr)
+}
+
+func TestMonitorDeadline_ContextTimeout(t *testing.T) {
+	m := newTestMonitor(t, time.Millisecond)
+
+	m.dRepo.EXPECT().
 		GetExpiredOrders(gomock.Any()).
 		Return([]int64{}, nil).
 		AnyTimes()
 
-	cRepo.EXPECT().
+	m.cRepo.EXPECT().
 		UpdateAllExpiredCourier(gomock.Any(), gomock.Any()).
 		Times(0)
 
@@ -155,8 +171,9 @@ func TestMonitorDeadline_ContextTimeout(t *testing.T) {
 	defer cancel()
 
 	errCh := make(chan error, 1)
+
 	go func() {
-		errCh <- service.MonitorDeadline(ctx)
+		errCh <- m.service.MonitorDeadline(ctx)
 	}()
 
 	err := <-errCh
@@ -166,32 +183,23 @@ func TestMonitorDeadline_ContextTimeout(t *testing.T) {
 func TestMonitorDeadline_HandleTickError(t *testing.T) {
 	t.Parallel()
 
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	dRepo := mocks.NewMockDeliveryRepository(ctrl)
-	cRepo := mocks.NewMockCourierRepository(ctrl)
-
-	interval := time.Millisecond
-
-	service := NewDeliveryMonitorService(dRepo, cRepo, interval)
+	m := newTestMonitor(t, time.Millisecond)
 
 	wantErr := errors.New("get expired error")
 
-	dRepo.EXPECT().
+	m.dRepo.EXPECT().
 		GetExpiredOrders(gomock.Any()).
 		Return(nil, wantErr).
 		Times(1)
 
-	cRepo.EXPECT().
+	m.cRepo.EXPECT().
 		UpdateAllExpiredCourier(gomock.Any(), gomock.Any()).
 		Times(0)
 
-	ctx := context.Background()
-
 	errCh := make(chan error, 1)
+
 	go func() {
-		errCh <- service.MonitorDeadline(ctx)
+		errCh <- m.service.MonitorDeadline(context.Background())
 	}()
 
 	err := <-errCh
@@ -0,0 +1,10 @@
+package delivery_monitor_service
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/events"
+)
+
+type eventStore interface {
+	Append(ctx context.Context, e events.Event) error
+}
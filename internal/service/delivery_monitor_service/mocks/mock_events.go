@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/delivery_monitor_service/events_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/service/delivery_monitor_service/events_contract.go -destination internal/service/delivery_monitor_service/mocks/mock_events.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	events "course-go-avito-SitnikovArtem06/internal/events"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockeventStore is a mock of eventStore interface.
+type MockeventStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockeventStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockeventStoreMockRecorder is the mock recorder for MockeventStore.
+type MockeventStoreMockRecorder struct {
+	mock *MockeventStore
+}
+
+// NewMockeventStore creates a new mock instance.
+func NewMockeventStore(ctrl *gomock.Controller) *MockeventStore {
+	mock := &MockeventStore{ctrl: ctrl}
+	mock.recorder = &MockeventStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockeventStore) EXPECT() *MockeventStoreMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockeventStore) Append(ctx context.Context, e events.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockeventStoreMockRecorder) Append(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockeventStore)(nil).Append), ctx, e)
+}
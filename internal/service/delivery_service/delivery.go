@@ -0,0 +1,96 @@
+package delivery_service
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/repository/courier_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/delivery_repository"
+	"errors"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// DeliveryService is the read side of deliveries, for dispatch support.
+type DeliveryService struct {
+	deliveryRepo delivery_repository.DeliveryRepository
+	courierRepo  courier_repository.CourierRepository
+}
+
+func NewDeliveryService(dRepo delivery_repository.DeliveryRepository, cRepo courier_repository.CourierRepository) *DeliveryService {
+	return &DeliveryService{deliveryRepo: dRepo, courierRepo: cRepo}
+}
+
+func (s *DeliveryService) GetDelivery(ctx context.Context, orderId string) (*model.Delivery, error) {
+
+	delivery, err := s.deliveryRepo.GetDelivery(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, delivery_repository.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ListDeliveries returns one page of deliveries matching filter, newest
+// first. Its After and Limit are taken from pageToken and limit; a limit
+// outside 1..MaxPageSize falls back to DefaultPageSize or MaxPageSize.
+func (s *DeliveryService) ListDeliveries(ctx context.Context, filter model.DeliveryFilter, limit int, pageToken string) (*model.DeliveriesPage, error) {
+
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return nil, ErrInvalidStatus
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, ErrInvalidRange
+	}
+
+	if pageToken != "" {
+		cursor, err := DecodePageToken(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = &cursor
+	}
+
+	switch {
+	case limit <= 0:
+		limit = DefaultPageSize
+	case limit > MaxPageSize:
+		limit = MaxPageSize
+	}
+	// One extra row tells whether there is a next page.
+	filter.Limit = limit + 1
+
+	deliveries, err := s.deliveryRepo.FindDeliveries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.DeliveriesPage{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		page.NextPageToken = EncodePageToken(&page.Deliveries[limit-1])
+	}
+
+	return page, nil
+}
+
+// ListCourierDeliveries is ListDeliveries for one courier, which must exist.
+func (s *DeliveryService) ListCourierDeliveries(ctx context.Context, courierId int64, filter model.DeliveryFilter, limit int, pageToken string) (*model.DeliveriesPage, error) {
+
+	if _, err := s.courierRepo.Get(ctx, courierId); err != nil {
+		if errors.Is(err, courier_repository.ErrNotFoundRepo) {
+			return nil, ErrCourierNotFound
+		}
+		return nil, err
+	}
+
+	filter.CourierId = courierId
+	return s.ListDeliveries(ctx, filter, limit, pageToken)
+}
@@ -0,0 +1,128 @@
+package delivery_service
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/repository/courier_repository"
+	"course-go-avito-SitnikovArtem06/internal/repository/delivery_repository"
+	"course-go-avito-SitnikovArtem06/internal/service/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func deliveries(n int) []model.Delivery {
+	t0 := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	out := make([]model.Delivery, 0, n)
+	for i := n; i > 0; i-- {
+		out = append(out, model.Delivery{Id: int64(i), AssignedAt: t0.Add(time.Duration(i) * time.Minute)})
+	}
+	return out
+}
+
+func TestGetDelivery_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	dRepo := mocks.NewMockDeliveryRepository(ctrl)
+	dRepo.EXPECT().GetDelivery(gomock.Any(), "o-1").Return(nil, delivery_repository.ErrNotFound)
+
+	_, err := NewDeliveryService(dRepo, nil).GetDelivery(context.Background(), "o-1")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestListDeliveries_Pages(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	dRepo := mocks.NewMockDeliveryRepository(ctrl)
+	s := NewDeliveryService(dRepo, nil)
+
+	all := deliveries(3)
+	statuses := []model.DeliveryStatus{model.DeliveryAssigned}
+
+	dRepo.EXPECT().FindDeliveries(gomock.Any(), model.DeliveryFilter{Statuses: statuses, Limit: 3}).Return(all, nil)
+
+	first, err := s.ListDeliveries(context.Background(), model.DeliveryFilter{Statuses: statuses}, 2, "")
+	require.NoError(t, err)
+	require.Equal(t, all[:2], first.Deliveries)
+	require.NotEmpty(t, first.NextPageToken)
+
+	cursor, err := DecodePageToken(first.NextPageToken)
+	require.NoError(t, err)
+	require.Equal(t, model.DeliveryCursor{AssignedAt: all[1].AssignedAt, Id: all[1].Id}, cursor)
+
+	dRepo.EXPECT().FindDeliveries(gomock.Any(), model.DeliveryFilter{Statuses: statuses, After: &cursor, Limit: 3}).Return(all[2:], nil)
+
+	second, err := s.ListDeliveries(context.Background(), model.DeliveryFilter{Statuses: statuses}, 2, first.NextPageToken)
+	require.NoError(t, err)
+	require.Equal(t, all[2:], second.Deliveries)
+	require.Empty(t, second.NextPageToken)
+}
+
+func TestListDeliveries_Limits(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	dRepo := mocks.NewMockDeliveryRepository(ctrl)
+	s := NewDeliveryService(dRepo, nil)
+
+	dRepo.EXPECT().FindDeliveries(gomock.Any(), model.DeliveryFilter{Limit: DefaultPageSize + 1}).Return(nil, nil)
+	dRepo.EXPECT().FindDeliveries(gomock.Any(), model.DeliveryFilter{Limit: MaxPageSize + 1}).Return(nil, nil)
+
+	_, err := s.ListDeliveries(context.Background(), model.DeliveryFilter{}, 0, "")
+	require.NoError(t, err)
+	_, err = s.ListDeliveries(context.Background(), model.DeliveryFilter{}, MaxPageSize*2, "")
+	require.NoError(t, err)
+}
+
+func TestListDeliveries_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		filter model.DeliveryFilter
+		token  string
+		want   error
+	}{
+		{"status", model.DeliveryFilter{Statuses: []model.DeliveryStatus{"lost"}}, "", ErrInvalidStatus},
+		{"range", model.DeliveryFilter{From: now, To: now}, "", ErrInvalidRange},
+		{"token", model.DeliveryFilter{}, "not a token", ErrInvalidPageToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			s := NewDeliveryService(mocks.NewMockDeliveryRepository(ctrl), nil)
+
+			_, err := s.ListDeliveries(context.Background(), tt.filter, 10, tt.token)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestListCourierDeliveries(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	dRepo := mocks.NewMockDeliveryRepository(ctrl)
+	cRepo := mocks.NewMockCourierRepository(ctrl)
+	s := NewDeliveryService(dRepo, cRepo)
+
+	cRepo.EXPECT().Get(gomock.Any(), int64(2)).Return(nil, courier_repository.ErrNotFoundRepo)
+	_, err := s.ListCourierDeliveries(context.Background(), 2, model.DeliveryFilter{}, 10, "")
+	require.ErrorIs(t, err, ErrCourierNotFound)
+
+	cRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(&model.CourierDB{Id: 1}, nil)
+	dRepo.EXPECT().FindDeliveries(gomock.Any(), model.DeliveryFilter{CourierId: 1, Limit: 11}).Return(deliveries(1), nil)
+
+	page, err := s.ListCourierDeliveries(context.Background(), 1, model.DeliveryFilter{}, 10, "")
+	require.NoError(t, err)
+	require.Len(t, page.Deliveries, 1)
+}
@@ -0,0 +1,15 @@
+package delivery_service
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("delivery not found")
+
+	ErrCourierNotFound = errors.New("courier not found")
+
+	ErrInvalidStatus = errors.New("invalid delivery status")
+
+	ErrInvalidRange = errors.New("from must be before to")
+
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
@@ -0,0 +1,37 @@
+package delivery_service
+
+import (
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodePageToken turns the last delivery of a page into an opaque token.
+func EncodePageToken(last *model.Delivery) string {
+	raw := strconv.FormatInt(last.AssignedAt.UnixNano(), 10) + ":" + strconv.FormatInt(last.Id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodePageToken(token string) (model.DeliveryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return model.DeliveryCursor{}, ErrInvalidPageToken
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return model.DeliveryCursor{}, ErrInvalidPageToken
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return model.DeliveryCursor{}, ErrInvalidPageToken
+	}
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return model.DeliveryCursor{}, ErrInvalidPageToken
+	}
+
+	return model.DeliveryCursor{AssignedAt: time.Unix(0, n).UTC(), Id: i}, nil
+}
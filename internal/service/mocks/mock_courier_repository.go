@@ -116,11 +116,12 @@ func (mr *MockCourierRepositoryMockRecorder) Update(ctx, req any) *gomock.Call {
 }
 
 // UpdateAllExpiredCourier mocks base method.
-func (m *MockCourierRepository) UpdateAllExpiredCourier(ctx context.Context, ids []int64) error {
+func (m *MockCourierRepository) UpdateAllExpiredCourier(ctx context.Context, ids []int64) ([]model.CourierDB, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateAllExpiredCourier", ctx, ids)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]model.CourierDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateAllExpiredCourier indicates an expected call of UpdateAllExpiredCourier.
@@ -71,6 +71,21 @@ func (mr *MockDeliveryRepositoryMockRecorder) Delete(ctx, orderId any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeliveryRepository)(nil).Delete), ctx, orderId)
 }
 
+// FindDeliveries mocks base method.
+func (m *MockDeliveryRepository) FindDeliveries(ctx context.Context, filter model.DeliveryFilter) ([]model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveries", ctx, filter)
+	ret0, _ := ret[0].([]model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveries indicates an expected call of FindDeliveries.
+func (mr *MockDeliveryRepositoryMockRecorder) FindDeliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveries", reflect.TypeOf((*MockDeliveryRepository)(nil).FindDeliveries), ctx, filter)
+}
+
 // GetByOrderId mocks base method.
 func (m *MockDeliveryRepository) GetByOrderId(ctx context.Context, orderID string) (*model.DeliveryDB, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,21 @@ func (mr *MockDeliveryRepositoryMockRecorder) GetCourierWithMinimumOrder(ctx any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierWithMinimumOrder", reflect.TypeOf((*MockDeliveryRepository)(nil).GetCourierWithMinimumOrder), ctx)
 }
 
+// GetDelivery mocks base method.
+func (m *MockDeliveryRepository) GetDelivery(ctx context.Context, orderId string) (*model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, orderId)
+	ret0, _ := ret[0].(*model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockDeliveryRepositoryMockRecorder) GetDelivery(ctx, orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockDeliveryRepository)(nil).GetDelivery), ctx, orderId)
+}
+
 // GetExpiredOrders mocks base method.
 func (m *MockDeliveryRepository) GetExpiredOrders(ctx context.Context) ([]int64, error) {
 	m.ctrl.T.Helper()
@@ -115,3 +145,31 @@ func (mr *MockDeliveryRepositoryMockRecorder) GetExpiredOrders(ctx any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredOrders", reflect.TypeOf((*MockDeliveryRepository)(nil).GetExpiredOrders), ctx)
 }
+
+// SaveSnapshot mocks base method.
+func (m *MockDeliveryRepository) SaveSnapshot(ctx context.Context, orderId string, snapshot model.OrderSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", ctx, orderId, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockDeliveryRepositoryMockRecorder) SaveSnapshot(ctx, orderId, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockDeliveryRepository)(nil).SaveSnapshot), ctx, orderId, snapshot)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockDeliveryRepository) UpdateStatus(ctx context.Context, orderId string, status model.DeliveryStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, orderId, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockDeliveryRepositoryMockRecorder) UpdateStatus(ctx, orderId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockDeliveryRepository)(nil).UpdateStatus), ctx, orderId, status)
+}
@@ -2,9 +2,15 @@ package order_changed_service
 
 import (
 	"context"
-	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/model"
 )
 
 type orderGateway interface {
-	GetOrder(ctx context.Context, orderID string) (*order.OrderDto, error)
+	GetOrder(ctx context.Context, orderID string) (*model.Order, error)
+}
+
+// invalidator is implemented by caching gateways that can drop a cached
+// order.
+type invalidator interface {
+	Invalidate(orderID string)
 }
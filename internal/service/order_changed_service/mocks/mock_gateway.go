@@ -11,7 +11,7 @@ package mocks
 
 import (
 	context "context"
-	order "course-go-avito-SitnikovArtem06/internal/gateway/order"
+	model "course-go-avito-SitnikovArtem06/internal/model"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -42,10 +42,10 @@ func (m *MockorderGateway) EXPECT() *MockorderGatewayMockRecorder {
 }
 
 // GetOrder mocks base method.
-func (m *MockorderGateway) GetOrder(ctx context.Context, orderID string) (*order.OrderDto, error) {
+func (m *MockorderGateway) GetOrder(ctx context.Context, orderID string) (*model.Order, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetOrder", ctx, orderID)
-	ret0, _ := ret[0].(*order.OrderDto)
+	ret0, _ := ret[0].(*model.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -55,3 +55,39 @@ func (mr *MockorderGatewayMockRecorder) GetOrder(ctx, orderID any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockorderGateway)(nil).GetOrder), ctx, orderID)
 }
+
+// Mockinvalidator is a mock of invalidator interface.
+type Mockinvalidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockinvalidatorMockRecorder
+	isgomock struct{}
+}
+
+// MockinvalidatorMockRecorder is the mock recorder for Mockinvalidator.
+type MockinvalidatorMockRecorder struct {
+	mock *Mockinvalidator
+}
+
+// NewMockinvalidator creates a new mock instance.
+func NewMockinvalidator(ctrl *gomock.Controller) *Mockinvalidator {
+	mock := &Mockinvalidator{ctrl: ctrl}
+	mock.recorder = &MockinvalidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockinvalidator) EXPECT() *MockinvalidatorMockRecorder {
+	return m.recorder
+}
+
+// Invalidate mocks base method.
+func (m *Mockinvalidator) Invalidate(orderID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Invalidate", orderID)
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockinvalidatorMockRecorder) Invalidate(orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*Mockinvalidator)(nil).Invalidate), orderID)
+}
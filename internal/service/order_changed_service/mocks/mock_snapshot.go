@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/order_changed_service/snapshot_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/service/order_changed_service/snapshot_contract.go -destination internal/service/order_changed_service/mocks/mock_snapshot.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "course-go-avito-SitnikovArtem06/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockorderSnapshots is a mock of orderSnapshots interface.
+type MockorderSnapshots struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderSnapshotsMockRecorder
+	isgomock struct{}
+}
+
+// MockorderSnapshotsMockRecorder is the mock recorder for MockorderSnapshots.
+type MockorderSnapshotsMockRecorder struct {
+	mock *MockorderSnapshots
+}
+
+// NewMockorderSnapshots creates a new mock instance.
+func NewMockorderSnapshots(ctrl *gomock.Controller) *MockorderSnapshots {
+	mock := &MockorderSnapshots{ctrl: ctrl}
+	mock.recorder = &MockorderSnapshotsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderSnapshots) EXPECT() *MockorderSnapshotsMockRecorder {
+	return m.recorder
+}
+
+// SaveOrderSnapshot mocks base method.
+func (m *MockorderSnapshots) SaveOrderSnapshot(ctx context.Context, order *model.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrderSnapshot", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrderSnapshot indicates an expected call of SaveOrderSnapshot.
+func (mr *MockorderSnapshotsMockRecorder) SaveOrderSnapshot(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrderSnapshot", reflect.TypeOf((*MockorderSnapshots)(nil).SaveOrderSnapshot), ctx, order)
+}
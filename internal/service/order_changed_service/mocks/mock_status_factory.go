@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	model "course-go-avito-SitnikovArtem06/internal/model"
 	order_status_factory "course-go-avito-SitnikovArtem06/internal/service/order_status_factory"
 	reflect "reflect"
 
@@ -42,17 +43,17 @@ func (m *MockOrderStatus) EXPECT() *MockOrderStatusMockRecorder {
 }
 
 // Do mocks base method.
-func (m *MockOrderStatus) Do(ctx context.Context, orderId string) error {
+func (m *MockOrderStatus) Do(ctx context.Context, order *model.Order) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Do", ctx, orderId)
+	ret := m.ctrl.Call(m, "Do", ctx, order)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Do indicates an expected call of Do.
-func (mr *MockOrderStatusMockRecorder) Do(ctx, orderId any) *gomock.Call {
+func (mr *MockOrderStatusMockRecorder) Do(ctx, order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockOrderStatus)(nil).Do), ctx, orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockOrderStatus)(nil).Do), ctx, order)
 }
 
 // MockOrderStatusFactory is a mock of OrderStatusFactory interface.
@@ -3,26 +3,38 @@ package order_changed_service
 import (
 	"context"
 	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory"
+	"errors"
 )
 
 type OrderChangedService struct {
-	factory order_status_factory.OrderStatusFactory
-	gateway orderGateway
+	factory   order_status_factory.OrderStatusFactory
+	gateway   orderGateway
+	snapshots orderSnapshots
 }
 
-func NewOrderChangedService(f order_status_factory.OrderStatusFactory, gateway orderGateway) *OrderChangedService {
-	return &OrderChangedService{factory: f, gateway: gateway}
+// NewOrderChangedService builds the service. snapshots may be nil, in which
+// case order snapshots are not stored with deliveries.
+func NewOrderChangedService(f order_status_factory.OrderStatusFactory, gateway orderGateway, snapshots orderSnapshots) *OrderChangedService {
+	return &OrderChangedService{factory: f, gateway: gateway, snapshots: snapshots}
 }
 
 func (s *OrderChangedService) HandleStatusChanged(ctx context.Context, req model.ChangedStatus) error {
 
-	statusGateway, err := s.gateway.GetOrder(ctx, req.OrderID)
+	// Every status change makes a cached order stale. The event's
+	// created_at comes from the producer's clock and may be missing, so it
+	// cannot tell whether the cached copy predates the change.
+	if inv, ok := s.gateway.(invalidator); ok {
+		inv.Invalidate(req.OrderID)
+	}
+
+	order, err := s.gateway.GetOrder(ctx, req.OrderID)
 	if err != nil {
 		return err
 	}
 
-	if req.Status != statusGateway.Status {
+	if req.Status != order.Status {
 		return ErrMismatchStatus
 	}
 
@@ -32,5 +44,21 @@ func (s *OrderChangedService) HandleStatusChanged(ctx context.Context, req model
 		return nil
 	}
 
-	return status.Do(ctx, req.OrderID)
+	err = status.Do(ctx, order)
+	switch {
+	case s.snapshots != nil && req.Status == order_status_factory.StatusCreated && errors.Is(err, assign_service.ErrOrderAlreadyAssign):
+		// The order was assigned through the API, which stores no snapshot;
+		// its created event fills it in.
+	case err != nil:
+		return err
+	case s.snapshots == nil || req.Status == order_status_factory.StatusCreated:
+		// A created order gets its snapshot together with its delivery.
+		return nil
+	}
+
+	err = s.snapshots.SaveOrderSnapshot(ctx, order)
+	if errors.Is(err, assign_service.ErrNotFoundOrder) {
+		return nil
+	}
+	return err
 }
@@ -2,13 +2,14 @@ package order_changed_service
 
 import (
 	"context"
-	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"course-go-avito-SitnikovArtem06/internal/model"
 	"course-go-avito-SitnikovArtem06/internal/service/order_changed_service/mocks"
 	"errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestHandleStatusChanged_Success_CallsDo(t *testing.T) {
@@ -21,18 +22,20 @@ func TestHandleStatusChanged_Success_CallsDo(t *testing.T) {
 	gw := mocks.NewMockorderGateway(ctrl)
 	st := mocks.NewMockOrderStatus(ctrl)
 
-	svc := NewOrderChangedService(f, gw)
+	svc := NewOrderChangedService(f, gw, nil)
 
 	req := model.ChangedStatus{OrderID: "o1", Status: "created"}
 
+	o := &model.Order{Id: req.OrderID, Status: req.Status}
+
 	gw.EXPECT().
 		GetOrder(gomock.Any(), req.OrderID).
-		Return(&order.OrderDto{OrderID: req.OrderID, Status: req.Status}, nil)
+		Return(o, nil)
 
 	f.EXPECT().Get(req.Status).Return(st)
 
 	st.EXPECT().
-		Do(gomock.Any(), req.OrderID).
+		Do(gomock.Any(), o).
 		Return(nil)
 
 	err := svc.HandleStatusChanged(context.Background(), req)
@@ -48,13 +51,13 @@ func TestHandleStatusChanged_UnknownStatus(t *testing.T) {
 	f := mocks.NewMockOrderStatusFactory(ctrl)
 	gw := mocks.NewMockorderGateway(ctrl)
 
-	svc := NewOrderChangedService(f, gw)
+	svc := NewOrderChangedService(f, gw, nil)
 
 	req := model.ChangedStatus{OrderID: "o1", Status: "unknown"}
 
 	gw.EXPECT().
 		GetOrder(gomock.Any(), req.OrderID).
-		Return(&order.OrderDto{OrderID: req.OrderID, Status: req.Status}, nil)
+		Return(&model.Order{Id: req.OrderID, Status: req.Status}, nil)
 
 	f.EXPECT().Get(req.Status).Return(nil)
 
@@ -71,7 +74,7 @@ func TestHandleStatusChanged_GatewayError(t *testing.T) {
 	f := mocks.NewMockOrderStatusFactory(ctrl)
 	gw := mocks.NewMockorderGateway(ctrl)
 
-	svc := NewOrderChangedService(f, gw)
+	svc := NewOrderChangedService(f, gw, nil)
 
 	req := model.ChangedStatus{OrderID: "o1", Status: "created"}
 	Err := errors.New("gw error")
@@ -93,13 +96,13 @@ func TestHandleStatusChanged_StatusMismatch(t *testing.T) {
 	f := mocks.NewMockOrderStatusFactory(ctrl)
 	gw := mocks.NewMockorderGateway(ctrl)
 
-	svc := NewOrderChangedService(f, gw)
+	svc := NewOrderChangedService(f, gw, nil)
 
 	req := model.ChangedStatus{OrderID: "o1", Status: "created"}
 
 	gw.EXPECT().
 		GetOrder(gomock.Any(), req.OrderID).
-		Return(&order.OrderDto{OrderID: req.OrderID, Status: "cancelled"}, nil)
+		Return(&model.Order{Id: req.OrderID, Status: "cancelled"}, nil)
 
 	err := svc.HandleStatusChanged(context.Background(), req)
 	require.ErrorIs(t, err, ErrMismatchStatus)
@@ -115,21 +118,182 @@ func TestHandleStatusChanged_DoReturnsError(t *testing.T) {
 	gw := mocks.NewMockorderGateway(ctrl)
 	st := mocks.NewMockOrderStatus(ctrl)
 
-	svc := NewOrderChangedService(f, gw)
+	svc := NewOrderChangedService(f, gw, nil)
 
 	req := model.ChangedStatus{OrderID: "o1", Status: "completed"}
 	Err := errors.New("do error")
 
+	o := &model.Order{Id: req.OrderID, Status: req.Status}
+
 	gw.EXPECT().
 		GetOrder(gomock.Any(), req.OrderID).
-		Return(&order.OrderDto{OrderID: req.OrderID, Status: req.Status}, nil)
+		Return(o, nil)
 
 	f.EXPECT().Get(req.Status).Return(st)
 
 	st.EXPECT().
-		Do(gomock.Any(), req.OrderID).
+		Do(gomock.Any(), o).
 		Return(Err)
 
 	err := svc.HandleStatusChanged(context.Background(), req)
 	require.ErrorIs(t, err, Err)
 }
+
+type invalidatingGateway struct {
+	*mocks.MockorderGateway
+	invalidated []string
+}
+
+func (g *invalidatingGateway) Invalidate(orderID string) {
+	g.invalidated = append(g.invalidated, orderID)
+}
+
+func TestHandleStatusChanged_InvalidatesCache(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := mocks.NewMockOrderStatusFactory(ctrl)
+	gw := &invalidatingGateway{MockorderGateway: mocks.NewMockorderGateway(ctrl)}
+
+	svc := NewOrderChangedService(f, gw, nil)
+
+	// The second event carries no created_at and is invalidated all the same.
+	for _, req := range []model.ChangedStatus{
+		{OrderID: "o1", Status: "unknown", CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)},
+		{OrderID: "o2", Status: "unknown"},
+	} {
+		gw.EXPECT().
+			GetOrder(gomock.Any(), req.OrderID).
+			Return(&model.Order{Id: req.OrderID, Status: req.Status}, nil)
+
+		f.EXPECT().Get(req.Status).Return(nil)
+
+		require.NoError(t, svc.HandleStatusChanged(context.Background(), req))
+	}
+
+	require.Equal(t, []string{"o1", "o2"}, gw.invalidated)
+}
+
+
+func TestHandleStatusChanged_SavesSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := mocks.NewMockOrderStatusFactory(ctrl)
+	gw := mocks.NewMockorderGateway(ctrl)
+	st := mocks.NewMockOrderStatus(ctrl)
+	snapshots := mocks.NewMockorderSnapshots(ctrl)
+
+	svc := NewOrderChangedService(f, gw, snapshots)
+
+	req := model.ChangedStatus{OrderID: "o1", Status: "delivering"}
+	o := &model.Order{Id: req.OrderID, Status: req.Status, RestaurantId: "r1", TotalPrice: 1000}
+
+	gw.EXPECT().GetOrder(gomock.Any(), req.OrderID).Return(o, nil)
+	f.EXPECT().Get(req.Status).Return(st)
+	st.EXPECT().Do(gomock.Any(), o).Return(nil)
+	snapshots.EXPECT().SaveOrderSnapshot(gomock.Any(), o).Return(nil)
+
+	require.NoError(t, svc.HandleStatusChanged(context.Background(), req))
+}
+
+func TestHandleStatusChanged_CreatedSnapshotIsWrittenByDo(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := mocks.NewMockOrderStatusFactory(ctrl)
+	gw := mocks.NewMockorderGateway(ctrl)
+	st := mocks.NewMockOrderStatus(ctrl)
+
+	// No SaveOrderSnapshot is expected: the delivery and its snapshot are
+	// written together by Do.
+	svc := NewOrderChangedService(f, gw, mocks.NewMockorderSnapshots(ctrl))
+
+	req := model.ChangedStatus{OrderID: "o1", Status: "created"}
+	o := &model.Order{Id: req.OrderID, Status: req.Status, RestaurantId: "r1"}
+
+	gw.EXPECT().GetOrder(gomock.Any(), req.OrderID).Return(o, nil)
+	f.EXPECT().Get(req.Status).Return(st)
+	st.EXPECT().Do(gomock.Any(), o).Return(nil)
+
+	require.NoError(t, svc.HandleStatusChanged(context.Background(), req))
+}
+
+func TestHandleStatusChanged_CreatedBackfillsSnapshotOfAPIAssignment(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := mocks.NewMockOrderStatusFactory(ctrl)
+	gw := mocks.NewMockorderGateway(ctrl)
+	st := mocks.NewMockOrderStatus(ctrl)
+	snapshots := mocks.NewMockorderSnapshots(ctrl)
+
+	svc := NewOrderChangedService(f, gw, snapshots)
+
+	req := model.ChangedStatus{OrderID: "o1", Status: "created"}
+	o := &model.Order{Id: req.OrderID, Status: req.Status, RestaurantId: "r1"}
+
+	gw.EXPECT().GetOrder(gomock.Any(), req.OrderID).Return(o, nil)
+	f.EXPECT().Get(req.Status).Return(st)
+	st.EXPECT().Do(gomock.Any(), o).Return(assign_service.ErrOrderAlreadyAssign)
+	snapshots.EXPECT().SaveOrderSnapshot(gomock.Any(), o).Return(nil)
+
+	require.NoError(t, svc.HandleStatusChanged(context.Background(), req))
+}
+
+func TestHandleStatusChanged_SnapshotWithoutDeliveryIgnored(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := mocks.NewMockOrderStatusFactory(ctrl)
+	gw := mocks.NewMockorderGateway(ctrl)
+	st := mocks.NewMockOrderStatus(ctrl)
+	snapshots := mocks.NewMockorderSnapshots(ctrl)
+
+	svc := NewOrderChangedService(f, gw, snapshots)
+
+	req := model.ChangedStatus{OrderID: "o1", Status: "cancelled"}
+	o := &model.Order{Id: req.OrderID, Status: req.Status}
+
+	gw.EXPECT().GetOrder(gomock.Any(), req.OrderID).Return(o, nil)
+	f.EXPECT().Get(req.Status).Return(st)
+	st.EXPECT().Do(gomock.Any(), o).Return(nil)
+	snapshots.EXPECT().SaveOrderSnapshot(gomock.Any(), o).Return(assign_service.ErrNotFoundOrder)
+
+	require.NoError(t, svc.HandleStatusChanged(context.Background(), req))
+}
+
+func TestHandleStatusChanged_DoErrorSkipsSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := mocks.NewMockOrderStatusFactory(ctrl)
+	gw := mocks.NewMockorderGateway(ctrl)
+	st := mocks.NewMockOrderStatus(ctrl)
+	snapshots := mocks.NewMockorderSnapshots(ctrl)
+
+	svc := NewOrderChangedService(f, gw, snapshots)
+
+	req := model.ChangedStatus{OrderID: "o1", Status: "created"}
+	Err := errors.New("do error")
+
+	o := &model.Order{Id: req.OrderID, Status: req.Status}
+
+	gw.EXPECT().GetOrder(gomock.Any(), req.OrderID).Return(o, nil)
+	f.EXPECT().Get(req.Status).Return(st)
+	st.EXPECT().Do(gomock.Any(), o).Return(Err)
+
+	require.ErrorIs(t, svc.HandleStatusChanged(context.Background(), req), Err)
+}
@@ -0,0 +1,10 @@
+package order_changed_service
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+)
+
+type orderSnapshots interface {
+	SaveOrderSnapshot(ctx context.Context, order *model.Order) error
+}
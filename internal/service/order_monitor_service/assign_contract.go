@@ -6,5 +6,5 @@ import (
 )
 
 type assign interface {
-	AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error)
+	AssignOrder(ctx context.Context, order *model.Order) (*model.AssignCourier, error)
 }
@@ -0,0 +1,11 @@
+package order_monitor_service
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+)
+
+type cursorStore interface {
+	Get(ctx context.Context, name string) (model.OrderCursor, error)
+	Save(ctx context.Context, name string, cursor model.OrderCursor) error
+}
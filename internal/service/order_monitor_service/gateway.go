@@ -7,5 +7,5 @@ import (
 )
 
 type gateway interface {
-	GetNewOrders(ctx context.Context, from time.Time) (*model.OrdersResponse, error)
+	ListSince(ctx context.Context, from time.Time, limit int, pageToken string) (*model.OrdersPage, error)
 }
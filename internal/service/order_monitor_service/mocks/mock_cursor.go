@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/order_monitor_service/cursor_contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source internal/service/order_monitor_service/cursor_contract.go -destination internal/service/order_monitor_service/mocks/mock_cursor.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "course-go-avito-SitnikovArtem06/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcursorStore is a mock of cursorStore interface.
+type MockcursorStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockcursorStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockcursorStoreMockRecorder is the mock recorder for MockcursorStore.
+type MockcursorStoreMockRecorder struct {
+	mock *MockcursorStore
+}
+
+// NewMockcursorStore creates a new mock instance.
+func NewMockcursorStore(ctrl *gomock.Controller) *MockcursorStore {
+	mock := &MockcursorStore{ctrl: ctrl}
+	mock.recorder = &MockcursorStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcursorStore) EXPECT() *MockcursorStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockcursorStore) Get(ctx context.Context, name string) (model.OrderCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret0, _ := ret[0].(model.OrderCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockcursorStoreMockRecorder) Get(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockcursorStore)(nil).Get), ctx, name)
+}
+
+// Save mocks base method.
+func (m *MockcursorStore) Save(ctx context.Context, name string, cursor model.OrderCursor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, name, cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockcursorStoreMockRecorder) Save(ctx, name, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockcursorStore)(nil).Save), ctx, name, cursor)
+}
@@ -42,17 +42,17 @@ func (m *Mockgateway) EXPECT() *MockgatewayMockRecorder {
 	return m.recorder
 }
 
-// GetNewOrders mocks base method.
-func (m *Mockgateway) GetNewOrders(ctx context.Context, from time.Time) (*model.OrdersResponse, error) {
+// ListSince mocks base method.
+func (m *Mockgateway) ListSince(ctx context.Context, from time.Time, limit int, pageToken string) (*model.OrdersPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNewOrders", ctx, from)
-	ret0, _ := ret[0].(*model.OrdersResponse)
+	ret := m.ctrl.Call(m, "ListSince", ctx, from, limit, pageToken)
+	ret0, _ := ret[0].(*model.OrdersPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNewOrders indicates an expected call of GetNewOrders.
-func (mr *MockgatewayMockRecorder) GetNewOrders(ctx, from any) *gomock.Call {
+// ListSince indicates an expected call of ListSince.
+func (mr *MockgatewayMockRecorder) ListSince(ctx, from, limit, pageToken any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewOrders", reflect.TypeOf((*Mockgateway)(nil).GetNewOrders), ctx, from)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSince", reflect.TypeOf((*Mockgateway)(nil).ListSince), ctx, from, limit, pageToken)
 }
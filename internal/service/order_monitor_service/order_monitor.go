@@ -2,59 +2,134 @@ package order_monitor_service
 
 import (
 	"context"
+	"course-go-avito-SitnikovArtem06/internal/gateway/order"
+	"course-go-avito-SitnikovArtem06/internal/logger"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/repository/cursor_repository"
 	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
 )
 
+const CursorName = "order_monitor"
+
+const DefaultPageSize = 100
+
 type OrderMonitorService struct {
 	gateway  gateway
 	assign   assign
+	cursors  cursorStore
 	interval time.Duration
-	cursor   time.Time
+	pageSize int
+	cursor   model.OrderCursor
+	logger   logger.Logger
+	lastTick atomic.Int64
 }
 
-func NewOrderMonitorService(gateway gateway, assign assign, interval time.Duration) *OrderMonitorService {
+func NewOrderMonitorService(gateway gateway, assign assign, cursors cursorStore, interval time.Duration, pageSize int, logger logger.Logger) *OrderMonitorService {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
 	return &OrderMonitorService{
 		gateway:  gateway,
 		assign:   assign,
+		cursors:  cursors,
 		interval: interval,
-		cursor:   time.Now().UTC().Add(-interval),
+		pageSize: pageSize,
+		cursor:   model.OrderCursor{CreatedAt: time.Now().UTC().Add(-interval)},
+		logger:   logger,
 	}
 }
 
-func (s *OrderMonitorService) HandleTick(ctx context.Context) error {
-	orders, err := s.gateway.GetNewOrders(ctx, s.cursor)
+// LoadCursor resumes from the stored cursor. Without one the monitor starts
+// one interval back from now.
+func (s *OrderMonitorService) LoadCursor(ctx context.Context) error {
+	cursor, err := s.cursors.Get(ctx, CursorName)
 	if err != nil {
+		if errors.Is(err, cursor_repository.ErrNotFound) {
+			return nil
+		}
 		return err
 	}
 
-	for _, id := range orders.OrdersId {
-		_, err := s.assign.AssignCourier(ctx, id)
+	s.cursor = cursor
+	return nil
+}
+
+// HandleTick drains everything after the cursor page by page and persists the
+// cursor after each page, so a failure mid-backlog resumes from the last
+// completed page.
+func (s *OrderMonitorService) HandleTick(ctx context.Context) error {
+	from := s.cursor.CreatedAt
+	pageToken := ""
+
+	for {
+		page, err := s.gateway.ListSince(ctx, from, s.pageSize, pageToken)
 		if err != nil {
-			if errors.Is(err, assign_service.ErrNotAvailableCourier) {
-				continue
-			}
-			if errors.Is(err, assign_service.ErrOrderAlreadyAssign) {
-				continue
-			}
 			return err
 		}
+
+		if err = s.handlePage(ctx, page.Orders); err != nil {
+			return err
+		}
+		// A long backlog is progress too, not a stalled monitor.
+		s.lastTick.Store(time.Now().UnixNano())
+
+		if page.NextPageToken == "" || len(page.Orders) == 0 {
+			return nil
+		}
+		pageToken = page.NextPageToken
 	}
+}
+
+func (s *OrderMonitorService) handlePage(ctx context.Context, orders []model.Order) error {
+	order.SortOrders(orders)
+
+	next := s.cursor
+	for i := range orders {
+		if !s.cursor.Precedes(&orders[i]) {
+			continue
+		}
 
-	var maxCreatedAt = s.cursor
-	for _, t := range orders.CreatedAt {
-		if t.After(maxCreatedAt) {
-			maxCreatedAt = t
+		_, err := s.assign.AssignOrder(ctx, &orders[i])
+		if err != nil && !errors.Is(err, assign_service.ErrNotAvailableCourier) && !errors.Is(err, assign_service.ErrOrderAlreadyAssign) {
+			return err
 		}
+
+		next = model.OrderCursor{CreatedAt: orders[i].CreatedAt, OrderId: orders[i].Id}
+	}
+
+	if next == s.cursor {
+		return nil
+	}
+
+	if err := s.cursors.Save(ctx, CursorName, next); err != nil {
+		return err
 	}
 
-	s.cursor = maxCreatedAt
+	s.cursor = next
 	return nil
+}
 
+// LastTick returns when the monitor last handled a page, or the zero time
+// before it has loaded its cursor. Failed ticks do not count.
+func (s *OrderMonitorService) LastTick() time.Time {
+	if nanos := s.lastTick.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
 }
 
+// Monitor polls until ctx is done. A failed tick is logged and retried on the
+// next one from the same cursor.
 func (s *OrderMonitorService) Monitor(ctx context.Context) error {
+	if err := s.LoadCursor(ctx); err != nil {
+		return fmt.Errorf("load order cursor: %w", err)
+	}
+	s.lastTick.Store(time.Now().UnixNano())
+
 	ticker := time.NewTicker(s.interval)
 	defer ticker.Stop()
 
@@ -62,7 +137,10 @@ func (s *OrderMonitorService) Monitor(ctx context.Context) error {
 		select {
 		case <-ticker.C:
 			if err := s.HandleTick(ctx); err != nil {
-				return err
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				s.logger.Log(fmt.Sprintf("order monitor: %v", err))
 			}
 		case <-ctx.Done():
 			return ctx.Err()
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/repository/cursor_repository"
 	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"course-go-avito-SitnikovArtem06/internal/service/order_monitor_service/mocks"
 	"errors"
@@ -12,6 +13,11 @@ import (
 	"time"
 )
 
+// orderWithId matches the order passed to AssignOrder by its id.
+func orderWithId(id string) gomock.Matcher {
+	return gomock.Cond(func(o *model.Order) bool { return o.Id == id })
+}
+
 func TestHandleTick_Success(t *testing.T) {
 	t.Parallel()
 
@@ -20,32 +26,35 @@ func TestHandleTick_Success(t *testing.T) {
 
 	gw := mocks.NewMockgateway(ctrl)
 	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
 
-	s := NewOrderMonitorService(gw, asg, time.Second)
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
 
-	startCursor := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
 	s.cursor = startCursor
 
-	t1 := startCursor.Add(10 * time.Second)
-	t2 := startCursor.Add(30 * time.Second)
-	t3 := startCursor.Add(20 * time.Second)
+	t1 := startCursor.CreatedAt.Add(10 * time.Second)
+	t2 := startCursor.CreatedAt.Add(30 * time.Second)
+	t3 := startCursor.CreatedAt.Add(20 * time.Second)
 
-	resp := &model.OrdersResponse{
-		OrdersId:  []string{"o1", "o2", "o3"},
-		CreatedAt: []time.Time{t1, t2, t3},
+	resp := []model.Order{
+		{Id: "o1", CreatedAt: t1},
+		{Id: "o2", CreatedAt: t2},
+		{Id: "o3", CreatedAt: t3},
 	}
 
 	gw.EXPECT().
-		GetNewOrders(gomock.Any(), startCursor).
-		Return(resp, nil)
+		ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
+		Return(&model.OrdersPage{Orders: resp}, nil)
 
-	asg.EXPECT().AssignCourier(gomock.Any(), "o1").Return(nil, nil)
-	asg.EXPECT().AssignCourier(gomock.Any(), "o2").Return(nil, nil)
-	asg.EXPECT().AssignCourier(gomock.Any(), "o3").Return(nil, nil)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o1")).Return(nil, nil)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o2")).Return(nil, nil)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o3")).Return(nil, nil)
+	cur.EXPECT().Save(gomock.Any(), CursorName, model.OrderCursor{CreatedAt: t2, OrderId: "o2"}).Return(nil)
 
 	err := s.HandleTick(context.Background())
 	require.NoError(t, err)
-	require.Equal(t, t2, s.cursor)
+	require.Equal(t, model.OrderCursor{CreatedAt: t2, OrderId: "o2"}, s.cursor)
 }
 
 func TestHandleTick_Success_EmptyOrders(t *testing.T) {
@@ -56,20 +65,18 @@ func TestHandleTick_Success_EmptyOrders(t *testing.T) {
 
 	gw := mocks.NewMockgateway(ctrl)
 	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
 
-	s := NewOrderMonitorService(gw, asg, time.Second)
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
 
-	startCursor := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
 	s.cursor = startCursor
 
-	resp := &model.OrdersResponse{
-		OrdersId:  nil,
-		CreatedAt: nil,
-	}
+	var resp []model.Order
 
 	gw.EXPECT().
-		GetNewOrders(gomock.Any(), startCursor).
-		Return(resp, nil)
+		ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
+		Return(&model.OrdersPage{Orders: resp}, nil)
 
 	err := s.HandleTick(context.Background())
 	require.NoError(t, err)
@@ -84,28 +91,30 @@ func TestHandleTick_Success_IgnoresNotAvailableCourier(t *testing.T) {
 
 	gw := mocks.NewMockgateway(ctrl)
 	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
 
-	s := NewOrderMonitorService(gw, asg, time.Second)
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
 
-	startCursor := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
 	s.cursor = startCursor
 
-	t1 := startCursor.Add(10 * time.Second)
-	resp := &model.OrdersResponse{
-		OrdersId:  []string{"o1", "o2"},
-		CreatedAt: []time.Time{t1},
+	t1 := startCursor.CreatedAt.Add(10 * time.Second)
+	resp := []model.Order{
+		{Id: "o1", CreatedAt: t1},
+		{Id: "o2", CreatedAt: t1},
 	}
 
 	gw.EXPECT().
-		GetNewOrders(gomock.Any(), startCursor).
-		Return(resp, nil)
+		ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
+		Return(&model.OrdersPage{Orders: resp}, nil)
 
-	asg.EXPECT().AssignCourier(gomock.Any(), "o1").Return(nil, assign_service.ErrNotAvailableCourier)
-	asg.EXPECT().AssignCourier(gomock.Any(), "o2").Return(nil, nil)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o1")).Return(nil, assign_service.ErrNotAvailableCourier)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o2")).Return(nil, nil)
+	cur.EXPECT().Save(gomock.Any(), CursorName, model.OrderCursor{CreatedAt: t1, OrderId: "o2"}).Return(nil)
 
 	err := s.HandleTick(context.Background())
 	require.NoError(t, err)
-	require.Equal(t, t1, s.cursor)
+	require.Equal(t, model.OrderCursor{CreatedAt: t1, OrderId: "o2"}, s.cursor)
 }
 
 func TestHandleTick_Success_IgnoresAlreadyAssigned(t *testing.T) {
@@ -116,28 +125,30 @@ func TestHandleTick_Success_IgnoresAlreadyAssigned(t *testing.T) {
 
 	gw := mocks.NewMockgateway(ctrl)
 	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
 
-	s := NewOrderMonitorService(gw, asg, time.Second)
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
 
-	startCursor := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
 	s.cursor = startCursor
 
-	t1 := startCursor.Add(10 * time.Second)
-	resp := &model.OrdersResponse{
-		OrdersId:  []string{"o1", "o2"},
-		CreatedAt: []time.Time{t1},
+	t1 := startCursor.CreatedAt.Add(10 * time.Second)
+	resp := []model.Order{
+		{Id: "o1", CreatedAt: t1},
+		{Id: "o2", CreatedAt: t1},
 	}
 
 	gw.EXPECT().
-		GetNewOrders(gomock.Any(), startCursor).
-		Return(resp, nil)
+		ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
+		Return(&model.OrdersPage{Orders: resp}, nil)
 
-	asg.EXPECT().AssignCourier(gomock.Any(), "o1").Return(nil, assign_service.ErrOrderAlreadyAssign)
-	asg.EXPECT().AssignCourier(gomock.Any(), "o2").Return(nil, nil)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o1")).Return(nil, assign_service.ErrOrderAlreadyAssign)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o2")).Return(nil, nil)
+	cur.EXPECT().Save(gomock.Any(), CursorName, model.OrderCursor{CreatedAt: t1, OrderId: "o2"}).Return(nil)
 
 	err := s.HandleTick(context.Background())
 	require.NoError(t, err)
-	require.Equal(t, t1, s.cursor)
+	require.Equal(t, model.OrderCursor{CreatedAt: t1, OrderId: "o2"}, s.cursor)
 }
 
 func TestHandleTick_GatewayError(t *testing.T) {
@@ -148,16 +159,17 @@ func TestHandleTick_GatewayError(t *testing.T) {
 
 	gw := mocks.NewMockgateway(ctrl)
 	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
 
-	s := NewOrderMonitorService(gw, asg, time.Second)
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
 
-	startCursor := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
 	s.cursor = startCursor
 
 	gwErr := errors.New("gateway error")
 
 	gw.EXPECT().
-		GetNewOrders(gomock.Any(), startCursor).
+		ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
 		Return(nil, gwErr)
 
 	err := s.HandleTick(context.Background())
@@ -173,27 +185,166 @@ func TestHandleTick_Error_AssignError(t *testing.T) {
 
 	gw := mocks.NewMockgateway(ctrl)
 	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
 
-	s := NewOrderMonitorService(gw, asg, time.Second)
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
 
-	startCursor := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
 	s.cursor = startCursor
 
-	t1 := startCursor.Add(10 * time.Second)
-	resp := &model.OrdersResponse{
-		OrdersId:  []string{"o1", "o2"},
-		CreatedAt: []time.Time{t1},
+	t1 := startCursor.CreatedAt.Add(10 * time.Second)
+	resp := []model.Order{
+		{Id: "o1", CreatedAt: t1},
+		{Id: "o2", CreatedAt: t1},
 	}
 
 	gw.EXPECT().
-		GetNewOrders(gomock.Any(), startCursor).
-		Return(resp, nil)
+		ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
+		Return(&model.OrdersPage{Orders: resp}, nil)
 
 	asgErr := errors.New("assign error")
 
-	asg.EXPECT().AssignCourier(gomock.Any(), "o1").Return(nil, asgErr)
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o1")).Return(nil, asgErr)
 
 	err := s.HandleTick(context.Background())
 	require.ErrorIs(t, err, asgErr)
 	require.Equal(t, startCursor, s.cursor)
 }
+
+func TestHandleTick_Error_CursorSaveError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gw := mocks.NewMockgateway(ctrl)
+	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
+
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
+
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
+	s.cursor = startCursor
+
+	t1 := startCursor.CreatedAt.Add(10 * time.Second)
+	resp := []model.Order{{Id: "o1", CreatedAt: t1}}
+
+	gw.EXPECT().
+		ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
+		Return(&model.OrdersPage{Orders: resp}, nil)
+
+	saveErr := errors.New("save error")
+
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o1")).Return(nil, nil)
+	cur.EXPECT().Save(gomock.Any(), CursorName, model.OrderCursor{CreatedAt: t1, OrderId: "o1"}).Return(saveErr)
+
+	err := s.HandleTick(context.Background())
+	require.ErrorIs(t, err, saveErr)
+	require.Equal(t, startCursor, s.cursor)
+}
+
+func TestLoadCursor_ResumesFromStore(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cur := mocks.NewMockcursorStore(ctrl)
+
+	s := NewOrderMonitorService(mocks.NewMockgateway(ctrl), mocks.NewMockassign(ctrl), cur, time.Second, 2, nil)
+
+	stored := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC), OrderId: "o1"}
+	cur.EXPECT().Get(gomock.Any(), CursorName).Return(stored, nil)
+
+	require.NoError(t, s.LoadCursor(context.Background()))
+	require.Equal(t, stored, s.cursor)
+}
+
+func TestLoadCursor_NotFound_KeepsDefault(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cur := mocks.NewMockcursorStore(ctrl)
+
+	s := NewOrderMonitorService(mocks.NewMockgateway(ctrl), mocks.NewMockassign(ctrl), cur, time.Second, 2, nil)
+	initial := s.cursor
+
+	cur.EXPECT().Get(gomock.Any(), CursorName).Return(model.OrderCursor{}, cursor_repository.ErrNotFound)
+
+	require.NoError(t, s.LoadCursor(context.Background()))
+	require.Equal(t, initial, s.cursor)
+}
+
+func TestHandleTick_Pages(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gw := mocks.NewMockgateway(ctrl)
+	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
+
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
+
+	startCursor := model.OrderCursor{CreatedAt: time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)}
+	s.cursor = startCursor
+
+	t1 := startCursor.CreatedAt.Add(10 * time.Second)
+	t2 := startCursor.CreatedAt.Add(20 * time.Second)
+
+	first := []model.Order{{Id: "o1", CreatedAt: t1}, {Id: "o2", CreatedAt: t1}}
+	second := []model.Order{{Id: "o3", CreatedAt: t2}}
+
+	gomock.InOrder(
+		gw.EXPECT().
+			ListSince(gomock.Any(), startCursor.CreatedAt, 2, "").
+			Return(&model.OrdersPage{Orders: first, NextPageToken: "next"}, nil),
+		cur.EXPECT().Save(gomock.Any(), CursorName, model.OrderCursor{CreatedAt: t1, OrderId: "o2"}).Return(nil),
+		gw.EXPECT().
+			ListSince(gomock.Any(), startCursor.CreatedAt, 2, "next").
+			Return(&model.OrdersPage{Orders: second}, nil),
+		cur.EXPECT().Save(gomock.Any(), CursorName, model.OrderCursor{CreatedAt: t2, OrderId: "o3"}).Return(nil),
+	)
+
+	asg.EXPECT().AssignOrder(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+
+	err := s.HandleTick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.OrderCursor{CreatedAt: t2, OrderId: "o3"}, s.cursor)
+}
+
+func TestHandleTick_SkipsProcessedAtSameCreatedAt(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gw := mocks.NewMockgateway(ctrl)
+	asg := mocks.NewMockassign(ctrl)
+	cur := mocks.NewMockcursorStore(ctrl)
+
+	s := NewOrderMonitorService(gw, asg, cur, time.Second, 2, nil)
+
+	t1 := time.Date(2025, 12, 15, 12, 0, 0, 0, time.UTC)
+	s.cursor = model.OrderCursor{CreatedAt: t1, OrderId: "o2"}
+
+	resp := []model.Order{
+		{Id: "o1", CreatedAt: t1},
+		{Id: "o2", CreatedAt: t1},
+		{Id: "o3", CreatedAt: t1},
+	}
+
+	gw.EXPECT().
+		ListSince(gomock.Any(), t1, 2, "").
+		Return(&model.OrdersPage{Orders: resp}, nil)
+
+	asg.EXPECT().AssignOrder(gomock.Any(), orderWithId("o3")).Return(nil, nil)
+	cur.EXPECT().Save(gomock.Any(), CursorName, model.OrderCursor{CreatedAt: t1, OrderId: "o3"}).Return(nil)
+
+	err := s.HandleTick(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.OrderCursor{CreatedAt: t1, OrderId: "o3"}, s.cursor)
+}
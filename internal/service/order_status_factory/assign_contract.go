@@ -6,8 +6,10 @@ import (
 )
 
 type assign interface {
-	AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error)
+	AssignOrder(ctx context.Context, order *model.Order) (*model.AssignCourier, error)
 	UnassignCourier(ctx context.Context, orderId string) (*model.UnassignCourier, error)
 
-	CompleteCourier(ctx context.Context, orderId string) error
+	CompleteCourier(ctx context.Context, orderId string) (*model.CompleteCourier, error)
+
+	ChangeDeliveryStatus(ctx context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryStatusChange, error)
 }
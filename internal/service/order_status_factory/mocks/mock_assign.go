@@ -41,27 +41,43 @@ func (m *Mockassign) EXPECT() *MockassignMockRecorder {
 	return m.recorder
 }
 
-// AssignCourier mocks base method.
-func (m *Mockassign) AssignCourier(ctx context.Context, orderId string) (*model.AssignCourier, error) {
+// AssignOrder mocks base method.
+func (m *Mockassign) AssignOrder(ctx context.Context, order *model.Order) (*model.AssignCourier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignCourier", ctx, orderId)
+	ret := m.ctrl.Call(m, "AssignOrder", ctx, order)
 	ret0, _ := ret[0].(*model.AssignCourier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AssignCourier indicates an expected call of AssignCourier.
-func (mr *MockassignMockRecorder) AssignCourier(ctx, orderId any) *gomock.Call {
+// AssignOrder indicates an expected call of AssignOrder.
+func (mr *MockassignMockRecorder) AssignOrder(ctx, order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourier", reflect.TypeOf((*Mockassign)(nil).AssignCourier), ctx, orderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrder", reflect.TypeOf((*Mockassign)(nil).AssignOrder), ctx, order)
+}
+
+// ChangeDeliveryStatus mocks base method.
+func (m *Mockassign) ChangeDeliveryStatus(ctx context.Context, orderId string, status model.DeliveryStatus) (*model.DeliveryStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeDeliveryStatus", ctx, orderId, status)
+	ret0, _ := ret[0].(*model.DeliveryStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeDeliveryStatus indicates an expected call of ChangeDeliveryStatus.
+func (mr *MockassignMockRecorder) ChangeDeliveryStatus(ctx, orderId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeDeliveryStatus", reflect.TypeOf((*Mockassign)(nil).ChangeDeliveryStatus), ctx, orderId, status)
 }
 
 // CompleteCourier mocks base method.
-func (m *Mockassign) CompleteCourier(ctx context.Context, orderId string) error {
+func (m *Mockassign) CompleteCourier(ctx context.Context, orderId string) (*model.CompleteCourier, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CompleteCourier", ctx, orderId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*model.CompleteCourier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CompleteCourier indicates an expected call of CompleteCourier.
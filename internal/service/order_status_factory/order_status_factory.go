@@ -1,49 +1,72 @@
 package order_status_factory
 
-import "context"
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
+	"errors"
+	"sync"
+)
 
+const (
+	StatusCreated        = "created"
+	StatusCancelled      = "cancelled"
+	StatusCompleted      = "completed"
+	StatusPickedUp       = "picked_up"
+	StatusDelivering     = "delivering"
+	StatusReturned       = "returned"
+	StatusFailedDelivery = "failed_delivery"
+)
+
+// OrderStatus applies an order status to the order's delivery. order is the
+// order as the order service returned it.
 type OrderStatus interface {
-	Do(ctx context.Context, orderId string) error
+	Do(ctx context.Context, order *model.Order) error
 }
 
 type OrderStatusImpl struct {
-	created   OrderStatus
-	cancelled OrderStatus
-	completed OrderStatus
+	mu       sync.RWMutex
+	handlers map[string]OrderStatus
 }
 
 func NewOrderStatusFactory(a assign) *OrderStatusImpl {
-	return &OrderStatusImpl{
-		created:   &Created{s: a},
-		cancelled: &Cancelled{s: a},
-		completed: &Completed{s: a},
-	}
+	f := &OrderStatusImpl{handlers: make(map[string]OrderStatus)}
+
+	f.Register(StatusCreated, &Created{s: a})
+	f.Register(StatusCancelled, &Cancelled{s: a})
+	f.Register(StatusCompleted, &Completed{s: a})
+	f.Register(StatusPickedUp, &Transition{s: a, status: model.DeliveryPickedUp})
+	f.Register(StatusDelivering, &Transition{s: a, status: model.DeliveryDelivering})
+	f.Register(StatusReturned, &Transition{s: a, status: model.DeliveryReturned})
+	f.Register(StatusFailedDelivery, &Transition{s: a, status: model.DeliveryFailed})
+
+	return f
 }
 
 type OrderStatusFactory interface {
 	Get(status string) OrderStatus
 }
 
+// Register adds or replaces the handler for an order status.
+func (f *OrderStatusImpl) Register(status string, handler OrderStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[status] = handler
+}
+
 func (f *OrderStatusImpl) Get(status string) OrderStatus {
-	switch status {
-	case "created":
-		return f.created
-	case "cancelled":
-		return f.cancelled
-	case "completed":
-		return f.completed
-	default:
-		return nil
-	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.handlers[status]
 }
 
 type Created struct {
 	s assign
 }
 
-func (c Created) Do(ctx context.Context, orderId string) error {
+func (c Created) Do(ctx context.Context, order *model.Order) error {
 
-	_, err := c.s.AssignCourier(ctx, orderId)
+	_, err := c.s.AssignOrder(ctx, order)
 	return err
 }
 
@@ -51,16 +74,40 @@ type Cancelled struct {
 	s assign
 }
 
-func (c Cancelled) Do(ctx context.Context, orderId string) error {
+func (c Cancelled) Do(ctx context.Context, order *model.Order) error {
 
-	_, err := c.s.UnassignCourier(ctx, orderId)
+	_, err := c.s.UnassignCourier(ctx, order.Id)
 	return err
 }
 
+// Completed completes the delivery of the order. Like Transition it skips
+// orders without a delivery and deliveries that can no longer complete, such
+// as a failed one, so that the event does not stop the consumer.
 type Completed struct {
 	s assign
 }
 
-func (c Completed) Do(ctx context.Context, orderId string) error {
-	return c.s.CompleteCourier(ctx, orderId)
+func (c Completed) Do(ctx context.Context, order *model.Order) error {
+
+	_, err := c.s.CompleteCourier(ctx, order.Id)
+	if errors.Is(err, assign_service.ErrNotFoundOrder) || errors.Is(err, assign_service.ErrInvalidTransition) {
+		return nil
+	}
+	return err
+}
+
+// Transition moves the delivery of the order to status. Events for orders
+// without a delivery and stale events that arrive after a later status are
+// skipped.
+type Transition struct {
+	s      assign
+	status model.DeliveryStatus
+}
+
+func (t Transition) Do(ctx context.Context, order *model.Order) error {
+	_, err := t.s.ChangeDeliveryStatus(ctx, order.Id, t.status)
+	if errors.Is(err, assign_service.ErrNotFoundOrder) || errors.Is(err, assign_service.ErrInvalidTransition) {
+		return nil
+	}
+	return err
 }
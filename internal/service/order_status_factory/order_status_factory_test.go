@@ -3,6 +3,7 @@
 import (
 	"context"
 	"course-go-avito-SitnikovArtem06/internal/model"
+	"course-go-avito-SitnikovArtem06/internal/service/assign_service"
 	"course-go-avito-SitnikovArtem06/internal/service/order_status_factory/mocks"
 	"errors"
 	"github.com/stretchr/testify/require"
@@ -33,10 +34,10 @@ func TestCreated_Do_Success(t *testing.T) {
 	orderId := "o1"
 
 	a.EXPECT().
-		AssignCourier(gomock.Any(), orderId).
+		AssignOrder(gomock.Any(), &model.Order{Id: orderId}).
 		Return(&model.AssignCourier{OrderId: orderId}, nil)
 
-	err := f.Get("created").Do(context.Background(), orderId)
+	err := f.Get("created").Do(context.Background(), &model.Order{Id: orderId})
 	require.NoError(t, err)
 }
 
@@ -53,10 +54,10 @@ func TestCreated_Do_Error(t *testing.T) {
 	Err := errors.New("assign err")
 
 	a.EXPECT().
-		AssignCourier(gomock.Any(), orderId).
+		AssignOrder(gomock.Any(), &model.Order{Id: orderId}).
 		Return(nil, Err)
 
-	err := f.Get("created").Do(context.Background(), orderId)
+	err := f.Get("created").Do(context.Background(), &model.Order{Id: orderId})
 	require.ErrorIs(t, err, Err)
 }
 
@@ -75,7 +76,7 @@ func TestCancelled_Do_Success(t *testing.T) {
 		UnassignCourier(gomock.Any(), orderId).
 		Return(&model.UnassignCourier{OrderId: orderId}, nil)
 
-	err := f.Get("cancelled").Do(context.Background(), orderId)
+	err := f.Get("cancelled").Do(context.Background(), &model.Order{Id: orderId})
 	require.NoError(t, err)
 }
 
@@ -95,7 +96,7 @@ func TestCancelled_Do_Error(t *testing.T) {
 		UnassignCourier(gomock.Any(), orderId).
 		Return(nil, Err)
 
-	err := f.Get("cancelled").Do(context.Background(), orderId)
+	err := f.Get("cancelled").Do(context.Background(), &model.Order{Id: orderId})
 	require.ErrorIs(t, err, Err)
 }
 
@@ -112,9 +113,9 @@ func TestCompleted_Do_Success(t *testing.T) {
 
 	a.EXPECT().
 		CompleteCourier(gomock.Any(), orderId).
-		Return(nil)
+		Return(&model.CompleteCourier{CourierId: 1, OrderId: orderId}, nil)
 
-	err := f.Get("completed").Do(context.Background(), orderId)
+	err := f.Get("completed").Do(context.Background(), &model.Order{Id: orderId})
 	require.NoError(t, err)
 }
 
@@ -132,8 +133,116 @@ func TestCompleted_Do_Error(t *testing.T) {
 
 	a.EXPECT().
 		CompleteCourier(gomock.Any(), orderId).
-		Return(Err)
+		Return(nil, Err)
 
-	err := f.Get("completed").Do(context.Background(), orderId)
+	err := f.Get("completed").Do(context.Background(), &model.Order{Id: orderId})
 	require.ErrorIs(t, err, Err)
 }
+
+func TestCompleted_Do_SkipsFinishedAndUnknownOrders(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := mocks.NewMockassign(ctrl)
+	f := NewOrderStatusFactory(a)
+
+	a.EXPECT().
+		CompleteCourier(gomock.Any(), "o1").
+		Return(nil, assign_service.ErrInvalidTransition)
+	a.EXPECT().
+		CompleteCourier(gomock.Any(), "o2").
+		Return(nil, assign_service.ErrNotFoundOrder)
+
+	require.NoError(t, f.Get(StatusCompleted).Do(context.Background(), &model.Order{Id: "o1"}))
+	require.NoError(t, f.Get(StatusCompleted).Do(context.Background(), &model.Order{Id: "o2"}))
+}
+
+func TestTransition_Do_ChangesDeliveryStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]model.DeliveryStatus{
+		StatusPickedUp:       model.DeliveryPickedUp,
+		StatusDelivering:     model.DeliveryDelivering,
+		StatusReturned:       model.DeliveryReturned,
+		StatusFailedDelivery: model.DeliveryFailed,
+	}
+
+	for status, delivery := range cases {
+		t.Run(status, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mocks.NewMockassign(ctrl)
+			f := NewOrderStatusFactory(a)
+
+			a.EXPECT().
+				ChangeDeliveryStatus(gomock.Any(), "o1", delivery).
+				Return(nil, nil)
+
+			err := f.Get(status).Do(context.Background(), &model.Order{Id: "o1"})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTransition_Do_SkipsStaleAndUnknownOrders(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := mocks.NewMockassign(ctrl)
+	f := NewOrderStatusFactory(a)
+
+	a.EXPECT().
+		ChangeDeliveryStatus(gomock.Any(), "o1", model.DeliveryPickedUp).
+		Return(nil, assign_service.ErrInvalidTransition)
+	a.EXPECT().
+		ChangeDeliveryStatus(gomock.Any(), "o2", model.DeliveryPickedUp).
+		Return(nil, assign_service.ErrNotFoundOrder)
+
+	require.NoError(t, f.Get(StatusPickedUp).Do(context.Background(), &model.Order{Id: "o1"}))
+	require.NoError(t, f.Get(StatusPickedUp).Do(context.Background(), &model.Order{Id: "o2"}))
+}
+
+func TestTransition_Do_Error(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := mocks.NewMockassign(ctrl)
+	f := NewOrderStatusFactory(a)
+
+	Err := errors.New("db error")
+
+	a.EXPECT().
+		ChangeDeliveryStatus(gomock.Any(), "o1", model.DeliveryDelivering).
+		Return(nil, Err)
+
+	err := f.Get(StatusDelivering).Do(context.Background(), &model.Order{Id: "o1"})
+	require.ErrorIs(t, err, Err)
+}
+
+type noopStatus struct{}
+
+func (noopStatus) Do(ctx context.Context, order *model.Order) error { return nil }
+
+func TestOrderStatusFactory_Register(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	f := NewOrderStatusFactory(mocks.NewMockassign(ctrl))
+
+	require.Nil(t, f.Get("on_hold"))
+
+	f.Register("on_hold", noopStatus{})
+	require.Equal(t, noopStatus{}, f.Get("on_hold"))
+}
+
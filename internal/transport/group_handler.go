@@ -0,0 +1,113 @@
+package transport
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+type groupHandler struct {
+	ctx        context.Context
+	handler    MessageHandler
+	workers    int
+	retryDelay time.Duration
+	pool       *Pool
+
+	beatInterval time.Duration
+	lastBeat     *atomic.Int64
+}
+
+func newGroupHandler(ctx context.Context, handler MessageHandler, workers int) *groupHandler {
+	return &groupHandler{
+		ctx:          ctx,
+		handler:      handler,
+		workers:      workers,
+		retryDelay:   DefaultRetryDelay,
+		beatInterval: HeartbeatInterval,
+		lastBeat:     new(atomic.Int64),
+	}
+}
+
+func (h *groupHandler) Setup(sess sarama.ConsumerGroupSession) error {
+	h.pool = NewPool(h.ctx, h.workers, h.handler)
+	h.pool.retryDelay = h.retryDelay
+	go h.heartbeat(sess.Context())
+	return nil
+}
+
+// heartbeat beats every beatInterval while the session lasts, whether or
+// not messages arrive.
+func (h *groupHandler) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(h.beatInterval)
+	defer ticker.Stop()
+
+	for {
+		h.lastBeat.Store(time.Now().UnixNano())
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (h *groupHandler) Cleanup(sess sarama.ConsumerGroupSession) error {
+	h.pool.Close()
+	sess.Commit()
+	return nil
+}
+
+func (h *groupHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+
+	tracker := NewOffsetTracker()
+	errCh := make(chan error, 1)
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			tracker.Add(msg.Offset)
+
+			done := func(err error) {
+				if err != nil {
+					select {
+					case errCh <- err:
+					default:
+					}
+					return
+				}
+				if offset, ok := tracker.Done(msg.Offset); ok {
+					sess.MarkOffset(msg.Topic, msg.Partition, offset+1, "")
+				}
+			}
+
+			if err := h.pool.Submit(sess.Context(), h.messageKey(msg), msg.Value, done); err != nil {
+				if sess.Context().Err() != nil {
+					return nil
+				}
+				return err
+			}
+
+		case err := <-errCh:
+			return err
+
+		case <-sess.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (h *groupHandler) messageKey(msg *sarama.ConsumerMessage) string {
+	if kh, ok := h.handler.(KeyedHandler); ok {
+		if key := kh.MessageKey(msg.Value); key != "" {
+			return key
+		}
+	}
+	return string(msg.Key)
+}
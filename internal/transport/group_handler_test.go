@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"context"
+	"course-go-avito-SitnikovArtem06/internal/gateway/breaker"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	ctx context.Context
+
+	mu     sync.Mutex
+	marked []int64
+}
+
+func (s *fakeSession) Claims() map[string][]int32                  { return nil }
+func (s *fakeSession) MemberID() string                            { return "member" }
+func (s *fakeSession) GenerationID() int32                         { return 1 }
+func (s *fakeSession) ResetOffset(string, int32, int64, string)    {}
+func (s *fakeSession) MarkMessage(*sarama.ConsumerMessage, string) {}
+func (s *fakeSession) Commit()                                     {}
+func (s *fakeSession) Context() context.Context                    { return s.ctx }
+func (s *fakeSession) MarkOffset(_ string, _ int32, offset int64, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, offset)
+}
+
+func (s *fakeSession) offsets() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.marked...)
+}
+
+type fakeClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Topic() string                            { return "orders" }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) InitialOffset() int64                     { return 0 }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+// gatewayHandler fails like an order gateway behind b while b is open.
+type gatewayHandler struct {
+	b     *breaker.Breaker
+	calls atomic.Int32
+}
+
+func (h *gatewayHandler) HandleMessage(ctx context.Context, value []byte) error {
+	h.calls.Add(1)
+	if err := h.b.Allow(); err != nil {
+		return fmt.Errorf("order gateway: %w", err)
+	}
+	h.b.Success()
+	return nil
+}
+
+func TestConsumeClaim_RetriesWhileBreakerOpen(t *testing.T) {
+	t.Parallel()
+
+	b := breaker.New("consumer_test", 1, 100*time.Millisecond, 1)
+	b.Failure()
+	require.Equal(t, breaker.StateOpen, b.State())
+
+	h := &gatewayHandler{b: b}
+	gh := newGroupHandler(context.Background(), h, 1)
+	gh.retryDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sess := &fakeSession{ctx: ctx}
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+
+	require.NoError(t, gh.Setup(sess))
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- gh.ConsumeClaim(sess, claim) }()
+
+	claim.messages <- &sarama.ConsumerMessage{Topic: "orders", Offset: 7, Value: []byte(`{}`)}
+
+	// Nothing is committed while the breaker rejects the message, and the
+	// session keeps running.
+	require.Eventually(t, func() bool { return h.calls.Load() >= 2 }, time.Second, time.Millisecond)
+	require.Empty(t, sess.offsets())
+	require.Empty(t, errCh)
+
+	// Once the breaker lets calls through the same message is handled.
+	require.Eventually(t, func() bool { return len(sess.offsets()) == 1 }, 2*time.Second, time.Millisecond)
+	require.Equal(t, []int64{8}, sess.offsets())
+
+	cancel()
+	require.NoError(t, <-errCh)
+	require.NoError(t, gh.Cleanup(sess))
+}
+
+func TestSetup_BeatsWhileTheSessionLasts(t *testing.T) {
+	t.Parallel()
+
+	gh := newGroupHandler(context.Background(), failingHandler{}, 1)
+	gh.beatInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sess := &fakeSession{ctx: ctx}
+	require.NoError(t, gh.Setup(sess))
+
+	require.Eventually(t, func() bool { return gh.lastBeat.Load() != 0 }, time.Second, time.Millisecond)
+	first := gh.lastBeat.Load()
+	require.Eventually(t, func() bool { return gh.lastBeat.Load() > first }, time.Second, time.Millisecond, "beats without messages")
+
+	cancel()
+	require.NoError(t, gh.Cleanup(sess))
+	time.Sleep(3 * gh.beatInterval)
+
+	last := gh.lastBeat.Load()
+	time.Sleep(3 * gh.beatInterval)
+	require.Equal(t, last, gh.lastBeat.Load(), "beats stop with the session")
+}
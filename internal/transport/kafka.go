@@ -2,63 +2,150 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
+
 	"github.com/IBM/sarama"
 )
 
+const DefaultDrainTimeout = 30 * time.Second
+
+// HeartbeatInterval is how often a consumer in a live group session beats.
+const HeartbeatInterval = 5 * time.Second
+
 type MessageHandler interface {
 	HandleMessage(ctx context.Context, value []byte) error
 }
 
+type KeyedHandler interface {
+	MessageKey(value []byte) string
+}
+
+type ConsumerOptions struct {
+	Workers      int
+	DrainTimeout time.Duration
+}
+
 type KafkaConsumer struct {
 	brokers []string
 	topic   string
+	groupID string
 	handler MessageHandler
 	cfg     *sarama.Config
+	opts    ConsumerOptions
+
+	lastBeat atomic.Int64
 }
 
-func NewKafkaConsumer(brokers []string, topic string, handler MessageHandler, cfg *sarama.Config) *KafkaConsumer {
+func NewKafkaConsumer(brokers []string, topic string, groupID string, handler MessageHandler, cfg *sarama.Config, opts ConsumerOptions) *KafkaConsumer {
+
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.DrainTimeout <= 0 {
+		opts.DrainTimeout = DefaultDrainTimeout
+	}
 
 	return &KafkaConsumer{
 		brokers: brokers,
 		topic:   topic,
+		groupID: groupID,
 		handler: handler,
 		cfg:     cfg,
+		opts:    opts,
+	}
+}
+
+// LastBeat returns when the consumer last beat in a live group session, or
+// the zero time before it joined the group. Check it with health.Heartbeat
+// and HeartbeatInterval.
+func (c *KafkaConsumer) LastBeat() time.Time {
+	if nanos := c.lastBeat.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
 	}
+	return time.Time{}
 }
 
+// Run consumes until ctx is cancelled or a handler fails with an error that
+// is not transient; transient ones are retried in place. On exit it stops
+// fetching, waits up to DrainTimeout for in-flight handlers, commits the
+// offsets they completed and closes the consumer group.
 func (c *KafkaConsumer) Run(ctx context.Context) error {
 
-	consumer, err := sarama.NewConsumer(c.brokers, c.cfg)
+	group, err := sarama.NewConsumerGroup(c.brokers, c.groupID, c.cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create consumer: %w", err)
+		return fmt.Errorf("failed to create consumer group: %w", err)
 	}
-	defer consumer.Close()
 
-	pc, err := consumer.ConsumePartition(c.topic, 0, sarama.OffsetNewest)
-	if err != nil {
-		return fmt.Errorf("failed to consume partition: %w", err)
-	}
-	defer pc.Close()
+	handleCtx, cancelHandle := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandle()
 
-	for {
-		select {
-		case msg := <-pc.Messages():
-			if msg == nil {
-				continue
-			}
+	consumeCtx, stopConsume := context.WithCancel(ctx)
+	defer stopConsume()
 
-			if err := c.handler.HandleMessage(ctx, msg.Value); err != nil {
-				return err
-			}
+	errCh := make(chan error, 1)
 
-		case err := <-pc.Errors():
-			if err != nil {
-				return err
+	go func() {
+		for err := range group.Errors() {
+			select {
+			case errCh <- err:
+			default:
 			}
+		}
+	}()
+
+	h := newGroupHandler(handleCtx, c.handler, c.opts.Workers)
+	h.lastBeat = &c.lastBeat
+
+	consumeDone := make(chan struct{})
 
-		case <-ctx.Done():
-			return ctx.Err()
+	go func() {
+		defer close(consumeDone)
+		for {
+			if err := group.Consume(consumeCtx, []string{c.topic}, h); err != nil {
+				if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+					return
+				}
+				select {
+				case errCh <- err:
+				default:
+				}
+				return
+			}
+			if consumeCtx.Err() != nil {
+				return
+			}
 		}
+	}()
+
+	var runErr error
+
+	select {
+	case runErr = <-errCh:
+	case <-ctx.Done():
+		runErr = ctx.Err()
+	}
+
+	stopConsume()
+	c.drain(consumeDone, cancelHandle)
+
+	if err := group.Close(); err != nil && runErr == nil {
+		runErr = fmt.Errorf("failed to close consumer group: %w", err)
+	}
+
+	return runErr
+}
+
+func (c *KafkaConsumer) drain(consumeDone <-chan struct{}, cancelHandle context.CancelFunc) {
+	timer := time.NewTimer(c.opts.DrainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-consumeDone:
+	case <-timer.C:
+		cancelHandle()
+		<-consumeDone
 	}
 }
@@ -0,0 +1,44 @@
+package transport
+
+import "sync"
+
+// OffsetTracker reports the highest offset below which every message of a
+// partition has been handled, so commits never skip an unfinished message.
+type OffsetTracker struct {
+	mu      sync.Mutex
+	pending []int64
+	done    map[int64]struct{}
+}
+
+func NewOffsetTracker() *OffsetTracker {
+	return &OffsetTracker{done: make(map[int64]struct{})}
+}
+
+func (t *OffsetTracker) Add(offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, offset)
+}
+
+func (t *OffsetTracker) Done(offset int64) (int64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.done[offset] = struct{}{}
+
+	var last int64
+	advanced := false
+
+	for len(t.pending) > 0 {
+		head := t.pending[0]
+		if _, ok := t.done[head]; !ok {
+			break
+		}
+		delete(t.done, head)
+		t.pending = t.pending[1:]
+		last = head
+		advanced = true
+	}
+
+	return last, advanced
+}
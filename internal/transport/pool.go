@@ -0,0 +1,148 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+const ShardBuffer = 16
+
+const (
+	DefaultRetryDelay = time.Second
+	MaxRetryDelay     = 30 * time.Second
+)
+
+var ErrPoolClosed = errors.New("worker pool closed")
+
+// TransientError is implemented by handler errors that may go away without
+// any change to the message, such as an upstream service that is down.
+type TransientError interface {
+	error
+	Transient() bool
+}
+
+func IsTransient(err error) bool {
+	var t TransientError
+	return errors.As(err, &t) && t.Transient()
+}
+
+type job struct {
+	value []byte
+	done  func(err error)
+}
+
+// Pool runs handlers on a fixed set of workers. Messages with the same key
+// always land on the same worker, so they are handled in submit order. A
+// message whose handler fails with a transient error is handled again after
+// a backoff that starts at retryDelay, holding back the messages behind it.
+type Pool struct {
+	ctx        context.Context
+	handler    MessageHandler
+	shards     []chan job
+	closing    chan struct{}
+	wg         sync.WaitGroup
+	once       sync.Once
+	retryDelay time.Duration
+}
+
+func NewPool(ctx context.Context, size int, handler MessageHandler) *Pool {
+
+	if size <= 0 {
+		size = 1
+	}
+
+	p := &Pool{
+		ctx:        ctx,
+		handler:    handler,
+		shards:     make([]chan job, size),
+		closing:    make(chan struct{}),
+		retryDelay: DefaultRetryDelay,
+	}
+
+	for i := range p.shards {
+		p.shards[i] = make(chan job, ShardBuffer)
+		p.wg.Add(1)
+		go p.work(p.shards[i])
+	}
+
+	return p
+}
+
+func (p *Pool) Submit(ctx context.Context, key string, value []byte, done func(err error)) error {
+
+	shard := p.shards[p.shardIndex(key)]
+
+	select {
+	case shard <- job{value: value, done: done}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+// Close lets jobs that are already running finish and fails the queued ones
+// with ErrPoolClosed. Submit must not be called after Close.
+func (p *Pool) Close() {
+	p.once.Do(func() {
+		close(p.closing)
+		for _, shard := range p.shards {
+			close(shard)
+		}
+	})
+	p.wg.Wait()
+}
+
+func (p *Pool) work(shard chan job) {
+	defer p.wg.Done()
+
+	for j := range shard {
+		select {
+		case <-p.closing:
+			j.done(ErrPoolClosed)
+			continue
+		default:
+		}
+
+		if err := p.ctx.Err(); err != nil {
+			j.done(err)
+			continue
+		}
+		j.done(p.handle(j.value))
+	}
+}
+
+// handle retries transient failures until the handler settles, the pool is
+// closed or its context is done.
+func (p *Pool) handle(value []byte) error {
+	delay := p.retryDelay
+
+	for {
+		err := p.handler.HandleMessage(p.ctx, value)
+		if !IsTransient(err) {
+			return err
+		}
+
+		select {
+		case <-p.closing:
+			return ErrPoolClosed
+		case <-p.ctx.Done():
+			return p.ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, MaxRetryDelay)
+	}
+}
+
+func (p *Pool) shardIndex(key string) int {
+	if len(p.shards) == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(p.shards)))
+}
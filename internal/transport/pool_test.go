@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHandler struct {
+	mu   sync.Mutex
+	seen map[string][]string
+}
+
+func (h *recordingHandler) HandleMessage(ctx context.Context, value []byte) error {
+	var key, seq string
+	_, _ = fmt.Sscanf(string(value), "%s %s", &key, &seq)
+
+	time.Sleep(time.Millisecond)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seen[key] = append(h.seen[key], seq)
+	return nil
+}
+
+func TestPool_KeepsOrderPerKey(t *testing.T) {
+	t.Parallel()
+
+	h := &recordingHandler{seen: make(map[string][]string)}
+	p := NewPool(context.Background(), 4, h)
+
+	keys := []string{"o1", "o2", "o3", "o4", "o5"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for _, k := range keys {
+			value := []byte(fmt.Sprintf("%s %02d", k, i))
+			wg.Add(1)
+			require.NoError(t, p.Submit(context.Background(), k, value, func(err error) {
+				defer wg.Done()
+				require.NoError(t, err)
+			}))
+		}
+	}
+
+	wg.Wait()
+	p.Close()
+
+	for _, k := range keys {
+		require.Len(t, h.seen[k], 20)
+		for i, seq := range h.seen[k] {
+			require.Equal(t, fmt.Sprintf("%02d", i), seq)
+		}
+	}
+}
+
+type failingHandler struct {
+	err error
+}
+
+func (h failingHandler) HandleMessage(ctx context.Context, value []byte) error {
+	return h.err
+}
+
+func TestPool_ReportsHandlerError(t *testing.T) {
+	t.Parallel()
+
+	Err := errors.New("handle error")
+	p := NewPool(context.Background(), 2, failingHandler{err: Err})
+
+	got := make(chan error, 1)
+	require.NoError(t, p.Submit(context.Background(), "o1", []byte("o1 00"), func(err error) {
+		got <- err
+	}))
+
+	require.ErrorIs(t, <-got, Err)
+	p.Close()
+}
+
+func TestOffsetTracker_CommitsContiguousOnly(t *testing.T) {
+	t.Parallel()
+
+	tr := NewOffsetTracker()
+	tr.Add(10)
+	tr.Add(11)
+	tr.Add(12)
+
+	_, ok := tr.Done(11)
+	require.False(t, ok)
+
+	_, ok = tr.Done(12)
+	require.False(t, ok)
+
+	last, ok := tr.Done(10)
+	require.True(t, ok)
+	require.Equal(t, int64(12), last)
+}
+
+func TestOffsetTracker_InOrder(t *testing.T) {
+	t.Parallel()
+
+	tr := NewOffsetTracker()
+	tr.Add(1)
+	tr.Add(2)
+
+	last, ok := tr.Done(1)
+	require.True(t, ok)
+	require.Equal(t, int64(1), last)
+
+	last, ok = tr.Done(2)
+	require.True(t, ok)
+	require.Equal(t, int64(2), last)
+}
+
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h *blockingHandler) HandleMessage(ctx context.Context, value []byte) error {
+	h.started <- struct{}{}
+	<-h.release
+	return nil
+}
+
+func TestPool_CloseFinishesInFlightAndDropsQueued(t *testing.T) {
+	t.Parallel()
+
+	h := &blockingHandler{started: make(chan struct{}, 1), release: make(chan struct{})}
+	p := NewPool(context.Background(), 1, h)
+
+	results := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, p.Submit(context.Background(), "o1", []byte("o1"), func(err error) {
+			results <- err
+		}))
+	}
+
+	<-h.started
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+
+	<-p.closing
+	close(h.release)
+	<-closed
+
+	require.NoError(t, <-results)
+	require.ErrorIs(t, <-results, ErrPoolClosed)
+	require.ErrorIs(t, <-results, ErrPoolClosed)
+}
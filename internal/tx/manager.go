@@ -34,7 +34,15 @@ func (p *pgxTxManager) Begin(parent context.Context, withTx bool, fn func(ctx co
 		return err
 	}
 
-	tx, err := p.dbpool.Begin(parent)
+	// Inside a transaction fn runs under a savepoint, so its failure only
+	// undoes its own writes and the outer transaction decides what commits.
+	var tx pgx.Tx
+	var err error
+	if outer, ok := parent.Value(txKey{}).(pgx.Tx); ok && parent.Value(withTxKey{}) == true {
+		tx, err = outer.Begin(parent)
+	} else {
+		tx, err = p.dbpool.Begin(parent)
+	}
 
 	if err != nil {
 		return err
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE delivery
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'assigned',
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_delivery_status
+ON delivery (status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_delivery_status;
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
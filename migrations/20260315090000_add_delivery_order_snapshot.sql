@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE delivery
+    ADD COLUMN restaurant_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN address JSONB,
+    ADD COLUMN total_price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN items_count BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE delivery
+    DROP COLUMN IF EXISTS items_count,
+    DROP COLUMN IF EXISTS total_price,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS restaurant_id;
+-- +goose StatementEnd
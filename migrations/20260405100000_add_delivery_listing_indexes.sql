@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE INDEX IF NOT EXISTS idx_delivery_assigned_at_id
+ON delivery (assigned_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_delivery_courier_assigned_at_id
+ON delivery (courier_id, assigned_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP INDEX IF EXISTS idx_delivery_courier_assigned_at_id;
+DROP INDEX IF EXISTS idx_delivery_assigned_at_id;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+CREATE TABLE IF NOT EXISTS courier_events (
+    id             BIGSERIAL PRIMARY KEY,
+    type           TEXT        NOT NULL,
+    courier_id     BIGINT      NOT NULL,
+    courier_status TEXT        NOT NULL DEFAULT '',
+    transport_type TEXT        NOT NULL DEFAULT '',
+    order_id       TEXT        NOT NULL DEFAULT '',
+    deadline       TIMESTAMPTZ,
+    actor          TEXT        NOT NULL DEFAULT '',
+    actor_role     TEXT        NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_courier_events_created_at ON courier_events (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+DROP TABLE IF EXISTS courier_events;
+-- +goose StatementEnd
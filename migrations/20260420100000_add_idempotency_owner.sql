@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+ALTER TABLE idempotency_keys
+    ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS owner;
+-- +goose StatementEnd
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"os"
 	"time"
@@ -59,3 +60,48 @@ func InitDb(ctx context.Context) (*pgxpool.Pool, error) {
 
 	return nil, fmt.Errorf("db connect failed after %d attempts: %w", RetryAttempts, lastErr)
 }
+
+// MigrationVersion returns the schema version goose has applied. A version
+// that was migrated down no longer counts.
+func MigrationVersion(ctx context.Context, dbpool *pgxpool.Pool) (int64, error) {
+	const query = `
+		SELECT COALESCE(MAX(version_id), 0)
+		FROM (
+			SELECT DISTINCT ON (version_id) version_id, is_applied
+			FROM goose_db_version
+			ORDER BY version_id, id DESC
+		) v
+		WHERE is_applied`
+
+	var version int64
+	if err := dbpool.QueryRow(ctx, query).Scan(&version); err != nil {
+		return 0, fmt.Errorf("migration version: %w", err)
+	}
+	return version, nil
+}
+
+// Listen takes a connection out of dbpool, subscribes it to channel and calls
+// fn once subscribed and again after every notification, until ctx is done,
+// fn fails or the connection breaks. The connection is closed afterwards
+// instead of going back to the pool still subscribed.
+func Listen(ctx context.Context, dbpool *pgxpool.Pool, channel string, fn func(ctx context.Context) error) error {
+	pconn, err := dbpool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("listen acquire: %w", err)
+	}
+	conn := pconn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen %s: %w", channel, err)
+	}
+
+	for {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+	}
+}
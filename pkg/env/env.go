@@ -0,0 +1,81 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Reader parses optional settings and keeps the first error, so callers
+// can read everything and check once.
+type Reader struct {
+	err error
+}
+
+func (e *Reader) Err() error {
+	return e.err
+}
+
+func (e *Reader) String(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func (e *Reader) Bool(key string) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.Fail(key, err)
+	}
+	return b
+}
+
+func (e *Reader) Int32(key string, def int32) int32 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		e.Fail(key, err)
+		return def
+	}
+	if n < 0 {
+		e.Fail(key, fmt.Errorf("must not be negative"))
+		return def
+	}
+	return int32(n)
+}
+
+func (e *Reader) Int(key string, def int) int {
+	return int(e.Int32(key, int32(def)))
+}
+
+func (e *Reader) Duration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		e.Fail(key, err)
+		return def
+	}
+	if d <= 0 {
+		e.Fail(key, fmt.Errorf("must be positive"))
+		return def
+	}
+	return d
+}
+
+func (e *Reader) Fail(key string, err error) {
+	if e.err == nil {
+		e.err = fmt.Errorf("%s: %w", key, err)
+	}
+}
@@ -0,0 +1,78 @@
+package grpcclient
+
+import (
+	"course-go-avito-SitnikovArtem06/pkg/env"
+	"course-go-avito-SitnikovArtem06/pkg/tlsconfig"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	DefaultCallTimeout      = 3 * time.Second
+	DefaultRetryAttempts    = 3
+	DefaultKeepaliveTime    = 30 * time.Second
+	DefaultKeepaliveTimeout = 10 * time.Second
+)
+
+type OrderClientEnvConfig struct {
+	Addr          string
+	CallTimeout   time.Duration
+	RetryAttempts int
+}
+
+// InitOrderClient dials the order service from ORDER_GRPC_* variables. The
+// connection is lazy, so an unreachable server is only reported on calls.
+func InitOrderClient() (*grpc.ClientConn, *OrderClientEnvConfig, error) {
+	addr := os.Getenv("ORDER_GRPC_HOST")
+	if addr == "" {
+		return nil, nil, fmt.Errorf("ORDER_GRPC_HOST is required")
+	}
+
+	r := &env.Reader{}
+
+	cfg := &OrderClientEnvConfig{
+		Addr:          addr,
+		CallTimeout:   r.Duration("ORDER_GRPC_TIMEOUT", DefaultCallTimeout),
+		RetryAttempts: r.Int("ORDER_GRPC_RETRY_ATTEMPTS", DefaultRetryAttempts),
+	}
+
+	kp := keepalive.ClientParameters{
+		Time:                r.Duration("ORDER_GRPC_KEEPALIVE_TIME", DefaultKeepaliveTime),
+		Timeout:             r.Duration("ORDER_GRPC_KEEPALIVE_TIMEOUT", DefaultKeepaliveTimeout),
+		PermitWithoutStream: true,
+	}
+
+	if r.Err() != nil {
+		return nil, nil, r.Err()
+	}
+	if cfg.RetryAttempts < 1 {
+		return nil, nil, fmt.Errorf("ORDER_GRPC_RETRY_ATTEMPTS must be at least 1")
+	}
+
+	creds := insecure.NewCredentials()
+
+	tlsCfg, err := tlsconfig.Load("ORDER_GRPC")
+	if err != nil {
+		return nil, nil, err
+	}
+	if tlsCfg != nil {
+		creds = credentials.NewTLS(tlsCfg)
+	}
+
+	conn, err := grpc.NewClient(
+		addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(kp),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, cfg, nil
+}
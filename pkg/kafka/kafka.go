@@ -1,6 +1,8 @@
 ﻿package kafka
 
 import (
+	"course-go-avito-SitnikovArtem06/pkg/env"
+	"course-go-avito-SitnikovArtem06/pkg/tlsconfig"
 	"fmt"
 	"os"
 	"strings"
@@ -8,14 +10,21 @@ import (
 	"github.com/IBM/sarama"
 )
 
+const (
+	DefaultGroupID  = "service-courier-worker"
+	DefaultClientID = "service-courier"
+)
+
 type KafkaEnvConfig struct {
 	Brokers []string
 	Topic   string
+	GroupID string
 }
 
 func InitKafka() (KafkaEnvConfig, *sarama.Config, error) {
 	brokersRaw := os.Getenv("KAFKA_BROKERS")
 	topic := os.Getenv("KAFKA_ORDER_TOPIC")
+	groupID := os.Getenv("KAFKA_GROUP_ID")
 
 	if brokersRaw == "" || topic == "" {
 		return KafkaEnvConfig{}, nil, fmt.Errorf("KAFKA_BROKERS or KAFKA_ORDER_TOPIC is empty")
@@ -24,7 +33,7 @@ func InitKafka() (KafkaEnvConfig, *sarama.Config, error) {
 	parts := strings.Split(brokersRaw, ",")
 	brokers := make([]string, 0, len(parts))
 	for _, p := range parts {
-		if p != "" {
+		if p = strings.TrimSpace(p); p != "" {
 			brokers = append(brokers, p)
 		}
 	}
@@ -32,11 +41,85 @@ func InitKafka() (KafkaEnvConfig, *sarama.Config, error) {
 		return KafkaEnvConfig{}, nil, fmt.Errorf("KAFKA_BROKERS is empty after parsing")
 	}
 
-	cfg := sarama.NewConfig()
-	cfg.Consumer.Return.Errors = true
+	if groupID == "" {
+		groupID = DefaultGroupID
+	}
+
+	cfg, err := newSaramaConfig()
+	if err != nil {
+		return KafkaEnvConfig{}, nil, err
+	}
 
 	return KafkaEnvConfig{
 		Brokers: brokers,
 		Topic:   topic,
+		GroupID: groupID,
 	}, cfg, nil
 }
+
+func newSaramaConfig() (*sarama.Config, error) {
+	r := &env.Reader{}
+
+	cfg := sarama.NewConfig()
+	cfg.Consumer.Return.Errors = true
+
+	cfg.ClientID = r.String("KAFKA_CLIENT_ID", DefaultClientID)
+
+	if v := os.Getenv("KAFKA_VERSION"); v != "" {
+		version, err := sarama.ParseKafkaVersion(v)
+		if err != nil {
+			return nil, fmt.Errorf("KAFKA_VERSION: %w", err)
+		}
+		cfg.Version = version
+	}
+
+	switch offset := strings.ToLower(r.String("KAFKA_INITIAL_OFFSET", "newest")); offset {
+	case "newest":
+		cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	case "oldest":
+		cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	default:
+		return nil, fmt.Errorf("KAFKA_INITIAL_OFFSET: unknown policy %q, want newest or oldest", offset)
+	}
+
+	cfg.Consumer.Group.Session.Timeout = r.Duration("KAFKA_SESSION_TIMEOUT", cfg.Consumer.Group.Session.Timeout)
+	cfg.Consumer.Group.Heartbeat.Interval = r.Duration("KAFKA_HEARTBEAT_INTERVAL", cfg.Consumer.Group.Heartbeat.Interval)
+	cfg.Consumer.Group.Rebalance.Timeout = r.Duration("KAFKA_REBALANCE_TIMEOUT", cfg.Consumer.Group.Rebalance.Timeout)
+	cfg.Consumer.MaxWaitTime = r.Duration("KAFKA_FETCH_MAX_WAIT", cfg.Consumer.MaxWaitTime)
+
+	cfg.Consumer.Fetch.Min = r.Int32("KAFKA_FETCH_MIN_BYTES", cfg.Consumer.Fetch.Min)
+	cfg.Consumer.Fetch.Default = r.Int32("KAFKA_FETCH_DEFAULT_BYTES", cfg.Consumer.Fetch.Default)
+	cfg.Consumer.Fetch.Max = r.Int32("KAFKA_FETCH_MAX_BYTES", cfg.Consumer.Fetch.Max)
+
+	if r.Err() != nil {
+		return nil, r.Err()
+	}
+
+	if cfg.Consumer.Group.Heartbeat.Interval >= cfg.Consumer.Group.Session.Timeout {
+		return nil, fmt.Errorf("KAFKA_HEARTBEAT_INTERVAL must be lower than KAFKA_SESSION_TIMEOUT")
+	}
+	if cfg.Consumer.Fetch.Max > 0 && cfg.Consumer.Fetch.Default > cfg.Consumer.Fetch.Max {
+		return nil, fmt.Errorf("KAFKA_FETCH_DEFAULT_BYTES must not exceed KAFKA_FETCH_MAX_BYTES")
+	}
+	if cfg.Consumer.Fetch.Min > cfg.Consumer.Fetch.Default {
+		return nil, fmt.Errorf("KAFKA_FETCH_MIN_BYTES must not exceed KAFKA_FETCH_DEFAULT_BYTES")
+	}
+
+	tlsCfg, err := tlsconfig.Load("KAFKA")
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = tlsCfg
+	}
+	if err := configureSASL(cfg, r); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("kafka config: %w", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,96 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+func setBaseEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("KAFKA_BROKERS", "b1:9092, b2:9092")
+	t.Setenv("KAFKA_ORDER_TOPIC", "order.status.changed")
+}
+
+func TestInitKafka_Defaults(t *testing.T) {
+	setBaseEnv(t)
+
+	kcfg, cfg, err := InitKafka()
+	require.NoError(t, err)
+	require.Equal(t, []string{"b1:9092", "b2:9092"}, kcfg.Brokers)
+	require.Equal(t, DefaultGroupID, kcfg.GroupID)
+	require.Equal(t, DefaultClientID, cfg.ClientID)
+	require.Equal(t, sarama.OffsetNewest, cfg.Consumer.Offsets.Initial)
+	require.False(t, cfg.Net.TLS.Enable)
+	require.False(t, cfg.Net.SASL.Enable)
+}
+
+func TestInitKafka_Tuning(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("KAFKA_CLIENT_ID", "worker-1")
+	t.Setenv("KAFKA_VERSION", "3.6.0")
+	t.Setenv("KAFKA_INITIAL_OFFSET", "oldest")
+	t.Setenv("KAFKA_SESSION_TIMEOUT", "30s")
+	t.Setenv("KAFKA_HEARTBEAT_INTERVAL", "5s")
+	t.Setenv("KAFKA_FETCH_MIN_BYTES", "1")
+	t.Setenv("KAFKA_FETCH_DEFAULT_BYTES", "2097152")
+	t.Setenv("KAFKA_FETCH_MAX_BYTES", "4194304")
+
+	_, cfg, err := InitKafka()
+	require.NoError(t, err)
+	require.Equal(t, "worker-1", cfg.ClientID)
+	require.Equal(t, sarama.V3_6_0_0, cfg.Version)
+	require.Equal(t, sarama.OffsetOldest, cfg.Consumer.Offsets.Initial)
+	require.Equal(t, 30*time.Second, cfg.Consumer.Group.Session.Timeout)
+	require.Equal(t, 5*time.Second, cfg.Consumer.Group.Heartbeat.Interval)
+	require.Equal(t, int32(2097152), cfg.Consumer.Fetch.Default)
+	require.Equal(t, int32(4194304), cfg.Consumer.Fetch.Max)
+}
+
+func TestInitKafka_SCRAM(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("KAFKA_SASL_MECHANISM", "scram-sha-512")
+	t.Setenv("KAFKA_SASL_USERNAME", "courier")
+	t.Setenv("KAFKA_SASL_PASSWORD", "secret")
+
+	_, cfg, err := InitKafka()
+	require.NoError(t, err)
+	require.True(t, cfg.Net.SASL.Enable)
+	require.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), cfg.Net.SASL.Mechanism)
+	require.NotNil(t, cfg.Net.SASL.SCRAMClientGeneratorFunc)
+
+	client := cfg.Net.SASL.SCRAMClientGeneratorFunc()
+	require.NoError(t, client.Begin("courier", "secret", ""))
+	first, err := client.Step("")
+	require.NoError(t, err)
+	require.Contains(t, first, "n=courier")
+}
+
+func TestInitKafka_InvalidConfig(t *testing.T) {
+	cases := map[string]map[string]string{
+		"unknown offset policy": {"KAFKA_INITIAL_OFFSET": "latest"},
+		"bad version":           {"KAFKA_VERSION": "x.y"},
+		"bad duration":          {"KAFKA_SESSION_TIMEOUT": "soon"},
+		"heartbeat too long":    {"KAFKA_SESSION_TIMEOUT": "5s", "KAFKA_HEARTBEAT_INTERVAL": "10s"},
+		"fetch default > max":   {"KAFKA_FETCH_DEFAULT_BYTES": "10", "KAFKA_FETCH_MAX_BYTES": "5"},
+		"unknown mechanism":     {"KAFKA_SASL_MECHANISM": "GSSAPI", "KAFKA_SASL_USERNAME": "u", "KAFKA_SASL_PASSWORD": "p"},
+		"sasl without password": {"KAFKA_SASL_MECHANISM": "PLAIN", "KAFKA_SASL_USERNAME": "u"},
+		"plain without tls":     {"KAFKA_SASL_MECHANISM": "PLAIN", "KAFKA_SASL_USERNAME": "u", "KAFKA_SASL_PASSWORD": "p"},
+		"missing ca file":       {"KAFKA_TLS_ENABLED": "true", "KAFKA_TLS_CA_FILE": "/nonexistent/ca.pem"},
+		"cert without key":      {"KAFKA_TLS_ENABLED": "true", "KAFKA_TLS_CERT_FILE": "/tmp/cert.pem"},
+	}
+
+	for name, env := range cases {
+		t.Run(name, func(t *testing.T) {
+			setBaseEnv(t)
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+
+			_, _, err := InitKafka()
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/IBM/sarama"
+)
+
+// ErrCheckPending is reported while the first check is still running.
+var ErrCheckPending = errors.New("kafka: first check still running")
+
+// Probe checks that the brokers are reachable and know the topic. It keeps
+// one client between checks and opens it on first use, so that a broker
+// that is down at startup is reported rather than fatal.
+type Probe struct {
+	brokers []string
+	topic   string
+	cfg     *sarama.Config
+
+	mu     sync.Mutex
+	client sarama.Client
+	last   atomic.Pointer[error]
+}
+
+func NewProbe(brokers []string, topic string, cfg *sarama.Config) *Probe {
+	return &Probe{brokers: brokers, topic: topic, cfg: cfg}
+}
+
+// Check ignores ctx beyond an early return: sarama bounds its own calls with
+// the Net and Metadata timeouts of cfg. Only one check talks to the brokers
+// at a time; a check that arrives meanwhile returns the last result at once
+// instead of queueing behind it.
+func (p *Probe) Check(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !p.mu.TryLock() {
+		if last := p.last.Load(); last != nil {
+			return *last
+		}
+		return ErrCheckPending
+	}
+	defer p.mu.Unlock()
+
+	err := p.check()
+	p.last.Store(&err)
+	return err
+}
+
+func (p *Probe) check() error {
+	if p.client == nil {
+		client, err := sarama.NewClient(p.brokers, p.cfg)
+		if err != nil {
+			return fmt.Errorf("kafka connect: %w", err)
+		}
+		p.client = client
+	}
+
+	if err := p.client.RefreshMetadata(p.topic); err != nil {
+		return fmt.Errorf("kafka metadata: %w", err)
+	}
+	return nil
+}
+
+func (p *Probe) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.client == nil {
+		return nil
+	}
+	err := p.client.Close()
+	p.client = nil
+	return err
+}
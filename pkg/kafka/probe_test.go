@@ -0,0 +1,29 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbe_CheckInFlightReturnsLastResult(t *testing.T) {
+	t.Parallel()
+
+	p := NewProbe([]string{"localhost:0"}, "orders", nil)
+
+	// Another check holds the brokers.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	require.ErrorIs(t, p.Check(context.Background()), ErrCheckPending)
+
+	last := errors.New("kafka metadata: timeout")
+	p.last.Store(&last)
+	require.ErrorIs(t, p.Check(context.Background()), last)
+
+	var ok error
+	p.last.Store(&ok)
+	require.NoError(t, p.Check(context.Background()))
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"course-go-avito-SitnikovArtem06/pkg/env"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/xdg-go/scram"
+)
+
+func configureSASL(cfg *sarama.Config, r *env.Reader) error {
+	mechanism := strings.ToUpper(os.Getenv("KAFKA_SASL_MECHANISM"))
+	if mechanism == "" {
+		return nil
+	}
+
+	user := os.Getenv("KAFKA_SASL_USERNAME")
+	password := os.Getenv("KAFKA_SASL_PASSWORD")
+	if user == "" || password == "" {
+		return fmt.Errorf("KAFKA_SASL_USERNAME and KAFKA_SASL_PASSWORD are required for %s", mechanism)
+	}
+
+	cfg.Net.SASL.Enable = true
+	cfg.Net.SASL.Handshake = true
+	cfg.Net.SASL.User = user
+	cfg.Net.SASL.Password = password
+
+	switch mechanism {
+	case sarama.SASLTypePlaintext:
+		cfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case sarama.SASLTypeSCRAMSHA256:
+		cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGen: sha256.New}
+		}
+	case sarama.SASLTypeSCRAMSHA512:
+		cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+			return &scramClient{hashGen: sha512.New}
+		}
+	default:
+		return fmt.Errorf("KAFKA_SASL_MECHANISM: unsupported %q, want PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512", mechanism)
+	}
+
+	if !cfg.Net.TLS.Enable && mechanism == sarama.SASLTypePlaintext && !r.Bool("KAFKA_SASL_ALLOW_PLAINTEXT") {
+		return fmt.Errorf("SASL PLAIN without TLS sends the password in clear text, set KAFKA_TLS_ENABLED or KAFKA_SASL_ALLOW_PLAINTEXT")
+	}
+
+	return r.Err()
+}
+
+type scramClient struct {
+	hashGen scram.HashGeneratorFcn
+	conv    *scram.ClientConversation
+}
+
+func (c *scramClient) Begin(userName, password, authzID string) error {
+	client, err := c.hashGen.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.conv = client.NewConversation()
+	return nil
+}
+
+func (c *scramClient) Step(challenge string) (string, error) {
+	return c.conv.Step(challenge)
+}
+
+func (c *scramClient) Done() bool {
+	return c.conv.Done()
+}
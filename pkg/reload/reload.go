@@ -0,0 +1,111 @@
+package reload
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CheckInterval is how often Get looks at the files for changes.
+const CheckInterval = time.Second
+
+type stamp struct {
+	modTime int64
+	size    int64
+}
+
+// Value is parsed from one or more files and parsed again whenever one of
+// them changes on disk, so rotated credentials are picked up without a
+// restart. While a rotation is half-written and parsing fails, the last good
+// value is kept.
+type Value[T any] struct {
+	paths    []string
+	parse    func(data ...[]byte) (T, error)
+	interval time.Duration
+
+	value   atomic.Pointer[T]
+	checked atomic.Int64
+
+	mu     sync.Mutex
+	stamps []stamp
+}
+
+// NewValue loads the files once, so a broken configuration is reported at
+// startup rather than on the first request.
+func NewValue[T any](parse func(data ...[]byte) (T, error), paths ...string) (*Value[T], error) {
+	v := &Value[T]{paths: paths, parse: parse, interval: CheckInterval}
+
+	stamps, err := v.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := v.load(stamps); err != nil {
+		return nil, err
+	}
+	v.checked.Store(time.Now().UnixNano())
+
+	return v, nil
+}
+
+// Get returns the current value without blocking. At most once per
+// interval, one caller also checks the files and reloads them if they
+// changed; the others keep getting the current value meanwhile.
+func (v *Value[T]) Get() T {
+	now := time.Now().UnixNano()
+	if now-v.checked.Load() < int64(v.interval) || !v.mu.TryLock() {
+		return *v.value.Load()
+	}
+	defer v.mu.Unlock()
+
+	if now-v.checked.Load() >= int64(v.interval) {
+		v.checked.Store(now)
+		if stamps, err := v.stat(); err == nil && v.changed(stamps) {
+			_ = v.load(stamps)
+		}
+	}
+
+	return *v.value.Load()
+}
+
+func (v *Value[T]) stat() ([]stamp, error) {
+	stamps := make([]stamp, len(v.paths))
+	for i, path := range v.paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		stamps[i] = stamp{modTime: fi.ModTime().UnixNano(), size: fi.Size()}
+	}
+	return stamps, nil
+}
+
+func (v *Value[T]) changed(stamps []stamp) bool {
+	for i := range stamps {
+		if stamps[i] != v.stamps[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Value[T]) load(stamps []stamp) error {
+	data := make([][]byte, len(v.paths))
+	for i, path := range v.paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		data[i] = b
+	}
+
+	value, err := v.parse(data...)
+	if err != nil {
+		return fmt.Errorf("parse %v: %w", v.paths, err)
+	}
+
+	v.value.Store(&value)
+	v.stamps = stamps
+	return nil
+}
@@ -0,0 +1,95 @@
+package reload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func parse(data ...[]byte) (string, error) {
+	s := strings.TrimSpace(string(data[0]))
+	if s == "" {
+		return "", errors.New("empty")
+	}
+	return s, nil
+}
+
+func write(t *testing.T, path, content string, at time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(path, at, at))
+}
+
+func TestValue_ReloadsOnChange(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	t0 := time.Now().Add(-time.Hour)
+	write(t, path, "first", t0)
+
+	v, err := NewValue(parse, path)
+	require.NoError(t, err)
+	v.interval = 0
+	require.Equal(t, "first", v.Get())
+
+	write(t, path, "second", t0.Add(time.Minute))
+	require.Equal(t, "second", v.Get())
+}
+
+func TestValue_ChecksAtMostOncePerInterval(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	t0 := time.Now().Add(-time.Hour)
+	write(t, path, "first", t0)
+
+	v, err := NewValue(parse, path)
+	require.NoError(t, err)
+	v.interval = time.Hour
+
+	write(t, path, "second", t0.Add(time.Minute))
+	require.Equal(t, "first", v.Get(), "the files were checked less than an interval ago")
+
+	v.checked.Store(time.Now().Add(-time.Hour).UnixNano())
+	require.Equal(t, "second", v.Get())
+}
+
+func TestValue_KeepsLastGoodValue(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	t0 := time.Now().Add(-time.Hour)
+	write(t, path, "first", t0)
+
+	v, err := NewValue(parse, path)
+	require.NoError(t, err)
+	v.interval = 0
+
+	write(t, path, "", t0.Add(time.Minute))
+	require.Equal(t, "first", v.Get())
+
+	require.NoError(t, os.Remove(path))
+	require.Equal(t, "first", v.Get())
+
+	write(t, path, "third", t0.Add(2*time.Minute))
+	require.Equal(t, "third", v.Get())
+}
+
+func TestNewValue_FailsOnBadFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := NewValue(parse, filepath.Join(dir, "missing"))
+	require.Error(t, err)
+
+	path := filepath.Join(dir, "empty")
+	write(t, path, "", time.Now())
+	_, err = NewValue(parse, path)
+	require.Error(t, err)
+}
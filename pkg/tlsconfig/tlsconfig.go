@@ -0,0 +1,111 @@
+package tlsconfig
+
+import (
+	"course-go-avito-SitnikovArtem06/pkg/env"
+	"course-go-avito-SitnikovArtem06/pkg/reload"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Load builds a client TLS config from <prefix>_TLS_* variables. It returns
+// nil when <prefix>_TLS_ENABLED is not set. The CA and the client
+// certificate are re-read on handshakes after their files change, so they
+// can be rotated without a restart.
+func Load(prefix string) (*tls.Config, error) {
+	r := &env.Reader{}
+	enabled := r.Bool(prefix + "_TLS_ENABLED")
+	skipVerify := r.Bool(prefix + "_TLS_INSECURE_SKIP_VERIFY")
+	if r.Err() != nil {
+		return nil, r.Err()
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         os.Getenv(prefix + "_TLS_SERVER_NAME"),
+		InsecureSkipVerify: skipVerify,
+	}
+
+	caKey := prefix + "_TLS_CA_FILE"
+	if caFile := os.Getenv(caKey); caFile != "" {
+		ca, err := reload.NewValue(parseCA, caFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", caKey, err)
+		}
+		if !skipVerify {
+			// The standard verification only knows a fixed RootCAs pool, so
+			// it is replaced by one against the current CA.
+			tlsCfg.InsecureSkipVerify = true
+			serverName := tlsCfg.ServerName
+			tlsCfg.VerifyConnection = func(cs tls.ConnectionState) error {
+				return verify(cs, serverName, ca.Get())
+			}
+		}
+	}
+
+	certKey, keyKey := prefix+"_TLS_CERT_FILE", prefix+"_TLS_KEY_FILE"
+	certFile := os.Getenv(certKey)
+	keyFile := os.Getenv(keyKey)
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("%s and %s must be set together", certKey, keyKey)
+	}
+	if certFile != "" {
+		cert, err := reload.NewValue(parseKeyPair, certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %w", err)
+		}
+		tlsCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return cert.Get(), nil
+		}
+	}
+
+	return tlsCfg, nil
+}
+
+func parseCA(data ...[]byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data[0]) {
+		return nil, errors.New("no certificates found")
+	}
+	return pool, nil
+}
+
+func parseKeyPair(data ...[]byte) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(data[0], data[1])
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+// verify checks the server chain against roots. cs.ServerName is the SNI
+// name, which is empty when dialing an IP, so serverName must be configured
+// for such targets.
+func verify(cs tls.ConnectionState, serverName string, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: server sent no certificate")
+	}
+	if serverName == "" {
+		serverName = cs.ServerName
+	}
+	if serverName == "" {
+		return errors.New("tls: set a server name to verify the certificate of an IP address")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, c := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
@@ -0,0 +1,173 @@
+package tlsconfig
+
+import (
+	"course-go-avito-SitnikovArtem06/pkg/reload"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newAuthority(t *testing.T, name string) *authority {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &authority{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (a *authority) issue(t *testing.T, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, a.cert, &key.PublicKey, a.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeAt(t *testing.T, path string, data []byte, at time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, at, at))
+}
+
+func TestLoad_Disabled(t *testing.T) {
+	cfg, err := Load("TEST")
+	require.NoError(t, err)
+	require.Nil(t, cfg)
+}
+
+func TestLoad_MutualTLSWithRotation(t *testing.T) {
+	serverCA := newAuthority(t, "server-ca")
+	clientCA := newAuthority(t, "client-ca")
+
+	serverCert, serverKey := serverCA.issue(t, x509.ExtKeyUsageServerAuth)
+	pair, err := tls.X509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+
+	clientPool := x509.NewCertPool()
+	clientPool.AddCert(clientCA.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientCAs:    clientPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+
+	t0 := time.Now().Add(-time.Hour)
+	clientCert, clientKey := clientCA.issue(t, x509.ExtKeyUsageClientAuth)
+	writeAt(t, caFile, serverCA.pem, t0)
+	writeAt(t, certFile, clientCert, t0)
+	writeAt(t, keyFile, clientKey, t0)
+
+	t.Setenv("TEST_TLS_ENABLED", "true")
+	t.Setenv("TEST_TLS_SERVER_NAME", "localhost")
+	t.Setenv("TEST_TLS_CA_FILE", caFile)
+	t.Setenv("TEST_TLS_CERT_FILE", certFile)
+	t.Setenv("TEST_TLS_KEY_FILE", keyFile)
+
+	cfg, err := Load("TEST")
+	require.NoError(t, err)
+
+	get := func() error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg, DisableKeepAlives: true}}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get())
+
+	// Rotated files are picked up within reload.CheckInterval.
+	eventually := func(ok func(err error) bool) {
+		require.Eventually(t, func() bool { return ok(get()) }, 3*reload.CheckInterval, 50*time.Millisecond)
+	}
+	failing := func(err error) bool { return err != nil }
+	working := func(err error) bool { return err == nil }
+
+	// A client certificate from an unknown CA is rejected by the server, which
+	// proves the rotated file is used.
+	otherCert, otherKey := newAuthority(t, "other").issue(t, x509.ExtKeyUsageClientAuth)
+	writeAt(t, certFile, otherCert, t0.Add(time.Minute))
+	writeAt(t, keyFile, otherKey, t0.Add(time.Minute))
+	eventually(failing)
+
+	writeAt(t, certFile, clientCert, t0.Add(2*time.Minute))
+	writeAt(t, keyFile, clientKey, t0.Add(2*time.Minute))
+	eventually(working)
+
+	// Trusting a different CA makes the server certificate fail verification.
+	writeAt(t, caFile, clientCA.pem, t0.Add(3*time.Minute))
+	eventually(failing)
+}
+
+func TestLoad_CertWithoutKey(t *testing.T) {
+	t.Setenv("TEST_TLS_ENABLED", "true")
+	t.Setenv("TEST_TLS_CERT_FILE", "/tmp/cert.pem")
+
+	_, err := Load("TEST")
+	require.Error(t, err)
+}